@@ -0,0 +1,130 @@
+/*
+Copyright 2024 FleetForge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CellSpec defines the desired state of Cell
+type CellSpec struct {
+	// WorldRef is the name of the WorldSpec this cell belongs to
+	// +kubebuilder:validation:MinLength=1
+	WorldRef string `json:"worldRef"`
+	// Boundaries defines the spatial boundaries of this cell
+	Boundaries WorldBounds `json:"boundaries"`
+	// ParentID is the ID of the cell this cell was split from
+	// +optional
+	ParentID string `json:"parentId,omitempty"`
+	// SiblingIDs are the IDs of the other cells split from the same parent
+	// +optional
+	SiblingIDs []string `json:"siblingIds,omitempty"`
+	// ChildIDs are the IDs of the cells this cell was split into. A cell with
+	// children keeps its lineage but runs no pods until they are merged back.
+	// +optional
+	ChildIDs []string `json:"childIds,omitempty"`
+	// Generation is the generation level of this cell (0 for root, 1 for first split, etc.)
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Generation int32 `json:"generation,omitempty"`
+	// MergedInto is the ID of the cell this cell has been merged back into. Its
+	// pod hands its players off to that cell, after which the cell is deleted.
+	// +optional
+	MergedInto string `json:"mergedInto,omitempty"`
+}
+
+// IsSplit reports whether the cell has been split into children
+func (cs CellSpec) IsSplit() bool {
+	return len(cs.ChildIDs) > 0
+}
+
+// IsMerged reports whether the cell has been merged back into its parent
+func (cs CellSpec) IsMerged() bool {
+	return cs.MergedInto != ""
+}
+
+// CellObservedStatus defines the observed state of Cell
+type CellObservedStatus struct {
+	// Phase is the lifecycle phase of the cell: Pending, Running, Draining or
+	// Split. A split or merged cell is Draining while its pod hands players off
+	// to the cells taking over its space.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// Health indicates the health status of the cell's pod
+	// +optional
+	Health string `json:"health,omitempty"`
+	// Players is the current number of players in this cell
+	Players int32 `json:"players"`
+	// PodName is the name of the Kubernetes pod running this cell
+	// +optional
+	PodName string `json:"podName,omitempty"`
+	// LastHeartbeat is the time the cell's pod last reported its status
+	// +optional
+	LastHeartbeat *metav1.Time `json:"lastHeartbeat,omitempty"`
+	// Stale is set when the latest status query of the cell's pod failed, so
+	// Players and LastHeartbeat are from an earlier report
+	// +optional
+	Stale bool `json:"stale,omitempty"`
+	// SplitRequested is set while the cell's pod asks to be split because its
+	// density crossed the world's scale-up threshold
+	// +optional
+	SplitRequested bool `json:"splitRequested,omitempty"`
+	// PlayerPositions is a sample of the positions of the cell's players,
+	// reported by its pod so a split can balance the players between the children
+	// +optional
+	PlayerPositions []PlayerPosition `json:"playerPositions,omitempty"`
+}
+
+// PlayerPosition is a player's position in world coordinates
+type PlayerPosition struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:printcolumn:name="World",type="string",JSONPath=".spec.worldRef"
+//+kubebuilder:printcolumn:name="Parent",type="string",JSONPath=".spec.parentId"
+//+kubebuilder:printcolumn:name="Generation",type="integer",JSONPath=".spec.generation"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Players",type="integer",JSONPath=".status.players"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+//+genclient
+
+// Cell is the Schema for the cells API. Each cell of a world is one Cell
+// resource, owned by its WorldSpec.
+type Cell struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CellSpec           `json:"spec,omitempty"`
+	Status CellObservedStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CellList contains a list of Cell
+type CellList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Cell `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Cell{}, &CellList{})
+}
@@ -18,6 +18,12 @@ package v1
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -102,11 +108,106 @@ type WorldTopology struct {
 	MaxCellsPerCluster *int32 `json:"maxCellsPerCluster,omitempty"`
 	// WorldBoundaries defines the overall spatial boundaries of the world
 	WorldBoundaries WorldBounds `json:"worldBoundaries"`
-	// CellSize defines the preferred size of individual cells
+	// CellSize defines the preferred size of individual cells. When set, the
+	// initial grid has as many cells along each axis as are needed to keep cells
+	// no larger than CellSize, and InitialCells is ignored.
 	// +optional
 	CellSize *WorldBounds `json:"cellSize,omitempty"`
 }
 
+// MaxInitialCells is the largest initial partition a world may have
+const MaxInitialCells = 1000
+
+// GridDimensions returns the number of cells along the X, Y and Z axes of the
+// world's initial partition. Y and Z are only partitioned when the world has
+// bounds on them. Without a CellSize, or when CellSize would need more than
+// MaxInitialCells cells, InitialCells is factorised as close to a square (or
+// cube) as it allows, with the most cells along the longest axis.
+func (wt WorldTopology) GridDimensions() (cols, rows, layers int32) {
+	if x, y, z := wt.cellSizeGrid(); x*y*z > 0 && x*y*z <= MaxInitialCells {
+		return int32(x), int32(y), int32(z)
+	}
+
+	// Partitioned axes are indexes into counts (0=X, 1=Y, 2=Z), longest first
+	world := wt.WorldBoundaries
+	extents := []float64{world.XMax - world.XMin, 0, 0}
+	axes := []int{0}
+	if world.YMin != nil && world.YMax != nil {
+		extents[1] = *world.YMax - *world.YMin
+		axes = append(axes, 1)
+	}
+	if world.ZMin != nil && world.ZMax != nil {
+		extents[2] = *world.ZMax - *world.ZMin
+		axes = append(axes, 2)
+	}
+	sort.SliceStable(axes, func(i, j int) bool { return extents[axes[i]] > extents[axes[j]] })
+
+	// Factors come back largest first, so the longest axis gets the most cells
+	counts := [3]int32{1, 1, 1}
+	for i, factor := range nearCubeFactors(wt.InitialCells, len(axes)) {
+		counts[axes[i]] = factor
+	}
+	return counts[0], counts[1], counts[2]
+}
+
+// CellCount returns the number of cells in the world's initial partition
+func (wt WorldTopology) CellCount() int32 {
+	cols, rows, layers := wt.GridDimensions()
+	return cols * rows * layers
+}
+
+// cellSizeGrid returns how many cells of CellSize are needed along each axis
+// to cover the world, or zeros when CellSize is not set
+func (wt WorldTopology) cellSizeGrid() (cols, rows, layers float64) {
+	if wt.CellSize == nil {
+		return 0, 0, 0
+	}
+	world, size := wt.WorldBoundaries, wt.CellSize
+	cols, rows, layers = axisCells(world.XMax-world.XMin, size.XMax-size.XMin), 1, 1
+	if world.YMin != nil && world.YMax != nil && size.YMin != nil && size.YMax != nil {
+		rows = axisCells(*world.YMax-*world.YMin, *size.YMax-*size.YMin)
+	}
+	if world.ZMin != nil && world.ZMax != nil && size.ZMin != nil && size.ZMax != nil {
+		layers = axisCells(*world.ZMax-*world.ZMin, *size.ZMax-*size.ZMin)
+	}
+	return cols, rows, layers
+}
+
+// axisCells returns how many cells of the given size are needed to cover an extent
+func axisCells(extent, size float64) float64 {
+	if size <= 0 || extent <= 0 {
+		return 1
+	}
+	// Allow for float error so an exact fit does not add a sliver cell
+	return math.Max(1, math.Ceil(extent/size-1e-9))
+}
+
+// nearCubeFactors splits n into the given number of factors that are as close
+// to each other as n allows, largest first. Primes degrade to a single row.
+func nearCubeFactors(n int32, dims int) []int32 {
+	if n < 1 {
+		n = 1
+	}
+	if dims <= 1 {
+		return []int32{n}
+	}
+
+	// Pick the largest divisor no bigger than the dims-th root of n for one
+	// axis, then factorise the rest over the remaining axes
+	root := int32(math.Pow(float64(n), 1/float64(dims)) + 1e-9)
+	factor := int32(1)
+	for d := root; d >= 1; d-- {
+		if n%d == 0 {
+			factor = d
+			break
+		}
+	}
+
+	factors := append(nearCubeFactors(n/factor, dims-1), factor)
+	sort.Slice(factors, func(i, j int) bool { return factors[i] > factors[j] })
+	return factors
+}
+
 // CellCapacity defines resource and player limits for cells
 type CellCapacity struct {
 	// MaxPlayersPerCell is the maximum number of concurrent players per cell
@@ -141,6 +242,11 @@ type ScalingConfiguration struct {
 	ScaleDownThreshold float64 `json:"scaleDownThreshold"`
 	// PredictiveEnabled enables predictive scaling based on player behavior
 	PredictiveEnabled bool `json:"predictiveEnabled"`
+	// PredictionHorizon is how far ahead predictive scaling forecasts cell density;
+	// cells forecast to reach ScaleUpThreshold within it are split early. Defaults to 10m.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+[smh]$`
+	PredictionHorizon string `json:"predictionHorizon,omitempty"`
 	// MinCells is the minimum number of cells to maintain
 	// +optional
 	// +kubebuilder:validation:Minimum=1
@@ -149,8 +255,67 @@ type ScalingConfiguration struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=1
 	MaxCells *int32 `json:"maxCells,omitempty"`
+	// SplitStrategy selects how an overloaded cell's bounds are divided.
+	// Defaults to longestAxis.
+	// +optional
+	// +kubebuilder:validation:Enum=longestAxis;quad;octree;playerMedian
+	SplitStrategy string `json:"splitStrategy,omitempty"`
+	// SplitCooldown is the minimum time between splits of a cell. Cells that
+	// keep re-splitting back off exponentially from it. Defaults to 5m.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+[smh]$`
+	SplitCooldown string `json:"splitCooldown,omitempty"`
+	// SplitHysteresis is how far below ScaleUpThreshold a cell's density must
+	// fall before a threshold breach is considered over
+	// +optional
+	// +kubebuilder:validation:Minimum=0.0
+	// +kubebuilder:validation:Maximum=1.0
+	SplitHysteresis float64 `json:"splitHysteresis,omitempty"`
+	// SustainedBreach is how long a cell's density must stay above
+	// ScaleUpThreshold before it splits, so brief spikes don't cause splits.
+	// Defaults to splitting immediately.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+[smh]$`
+	SustainedBreach string `json:"sustainedBreach,omitempty"`
+	// MergeHysteresis is how long sibling cells must stay below ScaleDownThreshold
+	// before they are merged back into their parent. Defaults to 5m.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+[smh]$`
+	MergeHysteresis string `json:"mergeHysteresis,omitempty"`
 }
 
+// ParseSplitCooldown returns SplitCooldown as a duration (0 when unset)
+func (sc ScalingConfiguration) ParseSplitCooldown() (time.Duration, error) {
+	return parseDuration("splitCooldown", durationUnits, sc.SplitCooldown)
+}
+
+// ParseSustainedBreach returns SustainedBreach as a duration (0 when unset)
+func (sc ScalingConfiguration) ParseSustainedBreach() (time.Duration, error) {
+	return parseDuration("sustainedBreach", durationUnits, sc.SustainedBreach)
+}
+
+// ParsePredictionHorizon returns PredictionHorizon as a duration (0 when unset)
+func (sc ScalingConfiguration) ParsePredictionHorizon() (time.Duration, error) {
+	return parseDuration("predictionHorizon", durationUnits, sc.PredictionHorizon)
+}
+
+// ParseMergeHysteresis returns MergeHysteresis as a duration (0 when unset)
+func (sc ScalingConfiguration) ParseMergeHysteresis() (time.Duration, error) {
+	return parseDuration("mergeHysteresis", durationUnits, sc.MergeHysteresis)
+}
+
+// Split strategies for ScalingConfiguration.SplitStrategy
+const (
+	// SplitStrategyLongestAxis bisects a cell across its longest axis
+	SplitStrategyLongestAxis = "longestAxis"
+	// SplitStrategyQuad splits a 2D cell into four quadrants
+	SplitStrategyQuad = "quad"
+	// SplitStrategyOctree splits a 3D cell into eight octants
+	SplitStrategyOctree = "octree"
+	// SplitStrategyPlayerMedian bisects a cell across the axis its players are most spread along
+	SplitStrategyPlayerMedian = "playerMedian"
+)
+
 // PersistenceConfiguration defines data persistence behavior
 type PersistenceConfiguration struct {
 	// CheckpointInterval defines how often cell state is checkpointed
@@ -164,6 +329,59 @@ type PersistenceConfiguration struct {
 	RetentionPeriod string `json:"retentionPeriod"`
 	// Enabled controls whether persistence is active
 	Enabled bool `json:"enabled"`
+	// StateCodec selects how cell state is encoded in checkpoints.
+	// "binary" is more compact and keeps integer game state values as integers.
+	// +optional
+	// +kubebuilder:validation:Enum=json;binary
+	StateCodec string `json:"stateCodec,omitempty"`
+}
+
+// ParseCheckpointInterval returns CheckpointInterval as a duration (0 when unset)
+func (pc PersistenceConfiguration) ParseCheckpointInterval() (time.Duration, error) {
+	return parseDuration("checkpointInterval", durationUnits, pc.CheckpointInterval)
+}
+
+// ParseRetentionPeriod returns RetentionPeriod as a duration (0 when unset)
+func (pc PersistenceConfiguration) ParseRetentionPeriod() (time.Duration, error) {
+	return parseDuration("retentionPeriod", retentionUnits, pc.RetentionPeriod)
+}
+
+const (
+	// durationUnits are the units accepted by duration fields, matching their ^[0-9]+[smh]$ pattern
+	durationUnits = "smh"
+	// retentionUnits also accept days, matching the ^[0-9]+[smhd]$ pattern of retentionPeriod
+	retentionUnits = "smhd"
+)
+
+// parseDuration parses durations such as "30s", "5m", "12h" or "7d", accepting
+// only the given units
+func parseDuration(field, units, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	unit := value[len(value)-1]
+	amount, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+	if err != nil || amount < 0 || !strings.ContainsRune(units, rune(unit)) {
+		return 0, fmt.Errorf("invalid %s %q: expected a number followed by %s", field, value, unitList(units))
+	}
+
+	switch unit {
+	case 's':
+		return time.Duration(amount) * time.Second, nil
+	case 'm':
+		return time.Duration(amount) * time.Minute, nil
+	case 'h':
+		return time.Duration(amount) * time.Hour, nil
+	default:
+		return time.Duration(amount) * 24 * time.Hour, nil
+	}
+}
+
+// unitList spells out duration units for error messages, e.g. "s, m or h"
+func unitList(units string) string {
+	names := strings.Split(units, "")
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
 }
 
 // WorldSpecSpec defines the desired state of WorldSpec
@@ -179,6 +397,12 @@ type WorldSpecSpec struct {
 	// GameServerImage is the container image for cell game servers
 	// +kubebuilder:validation:MinLength=1
 	GameServerImage string `json:"gameServerImage"`
+	// TickRate is the number of simulation ticks per second each cell runs.
+	// Defaults to 20 when unset.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=128
+	// +optional
+	TickRate int32 `json:"tickRate,omitempty"`
 	// MultiClusterEnabled enables cross-cluster cell placement
 	// +optional
 	MultiClusterEnabled *bool `json:"multiClusterEnabled,omitempty"`
@@ -212,7 +436,8 @@ type WorldSpecStatus struct {
 	ActiveCells int32 `json:"activeCells"`
 	// TotalPlayers is the current total number of players across all cells
 	TotalPlayers int32 `json:"totalPlayers"`
-	// Cells contains status information for each cell
+	// Cells contains status information for each cell. The controller reports
+	// per-cell status on Cell resources instead, so this is left empty.
 	// +optional
 	Cells []CellStatus `json:"cells,omitempty"`
 	// Conditions represent the latest available observations of the world's state
@@ -240,8 +465,18 @@ func (ws *WorldSpecSpec) ValidateSpec() error {
 	}
 
 	// Validate cell size if provided
-	if ws.Topology.CellSize != nil && !ws.Topology.CellSize.IsValidBounds() {
-		return fmt.Errorf("invalid cell size: min values must be less than max values")
+	if ws.Topology.CellSize != nil {
+		if !ws.Topology.CellSize.IsValidBounds() {
+			return fmt.Errorf("invalid cell size: min values must be less than max values")
+		}
+		cols, rows, layers := ws.Topology.cellSizeGrid()
+		if cellCount := cols * rows * layers; cellCount > MaxInitialCells {
+			return fmt.Errorf("cellSize partitions the world into %.0f cells, more than the maximum of %d",
+				cellCount, MaxInitialCells)
+		} else if ws.Scaling.MaxCells != nil && cellCount > float64(*ws.Scaling.MaxCells) {
+			return fmt.Errorf("cellSize partitions the world into %.0f cells, more than maxCells (%d)",
+				cellCount, *ws.Scaling.MaxCells)
+		}
 	}
 
 	// Validate min/max cells relationship
@@ -263,6 +498,30 @@ func (ws *WorldSpecSpec) ValidateSpec() error {
 			ws.Topology.InitialCells, *ws.Scaling.MinCells)
 	}
 
+	// Validate split strategy if provided
+	switch ws.Scaling.SplitStrategy {
+	case "", SplitStrategyLongestAxis, SplitStrategyQuad, SplitStrategyOctree, SplitStrategyPlayerMedian:
+	default:
+		return fmt.Errorf("unknown splitStrategy %q", ws.Scaling.SplitStrategy)
+	}
+
+	if ws.Scaling.SplitHysteresis >= ws.Scaling.ScaleUpThreshold {
+		return fmt.Errorf("splitHysteresis (%f) must be less than scaleUpThreshold (%f)",
+			ws.Scaling.SplitHysteresis, ws.Scaling.ScaleUpThreshold)
+	}
+	if _, err := ws.Scaling.ParseSplitCooldown(); err != nil {
+		return err
+	}
+	if _, err := ws.Scaling.ParseSustainedBreach(); err != nil {
+		return err
+	}
+	if _, err := ws.Scaling.ParseMergeHysteresis(); err != nil {
+		return err
+	}
+	if _, err := ws.Scaling.ParsePredictionHorizon(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -126,6 +127,28 @@ func TestWorldSpecSpec_ValidateSpec(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "cell size yields too many cells",
+			spec: WorldSpecSpec{
+				Topology: WorldTopology{
+					InitialCells: 4,
+					WorldBoundaries: WorldBounds{
+						XMin: -1000.0,
+						XMax: 1000.0,
+					},
+					CellSize: &WorldBounds{
+						XMin: 0.0,
+						XMax: 1.0, // 2000 cells along X
+					},
+				},
+				Scaling: ScalingConfiguration{
+					ScaleUpThreshold:   0.8,
+					ScaleDownThreshold: 0.3,
+				},
+				GameServerImage: "example/game-server:latest",
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid min/max cells - min > max",
 			spec: WorldSpecSpec{
@@ -184,6 +207,25 @@ func TestWorldSpecSpec_ValidateSpec(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid merge hysteresis",
+			spec: WorldSpecSpec{
+				Topology: WorldTopology{
+					InitialCells: 4,
+					WorldBoundaries: WorldBounds{
+						XMin: -1000.0,
+						XMax: 1000.0,
+					},
+				},
+				Scaling: ScalingConfiguration{
+					ScaleUpThreshold:   0.8,
+					ScaleDownThreshold: 0.3,
+					MergeHysteresis:    "soon",
+				},
+				GameServerImage: "example/game-server:latest",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -358,6 +400,65 @@ func TestPersistenceConfiguration_Validation(t *testing.T) {
 	}
 }
 
+func TestPersistenceConfiguration_ParseDurations(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{value: "", expected: 0},
+		{value: "30s", expected: 30 * time.Second},
+		{value: "10m", expected: 10 * time.Minute},
+		{value: "12h", expected: 12 * time.Hour},
+		{value: "7d", expected: 7 * 24 * time.Hour},
+		{value: "7w", wantErr: true},
+		{value: "d", wantErr: true},
+		{value: "-1h", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			config := PersistenceConfiguration{RetentionPeriod: tt.value}
+			got, err := config.ParseRetentionPeriod()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRetentionPeriod(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseRetentionPeriod(%q) = %v, want %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScalingConfiguration_ParseDurations(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{value: "", expected: 0},
+		{value: "30s", expected: 30 * time.Second},
+		{value: "10m", expected: 10 * time.Minute},
+		{value: "2h", expected: 2 * time.Hour},
+		// Scaling durations follow the CRD's ^[0-9]+[smh]$ pattern
+		{value: "1d", wantErr: true},
+		{value: "m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			config := ScalingConfiguration{SplitCooldown: tt.value}
+			got, err := config.ParseSplitCooldown()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSplitCooldown(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseSplitCooldown(%q) = %v, want %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
 func TestWorldBounds_Area(t *testing.T) {
 	wb := WorldBounds{
 		XMin: -100.0,
@@ -25,6 +25,33 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Cell) DeepCopyInto(out *Cell) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cell.
+func (in *Cell) DeepCopy() *Cell {
+	if in == nil {
+		return nil
+	}
+	out := new(Cell)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Cell) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CellCapacity) DeepCopyInto(out *CellCapacity) {
 	*out = *in
@@ -50,6 +77,88 @@ func (in *CellCapacity) DeepCopy() *CellCapacity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CellList) DeepCopyInto(out *CellList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Cell, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CellList.
+func (in *CellList) DeepCopy() *CellList {
+	if in == nil {
+		return nil
+	}
+	out := new(CellList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CellList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CellObservedStatus) DeepCopyInto(out *CellObservedStatus) {
+	*out = *in
+	if in.LastHeartbeat != nil {
+		in, out := &in.LastHeartbeat, &out.LastHeartbeat
+		*out = (*in).DeepCopy()
+	}
+	if in.PlayerPositions != nil {
+		in, out := &in.PlayerPositions, &out.PlayerPositions
+		*out = make([]PlayerPosition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CellObservedStatus.
+func (in *CellObservedStatus) DeepCopy() *CellObservedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CellObservedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CellSpec) DeepCopyInto(out *CellSpec) {
+	*out = *in
+	in.Boundaries.DeepCopyInto(&out.Boundaries)
+	if in.SiblingIDs != nil {
+		in, out := &in.SiblingIDs, &out.SiblingIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChildIDs != nil {
+		in, out := &in.ChildIDs, &out.ChildIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CellSpec.
+func (in *CellSpec) DeepCopy() *CellSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CellSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CellStatus) DeepCopyInto(out *CellStatus) {
 	*out = *in
@@ -95,6 +204,21 @@ func (in *PersistenceConfiguration) DeepCopy() *PersistenceConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlayerPosition) DeepCopyInto(out *PlayerPosition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlayerPosition.
+func (in *PlayerPosition) DeepCopy() *PlayerPosition {
+	if in == nil {
+		return nil
+	}
+	out := new(PlayerPosition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalingConfiguration) DeepCopyInto(out *ScalingConfiguration) {
 	*out = *in
@@ -18,17 +18,24 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"math"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	fleetforgev1 "github.com/astrosteveo/fleetforge/api/v1"
 	"github.com/astrosteveo/fleetforge/pkg/cell"
+	"github.com/astrosteveo/fleetforge/pkg/gateway"
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -46,9 +53,24 @@ func main() {
 		xMax        = flag.Float64("x-max", getEnvFloat64("BOUNDARIES_X_MAX", 500.0), "Maximum X coordinate for cell boundaries")
 		yMin        = flag.Float64("y-min", getEnvFloat64("BOUNDARIES_Y_MIN", -500.0), "Minimum Y coordinate for cell boundaries")
 		yMax        = flag.Float64("y-max", getEnvFloat64("BOUNDARIES_Y_MAX", 500.0), "Maximum Y coordinate for cell boundaries")
+		zMin        = flag.Float64("z-min", getEnvFloat64("BOUNDARIES_Z_MIN", math.NaN()), "Minimum Z coordinate for cell boundaries (2D when unset)")
+		zMax        = flag.Float64("z-max", getEnvFloat64("BOUNDARIES_Z_MAX", math.NaN()), "Maximum Z coordinate for cell boundaries (2D when unset)")
+		tickRate    = flag.Int("tick-rate", getEnvInt("TICK_RATE", 20), "Simulation ticks per second")
 		maxPlayers  = flag.Int("max-players", getEnvInt("MAX_PLAYERS", 100), "Maximum number of players this cell can handle")
 		healthPort  = flag.Int("health-port", getEnvInt("HEALTH_PORT", 8081), "Port for health check endpoint")
 		metricsPort = flag.Int("metrics-port", getEnvInt("METRICS_PORT", 8080), "Port for metrics endpoint")
+
+		handoffToken = flag.String("handoff-token", getEnvString("HANDOFF_TOKEN", ""), "Bearer token the controller presents to request a player handoff (handoffs are refused when empty)")
+
+		checkpointDir      = flag.String("checkpoint-dir", getEnvString("CHECKPOINT_DIR", ""), "Directory for persistent checkpoints (disabled when empty)")
+		checkpointInterval = flag.String("checkpoint-interval", getEnvString("CHECKPOINT_INTERVAL", "30s"), "How often cell state is checkpointed (e.g. 30s, 5m)")
+		retentionPeriod    = flag.String("retention-period", getEnvString("RETENTION_PERIOD", "7d"), "How long to retain checkpoints (e.g. 12h, 7d)")
+		splitHysteresis    = flag.Float64("split-hysteresis", getEnvFloat64("SPLIT_HYSTERESIS", 0), "How far below the split threshold density must fall to end a breach")
+		sustainedBreach    = flag.String("sustained-breach", getEnvString("SUSTAINED_BREACH", "0s"), "How long density must stay above the split threshold before a split is requested (e.g. 30s)")
+		predictive         = flag.Bool("predictive-scaling", getEnvBool("PREDICTIVE_SCALING", false), "Request a split early when density is forecast to reach the scale-up threshold")
+		scaleUpThreshold   = flag.Float64("scale-up-threshold", getEnvFloat64("SCALE_UP_THRESHOLD", 0.8), "Density at which the cell requests a split")
+		predictionHorizon  = flag.String("prediction-horizon", getEnvString("PREDICTION_HORIZON", "10m"), "How far ahead density is forecast for predictive scaling (e.g. 90s, 10m)")
+		stateCodec         = flag.String("state-codec", getEnvString("STATE_CODEC", cell.StateCodecJSON), "Checkpoint state encoding (json or binary)")
 	)
 
 	opts := zap.Options{
@@ -72,14 +94,82 @@ func main() {
 		YMin: yMin,
 		YMax: yMax,
 	}
+	if !math.IsNaN(*zMin) && !math.IsNaN(*zMax) {
+		boundaries.ZMin = zMin
+		boundaries.ZMax = zMax
+	}
 
-	// Create and start cell simulator
+	// Create and start cell simulator. Splits are requested in the cell's status
+	// and made by the controller, which owns the world's cells.
 	cellSim := cell.NewCellSimulator(*cellID, boundaries, int32(*maxPlayers), setupLog)
 
+	splitTrigger := fleetforgev1.ScalingConfiguration{SustainedBreach: *sustainedBreach}
+	breach, err := splitTrigger.ParseSustainedBreach()
+	if err != nil {
+		setupLog.Error(err, "invalid split configuration")
+		os.Exit(1)
+	}
+	cellSim.SetSplitTrigger(*splitHysteresis, breach)
+
+	cellSim.SetSplitThreshold(*scaleUpThreshold)
+	cellSim.SetTickRate(*tickRate)
+
+	if *predictive {
+		scaling := fleetforgev1.ScalingConfiguration{
+			ScaleUpThreshold:  *scaleUpThreshold,
+			PredictiveEnabled: true,
+			PredictionHorizon: *predictionHorizon,
+		}
+		horizon, err := scaling.ParsePredictionHorizon()
+		if err != nil {
+			setupLog.Error(err, "invalid predictive scaling configuration")
+			os.Exit(1)
+		}
+		cellSim.SetPredictiveScaling(*scaleUpThreshold, horizon)
+	}
+
+	if err := cellSim.SetStateCodec(*stateCodec); err != nil {
+		setupLog.Error(err, "invalid state codec")
+		os.Exit(1)
+	}
+
+	if *checkpointDir != "" {
+		persistence := fleetforgev1.PersistenceConfiguration{
+			CheckpointInterval: *checkpointInterval,
+			RetentionPeriod:    *retentionPeriod,
+			Enabled:            true,
+		}
+
+		interval, err := persistence.ParseCheckpointInterval()
+		if err != nil {
+			setupLog.Error(err, "invalid checkpoint configuration")
+			os.Exit(1)
+		}
+		retention, err := persistence.ParseRetentionPeriod()
+		if err != nil {
+			setupLog.Error(err, "invalid checkpoint configuration")
+			os.Exit(1)
+		}
+
+		store, err := cell.NewLocalCheckpointStore(*checkpointDir, retention)
+		if err != nil {
+			setupLog.Error(err, "unable to create checkpoint store")
+			os.Exit(1)
+		}
+		cellSim.SetCheckpointStore(store, interval)
+
+		setupLog.Info("Checkpoint persistence enabled",
+			"dir", *checkpointDir,
+			"interval", interval,
+			"retention", retention,
+		)
+	}
+
 	setupLog.Info("Starting FleetForge Cell Simulator",
 		"cellID", *cellID,
 		"boundaries", boundaries,
 		"maxPlayers", *maxPlayers,
+		"tickRate", *tickRate,
 		"healthPort", *healthPort,
 		"metricsPort", *metricsPort,
 	)
@@ -90,7 +180,7 @@ func main() {
 	}
 
 	// Start health check server
-	go startHealthServer(*healthPort, cellSim, setupLog)
+	go startHealthServer(*healthPort, cellSim, *handoffToken, setupLog)
 
 	// Start Prometheus metrics server
 	go startMetricsServer(*metricsPort, cellSim, setupLog)
@@ -111,7 +201,21 @@ func main() {
 }
 
 // startHealthServer starts the health check HTTP server
-func startHealthServer(port int, cellSim *cell.CellSimulator, logger logr.Logger) {
+func startHealthServer(port int, cellSim *cell.CellSimulator, handoffToken string, logger logr.Logger) {
+	server := &http.Server{
+		Addr:    ":" + strconv.Itoa(port),
+		Handler: newHealthMux(cellSim, newPlayerLinks(), handoffToken, logger),
+	}
+
+	logger.Info("Starting health server", "port", port)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		logger.Error(err, "Health server failed")
+	}
+}
+
+// newHealthMux serves the cell's health, status and handoff endpoints and the
+// player links proxied by the gateway. Handoffs must present handoffToken.
+func newHealthMux(cellSim *cell.CellSimulator, links *playerLinks, handoffToken string, logger logr.Logger) *http.ServeMux {
 	mux := http.NewServeMux()
 
 	// Health check endpoint
@@ -152,54 +256,274 @@ func startHealthServer(port int, cellSim *cell.CellSimulator, logger logr.Logger
 
 	// Status endpoint with detailed information
 	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
-		status := cellSim.GetStatus()
-		boundaries := cellSim.GetBoundaries()
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
+		writeStatus(w, cellSim)
+	})
 
-		yMinVal := 0.0
-		yMaxVal := 0.0
-		if boundaries.YMin != nil {
-			yMinVal = *boundaries.YMin
+	// Once the controller has split or merged the cell and the cells taking over
+	// its space are serving, it asks for the players to be handed off to them.
+	// It keeps asking until the cell is empty, so handoffs the gateway could not
+	// apply yet are sent again.
+	mux.HandleFunc("/handoff", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
 		}
-		if boundaries.YMax != nil {
-			yMaxVal = *boundaries.YMax
+		if !authorizedHandoff(r, handoffToken) {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
 		}
 
-		// Use proper JSON marshaling for complex structure
-		fmt.Fprintf(w, `{
-			"id": "%s",
-			"health": "%s", 
-			"currentPlayers": %v,
-			"maxPlayers": %v,
-			"ready": %v,
-			"boundaries": {
-				"xMin": %f,
-				"xMax": %f,
-				"yMin": %f,
-				"yMax": %f
-			}
-		}`,
-			status["id"],
-			status["health"],
-			status["currentPlayers"],
-			status["maxPlayers"],
-			status["ready"],
-			boundaries.XMin,
-			boundaries.XMax,
-			yMinVal,
-			yMaxVal,
-		)
+		var request struct {
+			Cells []cell.HandoffTarget `json:"cells"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("invalid handoff request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		reassignments, err := cellSim.HandOff(request.Cells)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		links.handoff(reassignments)
+		logger.Info("Handing players off", "players", len(reassignments), "cells", len(request.Cells))
+
+		writeStatus(w, cellSim)
 	})
 
-	server := &http.Server{
-		Addr:    ":" + strconv.Itoa(port),
-		Handler: mux,
+	// Player links proxied by the gateway
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		servePlayerLink(w, r, cellSim, links, logger)
+	})
+
+	return mux
+}
+
+// authorizedHandoff reports whether a handoff request presents the cell's
+// handoff token. A cell without a token accepts no handoffs.
+func authorizedHandoff(r *http.Request, token string) bool {
+	presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && token != "" && subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1
+}
+
+// writeStatus writes the cell's status report
+func writeStatus(w http.ResponseWriter, cellSim *cell.CellSimulator) {
+	status := cellSim.GetStatus()
+	boundaries := cellSim.GetBoundaries()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	yMinVal := 0.0
+	yMaxVal := 0.0
+	if boundaries.YMin != nil {
+		yMinVal = *boundaries.YMin
+	}
+	if boundaries.YMax != nil {
+		yMaxVal = *boundaries.YMax
 	}
 
-	logger.Info("Starting health server", "port", port)
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		logger.Error(err, "Health server failed")
+	// The controller places split cuts from this sample of player positions
+	positions, err := json.Marshal(status["playerPositions"])
+	if err != nil {
+		positions = []byte("[]")
+	}
+
+	// Use proper JSON marshaling for complex structure
+	fmt.Fprintf(w, `{
+		"id": "%s",
+		"health": "%s", 
+		"currentPlayers": %v,
+		"maxPlayers": %v,
+		"ready": %v,
+		"splitRequested": %v,
+		"playerPositions": %s,
+		"boundaries": {
+			"xMin": %f,
+			"xMax": %f,
+			"yMin": %f,
+			"yMax": %f
+		}
+	}`,
+		status["id"],
+		status["health"],
+		status["currentPlayers"],
+		status["maxPlayers"],
+		status["ready"],
+		status["splitRequested"] == true,
+		positions,
+		boundaries.XMin,
+		boundaries.XMax,
+		yMinVal,
+		yMaxVal,
+	)
+}
+
+// servePlayerLink serves a player's link from the gateway. The player is in the
+// cell for as long as the link is open and moves with the positions in their
+// gameplay messages.
+func servePlayerLink(w http.ResponseWriter, r *http.Request, cellSim *cell.CellSimulator, links *playerLinks, logger logr.Logger) {
+	playerID := r.URL.Query().Get("playerId")
+	if playerID == "" {
+		http.Error(w, "playerId is required", http.StatusBadRequest)
+		return
+	}
+
+	ws, err := gateway.Upgrade(w, r)
+	if err != nil {
+		logger.V(1).Info("Player link upgrade failed", "playerID", playerID, "error", err.Error())
+		return
+	}
+	defer ws.Close(gateway.CloseNormalClosure, "")
+
+	// New players start at the center of the cell until they report a position;
+	// players handed off by another cell keep the position they had there
+	boundaries := cellSim.GetBoundaries()
+	spawn := cell.WorldPosition{X: (boundaries.XMin + boundaries.XMax) / 2}
+	if boundaries.YMin != nil && boundaries.YMax != nil {
+		spawn.Y = (*boundaries.YMin + *boundaries.YMax) / 2
+	}
+	if position, ok := handedOffPosition(r.URL.Query(), boundaries); ok {
+		spawn = position
+	}
+	if err := cellSim.AddPlayer(playerID, spawn); err != nil {
+		ws.Close(gateway.CloseTryAgainLater, err.Error())
+		return
+	}
+	defer cellSim.RemovePlayer(playerID)
+
+	link := links.add(playerID, ws)
+	defer links.remove(playerID, link)
+
+	// A player who joins after the handoff is sent on to the children as well
+	if reassignment, handedOff := cellSim.HandoffFor(playerID); handedOff {
+		links.handoff([]cell.PlayerReassignment{reassignment})
+	}
+
+	for {
+		messageType, data, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		if messageType != gateway.TextMessage {
+			continue
+		}
+
+		var msg gateway.WSMessage
+		if err := json.Unmarshal(data, &msg); err != nil || msg.Type != gateway.WSMessageGameplay {
+			continue
+		}
+		var update struct {
+			Position *cell.WorldPosition `json:"position"`
+		}
+		if err := json.Unmarshal(msg.Payload, &update); err != nil || update.Position == nil {
+			continue
+		}
+
+		reply := gateway.WSMessage{Type: gateway.WSMessageGameplay, Payload: msg.Payload}
+		if err := cellSim.UpdatePlayerPosition(playerID, *update.Position); err != nil {
+			reply = gateway.WSMessage{Type: gateway.WSMessageError, Error: err.Error()}
+		}
+		if err := link.send(reply); err != nil {
+			return
+		}
+	}
+}
+
+// handedOffPosition returns the position a player handed off by another cell
+// had there, passed by the gateway as the x and y query parameters, when it
+// lies within the cell
+func handedOffPosition(query url.Values, boundaries fleetforgev1.WorldBounds) (cell.WorldPosition, bool) {
+	x, errX := strconv.ParseFloat(query.Get("x"), 64)
+	y, errY := strconv.ParseFloat(query.Get("y"), 64)
+	if errX != nil || errY != nil || math.IsNaN(x) || math.IsNaN(y) {
+		return cell.WorldPosition{}, false
+	}
+	if x < boundaries.XMin || x > boundaries.XMax {
+		return cell.WorldPosition{}, false
+	}
+	if (boundaries.YMin != nil && y < *boundaries.YMin) || (boundaries.YMax != nil && y > *boundaries.YMax) {
+		return cell.WorldPosition{}, false
+	}
+	return cell.WorldPosition{X: x, Y: y}, true
+}
+
+// linkWriteTimeout bounds a write to a player's link, so a stalled gateway
+// cannot hold up the cell
+const linkWriteTimeout = 5 * time.Second
+
+// playerLink is a player's link from the gateway. Gameplay replies and
+// handoffs are written from different goroutines, so writes are serialized.
+type playerLink struct {
+	ws      *gateway.WSConn
+	writeMu sync.Mutex
+}
+
+// send writes a message to the player's link
+func (l *playerLink) send(msg gateway.WSMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	l.ws.SetWriteDeadline(time.Now().Add(linkWriteTimeout))
+	return l.ws.WriteMessage(gateway.TextMessage, data)
+}
+
+// playerLinks tracks the gateway link of each player in the cell
+type playerLinks struct {
+	mu    sync.Mutex
+	links map[string]*playerLink
+}
+
+func newPlayerLinks() *playerLinks {
+	return &playerLinks{links: make(map[string]*playerLink)}
+}
+
+func (l *playerLinks) add(playerID string, ws *gateway.WSConn) *playerLink {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	link := &playerLink{ws: ws}
+	l.links[playerID] = link
+	return link
+}
+
+func (l *playerLinks) remove(playerID string, link *playerLink) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.links[playerID] == link {
+		delete(l.links, playerID)
+	}
+}
+
+// handoff tells the gateway which cell each reassigned player moved to, and
+// where they were, so it re-routes their connection before this cell stops
+// serving them
+func (l *playerLinks) handoff(reassignments []cell.PlayerReassignment) {
+	l.mu.Lock()
+	links := make([]*playerLink, len(reassignments))
+	for i, reassignment := range reassignments {
+		links[i] = l.links[string(reassignment.PlayerID)]
+	}
+	l.mu.Unlock()
+
+	// Links are written outside the lock so players can keep joining and
+	// leaving; a failed write is retried with the next handoff request
+	for i, reassignment := range reassignments {
+		if links[i] == nil {
+			continue
+		}
+		payload, _ := json.Marshal(struct {
+			Position cell.WorldPosition `json:"position"`
+		}{Position: reassignment.Position})
+		links[i].send(gateway.WSMessage{
+			Type:     gateway.WSMessageHandoff,
+			PlayerID: reassignment.PlayerID,
+			CellID:   reassignment.ToCellID,
+			Payload:  payload,
+		})
 	}
 }
 
@@ -248,6 +572,16 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvBool gets a boolean from environment variable with fallback default
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
 // getEnvFloat64 gets a float64 from environment variable with fallback default
 func getEnvFloat64(key string, defaultValue float64) float64 {
 	if value := os.Getenv(key); value != "" {
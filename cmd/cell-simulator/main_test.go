@@ -0,0 +1,336 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetforgev1 "github.com/astrosteveo/fleetforge/api/v1"
+	"github.com/astrosteveo/fleetforge/pkg/cell"
+	"github.com/astrosteveo/fleetforge/pkg/controllers"
+	"github.com/astrosteveo/fleetforge/pkg/gateway"
+)
+
+// startCellPod runs a cell simulator behind its health server, as the pod of a
+// cell with the token from its handoff secret
+func startCellPod(t *testing.T, c client.Client, cellID string, boundaries fleetforgev1.WorldBounds, maxPlayers int32) (*cell.CellSimulator, *gateway.CellInfo) {
+	t.Helper()
+
+	cellSim := cell.NewCellSimulator(cellID, boundaries, maxPlayers, logr.Discard())
+	cellSim.SetSplitThreshold(0.5)
+	cellSim.SetTickRate(100)
+	if err := cellSim.Start(); err != nil {
+		t.Fatalf("Failed to start cell %s: %v", cellID, err)
+	}
+	t.Cleanup(func() { cellSim.Stop() })
+
+	// The gateway only routes to cells whose pods passed their readiness probe
+	waitFor(t, "cell "+cellID+" to be ready", func() bool {
+		return cellSim.GetHealth().Healthy
+	})
+
+	secret := &corev1.Secret{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: cellID + "-handoff", Namespace: "default"}, secret); err != nil {
+		t.Fatalf("Failed to get the handoff secret of cell %s: %v", cellID, err)
+	}
+
+	server := httptest.NewServer(newHealthMux(cellSim, newPlayerLinks(), string(secret.Data["token"]), logr.Discard()))
+	t.Cleanup(server.Close)
+
+	addr := server.Listener.Addr().(*net.TCPAddr)
+	return cellSim, &gateway.CellInfo{ID: cell.CellID(cellID), Address: addr.IP.String(), Port: addr.Port, Healthy: true, Capacity: int(maxPlayers)}
+}
+
+// readUntil reads messages from the player's connection until one of the given type arrives
+func readUntil(t *testing.T, ws *gateway.WSConn, messageType string) gateway.WSMessage {
+	t.Helper()
+
+	ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		_, data, err := ws.ReadMessage()
+		if err != nil {
+			t.Fatalf("Failed to read a %s message: %v", messageType, err)
+		}
+		var msg gateway.WSMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.Fatalf("Failed to decode message %s: %v", data, err)
+		}
+		if msg.Type == messageType {
+			return msg
+		}
+	}
+}
+
+func sendPosition(t *testing.T, ws *gateway.WSConn, position cell.WorldPosition) {
+	t.Helper()
+
+	payload, _ := json.Marshal(map[string]cell.WorldPosition{"position": position})
+	data, _ := json.Marshal(gateway.WSMessage{Type: gateway.WSMessageGameplay, Payload: payload})
+	if err := ws.WriteMessage(gateway.TextMessage, data); err != nil {
+		t.Fatalf("Failed to send position: %v", err)
+	}
+}
+
+func waitFor(t *testing.T, description string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", description)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// TestSplitHandsPlayersOffThroughGateway follows a split from the pod asking
+// for it, through the controller splitting the cell and starting its children,
+// to the gateway moving the player over to the child that took them
+func TestSplitHandsPlayersOffThroughGateway(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	yMin := -1000.0
+	yMax := 1000.0
+	worldSpec := &fleetforgev1.WorldSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "e2e-world", Namespace: "default"},
+		Spec: fleetforgev1.WorldSpecSpec{
+			Topology: fleetforgev1.WorldTopology{
+				InitialCells: 1,
+				WorldBoundaries: fleetforgev1.WorldBounds{
+					XMin: -1000.0,
+					XMax: 1000.0,
+					YMin: &yMin,
+					YMax: &yMax,
+				},
+			},
+			Capacity:        fleetforgev1.CellCapacity{MaxPlayersPerCell: 2},
+			GameServerImage: "fleetforge-cell:latest",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(worldSpec).
+		WithStatusSubresource(&fleetforgev1.WorldSpec{}, &fleetforgev1.Cell{}).
+		Build()
+
+	worldReconciler := &controllers.WorldSpecReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: record.NewFakeRecorder(100),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	worldReq := ctrl.Request{NamespacedName: client.ObjectKey{Name: "e2e-world", Namespace: "default"}}
+	if _, err := worldReconciler.Reconcile(ctx, worldReq); err != nil {
+		t.Fatalf("WorldSpec reconcile failed: %v", err)
+	}
+
+	scraper := controllers.NewHTTPCellStatusScraper()
+	cellReconciler := &controllers.CellReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		Log:              ctrl.Log.WithName("test"),
+		Recorder:         record.NewFakeRecorder(100),
+		StatusScraper:    scraper,
+		HandoffRequester: scraper,
+	}
+	reconcileCell := func(name string) *fleetforgev1.Cell {
+		t.Helper()
+		req := ctrl.Request{NamespacedName: client.ObjectKey{Name: name, Namespace: "default"}}
+		if _, err := cellReconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Cell reconcile of %s failed: %v", name, err)
+		}
+		cellObj := &fleetforgev1.Cell{}
+		if err := fakeClient.Get(ctx, req.NamespacedName, cellObj); err != nil {
+			t.Fatalf("Failed to get Cell %s: %v", name, err)
+		}
+		return cellObj
+	}
+
+	// The parent cell's pod serves one player through the gateway
+	parentID := "e2e-world-cell-0"
+	reconcileCell(parentID)
+	parentSim, parentInfo := startCellPod(t, fakeClient, parentID, worldSpec.Spec.Topology.WorldBoundaries, 2)
+	scraper.Port = parentInfo.Port
+	parentPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      parentID + "-abc",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "fleetforge-cell", "cell-id": parentID, "world": "e2e-world"},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "127.0.0.1"},
+	}
+	if err := fakeClient.Create(ctx, parentPod); err != nil {
+		t.Fatalf("Failed to create pod: %v", err)
+	}
+
+	gw := gateway.NewGatewayServer(gateway.DefaultGatewayConfig(), nil)
+	if err := gw.RegisterCell(parentInfo); err != nil {
+		t.Fatalf("Failed to register cell: %v", err)
+	}
+	gatewayServer := httptest.NewServer(http.HandlerFunc(gw.HandleWebSocket))
+	defer gatewayServer.Close()
+
+	ws, err := gateway.Dial(ctx, "ws://"+strings.TrimPrefix(gatewayServer.URL, "http://")+"/?playerId=e2e-player")
+	if err != nil {
+		t.Fatalf("Failed to connect to the gateway: %v", err)
+	}
+	defer ws.Close(gateway.CloseNormalClosure, "")
+	if connected := readUntil(t, ws, gateway.WSMessageConnected); connected.CellID != cell.CellID(parentID) {
+		t.Fatalf("Expected the player to be routed to %s, got %s", parentID, connected.CellID)
+	}
+
+	// The player moves into the right half of the world, and at half the
+	// cell's capacity the pod asks for a split instead of splitting itself
+	position := cell.WorldPosition{X: 500, Y: 250}
+	sendPosition(t, ws, position)
+	readUntil(t, ws, gateway.WSMessageGameplay)
+	waitFor(t, "the pod to request a split", func() bool {
+		_, requested := parentSim.SplitRequested()
+		return requested
+	})
+	if parentSim.GetPlayerCount() != 1 {
+		t.Fatalf("Expected the parent pod to keep serving its player, got %d players", parentSim.GetPlayerCount())
+	}
+
+	// The controller picks the request up from the pod's status and splits the cell
+	if parent := reconcileCell(parentID); !parent.Status.SplitRequested {
+		t.Fatalf("Expected the cell to report the pod's split request, got %+v", parent.Status)
+	} else if len(parent.Status.PlayerPositions) != 1 || parent.Status.PlayerPositions[0] != (fleetforgev1.PlayerPosition{X: position.X, Y: position.Y}) {
+		t.Errorf("Expected the cell to report its player's position, got %v", parent.Status.PlayerPositions)
+	}
+	if _, err := worldReconciler.Reconcile(ctx, worldReq); err != nil {
+		t.Fatalf("WorldSpec reconcile failed: %v", err)
+	}
+	parent := reconcileCell(parentID)
+	if len(parent.Spec.ChildIDs) != 2 {
+		t.Fatalf("Expected the cell to be split in two, got children %v", parent.Spec.ChildIDs)
+	}
+
+	// Its pod keeps the player while the children's pods start
+	if parent.Status.Phase != "Draining" {
+		t.Errorf("Expected the split cell to drain, got phase %q", parent.Status.Phase)
+	}
+	if affinity, err := gw.GetSessionAffinity("e2e-player"); err != nil || affinity.CellID != cell.CellID(parentID) {
+		t.Errorf("Expected the player to stay on %s until the children run, got %+v (%v)", parentID, affinity, err)
+	}
+
+	var target cell.CellID
+	childSims := make(map[cell.CellID]*cell.CellSimulator)
+	for _, childID := range parent.Spec.ChildIDs {
+		child := reconcileCell(childID)
+		if child.Spec.Boundaries.XMin <= position.X && position.X < child.Spec.Boundaries.XMax {
+			target = cell.CellID(childID)
+		}
+
+		childSim, childInfo := startCellPod(t, fakeClient, childID, child.Spec.Boundaries, 2)
+		childSims[childInfo.ID] = childSim
+		if err := gw.RegisterCell(childInfo); err != nil {
+			t.Fatalf("Failed to register child cell: %v", err)
+		}
+
+		deployment := &appsv1.Deployment{}
+		if err := fakeClient.Get(ctx, client.ObjectKey{Name: childID, Namespace: "default"}, deployment); err != nil {
+			t.Fatalf("Expected a deployment for child %s: %v", childID, err)
+		}
+		deployment.Status.ReadyReplicas = 1
+		if err := fakeClient.Status().Update(ctx, deployment); err != nil {
+			t.Fatalf("Failed to mark child %s ready: %v", childID, err)
+		}
+		if child := reconcileCell(childID); child.Status.Phase != "Running" {
+			t.Fatalf("Expected child %s to run, got phase %q", childID, child.Status.Phase)
+		}
+	}
+	if target == "" {
+		t.Fatalf("No child of %s covers the player's position", parentID)
+	}
+
+	// With the children running the pod hands the player off, and the gateway
+	// moves the connection over without the client reconnecting
+	reconcileCell(parentID)
+	if changed := readUntil(t, ws, gateway.WSMessageCellChanged); changed.CellID != target {
+		t.Fatalf("Expected the player to move to %s, got %s", target, changed.CellID)
+	}
+	if affinity, err := gw.GetSessionAffinity("e2e-player"); err != nil || affinity.CellID != target {
+		t.Errorf("Expected the session to move to %s, got %+v (%v)", target, affinity, err)
+	}
+
+	// The player keeps their position in the child rather than respawning
+	waitFor(t, "the child pod to serve the player", func() bool {
+		return childSims[target].GetPlayerCount() == 1
+	})
+	if positions := childSims[target].PlayerPositions(1); len(positions) != 1 || positions[0] != position {
+		t.Errorf("Expected the player to keep position %v in %s, got %v", position, target, positions)
+	}
+
+	sendPosition(t, ws, position)
+	if echo := readUntil(t, ws, gateway.WSMessageGameplay); echo.CellID != target {
+		t.Errorf("Expected gameplay to be served by %s, got %s", target, echo.CellID)
+	}
+	waitFor(t, "the parent pod to release the player", func() bool {
+		return parentSim.GetPlayerCount() == 0
+	})
+
+	// The empty pod is retired
+	if parent := reconcileCell(parentID); parent.Status.Phase != "Split" {
+		t.Errorf("Expected the drained cell to be split, got phase %q", parent.Status.Phase)
+	}
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: parentID, Namespace: "default"}, &appsv1.Deployment{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the drained cell's deployment to be deleted, got %v", err)
+	}
+}
+
+func TestHandoffRequiresToken(t *testing.T) {
+	cellSim := cell.NewCellSimulator("token-cell", fleetforgev1.WorldBounds{XMin: -100, XMax: 100}, 10, logr.Discard())
+	if err := cellSim.Start(); err != nil {
+		t.Fatalf("Failed to start cell: %v", err)
+	}
+	t.Cleanup(func() { cellSim.Stop() })
+
+	body := `{"cells": [{"id": "token-cell-child-1", "boundaries": {"xMin": -100, "xMax": 100}}]}`
+	handoff := func(cellToken, presented string) int {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/handoff", strings.NewReader(body))
+		if presented != "" {
+			req.Header.Set("Authorization", "Bearer "+presented)
+		}
+		recorder := httptest.NewRecorder()
+		newHealthMux(cellSim, newPlayerLinks(), cellToken, logr.Discard()).ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	if code := handoff("secret", ""); code != http.StatusUnauthorized {
+		t.Errorf("Expected a handoff without a token to be refused, got %d", code)
+	}
+	if code := handoff("secret", "guess"); code != http.StatusUnauthorized {
+		t.Errorf("Expected a handoff with the wrong token to be refused, got %d", code)
+	}
+	if code := handoff("", ""); code != http.StatusUnauthorized {
+		t.Errorf("Expected a cell without a token to refuse handoffs, got %d", code)
+	}
+	if code := handoff("secret", "secret"); code != http.StatusOK {
+		t.Errorf("Expected a handoff with the cell's token to be accepted, got %d", code)
+	}
+}
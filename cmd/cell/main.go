@@ -9,9 +9,11 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	fleetforgev1 "github.com/astrosteveo/fleetforge/api/v1"
 	"github.com/astrosteveo/fleetforge/pkg/cell"
 )
 
@@ -20,6 +22,9 @@ type CellService struct {
 	manager cell.CellManager
 	port    int
 	server  *http.Server
+
+	// ready is set once checkpointed cells have been recovered
+	ready atomic.Bool
 }
 
 // NewCellService creates a new cell service
@@ -58,6 +63,50 @@ func (s *CellService) Start() error {
 	return s.server.ListenAndServe()
 }
 
+// EnableCheckpoints persists cell checkpoints to a local directory
+func (s *CellService) EnableCheckpoints(dir string, persistence fleetforgev1.PersistenceConfiguration) error {
+	interval, err := persistence.ParseCheckpointInterval()
+	if err != nil {
+		return err
+	}
+	retention, err := persistence.ParseRetentionPeriod()
+	if err != nil {
+		return err
+	}
+
+	store, err := cell.NewLocalCheckpointStore(dir, retention)
+	if err != nil {
+		return err
+	}
+
+	defaultManager, ok := s.manager.(*cell.DefaultCellManager)
+	if !ok {
+		return fmt.Errorf("cell manager does not support checkpoints")
+	}
+	defaultManager.SetCheckpointStore(store, interval)
+
+	log.Printf("Checkpoint persistence enabled in %s (interval %v, retention %v)", dir, interval, retention)
+	return nil
+}
+
+// Recover restores every checkpointed cell and then marks the service ready
+func (s *CellService) Recover() {
+	defer s.ready.Store(true)
+
+	defaultManager, ok := s.manager.(*cell.DefaultCellManager)
+	if !ok {
+		return
+	}
+
+	recovered, err := defaultManager.RecoverCells()
+	if err != nil {
+		log.Printf("Cell recovery incomplete: %v", err)
+	}
+	if len(recovered) > 0 {
+		log.Printf("Recovered %d cells from checkpoints: %v", len(recovered), recovered)
+	}
+}
+
 // Stop gracefully stops the cell service
 func (s *CellService) Stop() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -108,6 +157,10 @@ func (s *CellService) handleReady(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if !s.ready.Load() {
+		ready["status"] = "recovering"
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
 	json.NewEncoder(w).Encode(ready)
 }
 
@@ -336,6 +389,17 @@ func main() {
 	// Create and start the service
 	service := NewCellService(port)
 
+	if dir := os.Getenv("CHECKPOINT_DIR"); dir != "" {
+		persistence := fleetforgev1.PersistenceConfiguration{
+			CheckpointInterval: getEnvString("CHECKPOINT_INTERVAL", "30s"),
+			RetentionPeriod:    getEnvString("RETENTION_PERIOD", "7d"),
+			Enabled:            true,
+		}
+		if err := service.EnableCheckpoints(dir, persistence); err != nil {
+			log.Fatalf("Invalid checkpoint configuration: %v", err)
+		}
+	}
+
 	// Handle graceful shutdown
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -347,6 +411,9 @@ func main() {
 		}
 	}()
 
+	// Restore checkpointed cells; /ready reports false until this finishes
+	service.Recover()
+
 	// Wait for shutdown signal
 	<-sigChan
 	log.Println("Shutting down cell service...")
@@ -359,6 +426,14 @@ func main() {
 	log.Println("Cell service stopped gracefully")
 }
 
+// getEnvString returns an environment variable or a default value
+func getEnvString(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
 // handlePlayers handles player operations (POST to add player to a cell)
 func (s *CellService) handlePlayers(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -109,6 +109,16 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "WorldSpec")
 		os.Exit(1)
 	}
+	if err = (&controllers.CellReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		Log:           ctrl.Log.WithName("controllers").WithName("Cell"),
+		Recorder:      mgr.GetEventRecorderFor("cell-controller"),
+		StatusScraper: controllers.NewHTTPCellStatusScraper(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Cell")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
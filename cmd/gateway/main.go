@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -9,6 +10,13 @@ import (
 	"syscall"
 	"time"
 
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetforgev1 "github.com/astrosteveo/fleetforge/api/v1"
 	"github.com/astrosteveo/fleetforge/pkg/gateway"
 )
 
@@ -37,14 +45,24 @@ func (l *SimpleLogger) Debug(msg string, keysAndValues ...interface{}) {
 
 func main() {
 	var (
-		port           = flag.Int("port", 8090, "Port to listen on")
-		host           = flag.String("host", "0.0.0.0", "Host to bind to")
-		debug          = flag.Bool("debug", false, "Enable debug logging")
-		rateLimit      = flag.Int("rate-limit", 100, "Requests per second rate limit")
-		burstSize      = flag.Int("burst-size", 20, "Rate limit burst size")
-		sessionTimeout = flag.Duration("session-timeout", 5*time.Minute, "Session timeout duration")
-		readTimeout    = flag.Duration("read-timeout", 30*time.Second, "HTTP read timeout")
-		writeTimeout   = flag.Duration("write-timeout", 30*time.Second, "HTTP write timeout")
+		port               = flag.Int("port", 8090, "Port to listen on")
+		host               = flag.String("host", "0.0.0.0", "Host to bind to")
+		debug              = flag.Bool("debug", false, "Enable debug logging")
+		rateLimit          = flag.Int("rate-limit", 100, "Requests per second rate limit")
+		burstSize          = flag.Int("burst-size", 20, "Rate limit burst size")
+		sessionTimeout     = flag.Duration("session-timeout", 5*time.Minute, "Session timeout duration")
+		readTimeout        = flag.Duration("read-timeout", 30*time.Second, "HTTP read timeout")
+		writeTimeout       = flag.Duration("write-timeout", 30*time.Second, "HTTP write timeout")
+		healthCheck        = flag.Bool("cell-health-check", true, "Poll registered cells' /health and /status endpoints")
+		healthInterval     = flag.Duration("cell-health-interval", 30*time.Second, "Interval between cell discovery and health checks")
+		healthTimeout      = flag.Duration("cell-health-timeout", 2*time.Second, "Timeout for a single cell health check")
+		healthFailures     = flag.Int("cell-failure-threshold", 3, "Consecutive failed checks before a cell is marked unhealthy")
+		healthSuccesses    = flag.Int("cell-success-threshold", 2, "Consecutive successful checks before an unhealthy cell is marked healthy")
+		healthEvictAfter   = flag.Int("cell-evict-after", 10, "Unreachable checks before a cell is unregistered (0 to keep it)")
+		discovery          = flag.String("discovery", "", "Cell discovery source: kubernetes, static, or empty to rely on registration")
+		discoveryFile      = flag.String("discovery-file", "", "JSON file listing cells for static discovery")
+		discoveryNamespace = flag.String("discovery-namespace", os.Getenv("POD_NAMESPACE"), "Namespace to discover cells in (empty for all namespaces)")
+		discoveryWorld     = flag.String("discovery-world", "", "Only discover the cells of this WorldSpec")
 	)
 	flag.Parse()
 
@@ -75,6 +93,12 @@ func main() {
 	config.SessionTimeout = *sessionTimeout
 	config.RateLimit.RequestsPerSecond = *rateLimit
 	config.RateLimit.BurstSize = *burstSize
+	config.CellDiscovery.HealthCheck = *healthCheck
+	config.CellDiscovery.RefreshInterval = *healthInterval
+	config.CellDiscovery.HealthTimeout = *healthTimeout
+	config.CellDiscovery.FailureThreshold = *healthFailures
+	config.CellDiscovery.SuccessThreshold = *healthSuccesses
+	config.CellDiscovery.EvictAfter = *healthEvictAfter
 
 	logger.Info("starting FleetForge Gateway",
 		"version", "1.0.0",
@@ -87,6 +111,16 @@ func main() {
 	// Create gateway server
 	gatewayServer := gateway.NewGatewayServer(config, logger)
 
+	if *discovery != "" {
+		source, err := newDiscoverySource(*discovery, *discoveryFile, *discoveryNamespace, *discoveryWorld)
+		if err != nil {
+			logger.Error(err, "failed to set up cell discovery")
+			os.Exit(1)
+		}
+		gatewayServer.SetDiscoverySource(source)
+		logger.Info("cell discovery enabled", "source", source.Name())
+	}
+
 	// Start server in a goroutine
 	serverErrors := make(chan error, 1)
 	go func() {
@@ -116,3 +150,30 @@ func main() {
 		logger.Info("gateway shutdown completed")
 	}
 }
+
+// newDiscoverySource creates the cell discovery source selected by flags
+func newDiscoverySource(kind, file, namespace, world string) (gateway.DiscoverySource, error) {
+	switch kind {
+	case "static":
+		if file == "" {
+			return nil, fmt.Errorf("static discovery requires --discovery-file")
+		}
+		return gateway.NewStaticDiscoverySource(file), nil
+	case "kubernetes":
+		restConfig, err := ctrl.GetConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+		}
+		scheme := runtime.NewScheme()
+		utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+		utilruntime.Must(fleetforgev1.AddToScheme(scheme))
+
+		k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+		}
+		return gateway.NewKubernetesDiscoverySource(k8sClient, namespace, world), nil
+	default:
+		return nil, fmt.Errorf("unknown discovery source %q", kind)
+	}
+}
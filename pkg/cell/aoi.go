@@ -1,85 +1,340 @@
 package cell
 
 import (
+	"fmt"
 	"math"
+	"sort"
+	"sync"
 )
 
-// BasicAOIFilter implements a simple Area of Interest filter
-type BasicAOIFilter struct {
+// aoiNeighborhood holds the radii and the cell mesh link shared by the AOI
+// filters, independent of how each one indexes its players
+type aoiNeighborhood struct {
 	defaultRadius float64
 	syncRadius    float64
+
+	// Live cell mesh used to find neighboring cells
+	cellID CellID
+	mesh   *CellMesh
+	meshMu sync.RWMutex
+}
+
+// BasicAOIFilter implements a simple Area of Interest filter backed by a uniform grid
+type BasicAOIFilter struct {
+	aoiNeighborhood
+
+	// Uniform grid spatial index over the players in the cell
+	gridSize  float64
+	grid      map[gridKey]map[PlayerID]WorldPosition
+	positions map[PlayerID]WorldPosition
+
+	mu sync.RWMutex
+}
+
+// gridKey identifies a single bucket in the uniform grid
+type gridKey struct {
+	X int64
+	Y int64
 }
 
 // NewBasicAOIFilter creates a new basic AOI filter
 func NewBasicAOIFilter() AOIFilter {
+	return newBasicAOIFilter(100.0, 150.0)
+}
+
+// newBasicAOIFilter creates a basic AOI filter whose grid buckets match the default radius
+func newBasicAOIFilter(defaultRadius, syncRadius float64) *BasicAOIFilter {
 	return &BasicAOIFilter{
-		defaultRadius: 100.0,
-		syncRadius:    150.0,
+		aoiNeighborhood: aoiNeighborhood{defaultRadius: defaultRadius, syncRadius: syncRadius},
+		gridSize:        defaultRadius,
+		grid:            make(map[gridKey]map[PlayerID]WorldPosition),
+		positions:       make(map[PlayerID]WorldPosition),
+	}
+}
+
+// UpdatePlayer inserts a player into the grid or moves it to its new bucket.
+// Positions that are not finite are ignored.
+func (f *BasicAOIFilter) UpdatePlayer(playerID PlayerID, position WorldPosition) {
+	if !position.isFinite() {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	newKey := f.keyFor(position)
+	if oldPos, exists := f.positions[playerID]; exists {
+		oldKey := f.keyFor(oldPos)
+		if oldKey != newKey {
+			f.removeFromBucket(oldKey, playerID)
+		}
+	}
+
+	bucket, exists := f.grid[newKey]
+	if !exists {
+		bucket = make(map[PlayerID]WorldPosition)
+		f.grid[newKey] = bucket
+	}
+	bucket[playerID] = position
+	f.positions[playerID] = position
+}
+
+// RemovePlayer removes a player from the grid
+func (f *BasicAOIFilter) RemovePlayer(playerID PlayerID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	pos, exists := f.positions[playerID]
+	if !exists {
+		return
 	}
+
+	f.removeFromBucket(f.keyFor(pos), playerID)
+	delete(f.positions, playerID)
 }
 
 // GetPlayersInRange returns players within the specified range of a position
 func (f *BasicAOIFilter) GetPlayersInRange(center WorldPosition, radius float64) []PlayerID {
-	// This is a simple implementation that would be enhanced in a real system
-	// In practice, this would use spatial indexing (quadtree, octree, etc.)
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
 	var players []PlayerID
+	if radius < 0 || !isFinite(radius) || !center.isFinite() {
+		return players
+	}
 
-	// TODO: Replace the following with actual access to player positions.
-	// For demonstration, assuming a function GetAllPlayers() []struct{ID PlayerID; Pos WorldPosition}
-	// Example:
-	// for _, p := range GetAllPlayers() {
-	//     if f.calculateDistance(center, p.Pos) <= radius {
-	//         players = append(players, p.ID)
-	//     }
-	// }
+	// Only visit the buckets overlapping the bounding square of the query circle
+	minKey := f.keyFor(WorldPosition{X: center.X - radius, Y: center.Y - radius})
+	maxKey := f.keyFor(WorldPosition{X: center.X + radius, Y: center.Y + radius})
+
+	// A query wider than the populated area costs less by scanning the occupied
+	// buckets than by probing every bucket in range
+	span := float64(maxKey.X-minKey.X+1) * float64(maxKey.Y-minKey.Y+1)
+	if span > float64(len(f.grid)) {
+		for key, bucket := range f.grid {
+			if key.X < minKey.X || key.X > maxKey.X || key.Y < minKey.Y || key.Y > maxKey.Y {
+				continue
+			}
+			players = f.appendInRange(players, bucket, center, radius)
+		}
+		return players
+	}
+
+	for x := minKey.X; x <= maxKey.X; x++ {
+		for y := minKey.Y; y <= maxKey.Y; y++ {
+			players = f.appendInRange(players, f.grid[gridKey{X: x, Y: y}], center, radius)
+		}
+	}
 
-	// Placeholder: no player data available in this context.
 	return players
 }
 
+// appendInRange appends the players of a bucket that lie within radius of center
+func (f *BasicAOIFilter) appendInRange(players []PlayerID, bucket map[PlayerID]WorldPosition, center WorldPosition, radius float64) []PlayerID {
+	for id, pos := range bucket {
+		if calculateDistance(center, pos) <= radius {
+			players = append(players, id)
+		}
+	}
+	return players
+}
+
+// maxGridCoord bounds bucket coordinates so far-out positions saturate into the
+// outermost buckets instead of overflowing, and bucket spans still fit an int64
+const maxGridCoord = math.MaxInt64 / 4
+
+// keyFor returns the grid bucket containing a position
+func (f *BasicAOIFilter) keyFor(pos WorldPosition) gridKey {
+	return gridKey{
+		X: gridCoord(pos.X / f.gridSize),
+		Y: gridCoord(pos.Y / f.gridSize),
+	}
+}
+
+// gridCoord converts a position in bucket units to a bucket coordinate
+func gridCoord(v float64) int64 {
+	v = math.Floor(v)
+	if v >= maxGridCoord {
+		return maxGridCoord
+	}
+	if v <= -maxGridCoord {
+		return -maxGridCoord
+	}
+	return int64(v)
+}
+
+// isFinite reports whether v is neither NaN nor infinite
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// removeFromBucket removes a player from a bucket, dropping the bucket once empty
+func (f *BasicAOIFilter) removeFromBucket(key gridKey, playerID PlayerID) {
+	bucket, exists := f.grid[key]
+	if !exists {
+		return
+	}
+
+	delete(bucket, playerID)
+	if len(bucket) == 0 {
+		delete(f.grid, key)
+	}
+}
+
 // ShouldSync determines if two positions are close enough to require synchronization
-func (f *BasicAOIFilter) ShouldSync(pos1, pos2 WorldPosition, syncRadius float64) bool {
+func (n *aoiNeighborhood) ShouldSync(pos1, pos2 WorldPosition, syncRadius float64) bool {
 	if syncRadius == 0 {
-		syncRadius = f.syncRadius
+		syncRadius = n.syncRadius
 	}
 
-	distance := f.calculateDistance(pos1, pos2)
+	distance := calculateDistance(pos1, pos2)
 	return distance <= syncRadius
 }
 
-// GetNeighborCells returns the neighboring cells that might have relevant players
-func (f *BasicAOIFilter) GetNeighborCells(position WorldPosition) []CellID {
-	// This is a placeholder implementation
-	// In a real system, this would query the cell mesh to find neighboring cells
-	// based on the position and the AOI radius
-	return []CellID{}
+// GetNeighborCells returns the neighboring cells whose boundaries fall within the AOI radius of a position
+func (n *aoiNeighborhood) GetNeighborCells(position WorldPosition) []CellID {
+	n.meshMu.RLock()
+	mesh, cellID := n.mesh, n.cellID
+	n.meshMu.RUnlock()
+
+	if mesh == nil {
+		return []CellID{}
+	}
+
+	neighbors := mesh.CellsWithinRadius(cellID, position, n.defaultRadius)
+	if neighbors == nil {
+		return []CellID{}
+	}
+	return neighbors
+}
+
+// SetCellMesh attaches the filter to the live cell mesh on behalf of a cell
+func (n *aoiNeighborhood) SetCellMesh(cellID CellID, mesh *CellMesh) {
+	n.meshMu.Lock()
+	defer n.meshMu.Unlock()
+
+	n.cellID = cellID
+	n.mesh = mesh
 }
 
 // calculateDistance calculates the Euclidean distance between two positions
-func (f *BasicAOIFilter) calculateDistance(pos1, pos2 WorldPosition) float64 {
+func calculateDistance(pos1, pos2 WorldPosition) float64 {
 	dx := pos1.X - pos2.X
 	dy := pos1.Y - pos2.Y
 	return math.Sqrt(dx*dx + dy*dy)
 }
 
-// AdvancedAOIFilter implements a more sophisticated AOI filter using spatial indexing
+// AdvancedAOIFilter implements an AOI filter backed by an adaptive quadtree.
+// Leaves subdivide as player density grows and collapse again as players leave,
+// so dense hub areas stay cheap to query where a fixed grid would degrade.
 type AdvancedAOIFilter struct {
-	*BasicAOIFilter
-	// In a real implementation, this would include:
-	// - Quadtree or other spatial data structure
-	// - Interest management zones
-	// - Visibility culling
-	// - Dynamic LOD (Level of Detail) based on distance
+	aoiNeighborhood
+
+	tree      *quadtree
+	positions map[PlayerID]WorldPosition
+	mu        sync.RWMutex
 }
 
 // NewAdvancedAOIFilter creates a new advanced AOI filter
 func NewAdvancedAOIFilter() AOIFilter {
 	return &AdvancedAOIFilter{
-		BasicAOIFilter: &BasicAOIFilter{
-			defaultRadius: 200.0,
-			syncRadius:    300.0,
-		},
+		aoiNeighborhood: aoiNeighborhood{defaultRadius: 200.0, syncRadius: 300.0},
+		tree:            newQuadtree(16, 8, 12), // Split above 16 players, collapse at 8, at most 12 levels
+		positions:       make(map[PlayerID]WorldPosition),
+	}
+}
+
+// UpdatePlayer inserts a player into the quadtree or moves it to its new leaf.
+// Positions that are not finite are ignored.
+func (f *AdvancedAOIFilter) UpdatePlayer(playerID PlayerID, position WorldPosition) {
+	if !position.isFinite() {
+		return
 	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if oldPos, exists := f.positions[playerID]; exists {
+		f.tree.remove(playerID, oldPos)
+	}
+
+	f.tree.insert(playerID, position)
+	f.positions[playerID] = position
+}
+
+// RemovePlayer removes a player from the quadtree
+func (f *AdvancedAOIFilter) RemovePlayer(playerID PlayerID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	pos, exists := f.positions[playerID]
+	if !exists {
+		return
+	}
+
+	f.tree.remove(playerID, pos)
+	delete(f.positions, playerID)
+}
+
+// GetPlayersInRange returns players within the specified range of a position
+func (f *AdvancedAOIFilter) GetPlayersInRange(center WorldPosition, radius float64) []PlayerID {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var players []PlayerID
+	if radius < 0 || !isFinite(radius) || !center.isFinite() {
+		return players
+	}
+
+	return f.tree.queryRange(center, radius, players)
+}
+
+// GetNearestPlayers returns up to k players ordered by increasing distance from a position
+func (f *AdvancedAOIFilter) GetNearestPlayers(center WorldPosition, k int) []PlayerID {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.tree.nearest(center, k)
+}
+
+// AOI filter selection through CellSpec.GameConfig
+const (
+	// AOIFilterConfigKey is the GameConfig key selecting a cell's AOI filter
+	AOIFilterConfigKey = "aoiFilter"
+
+	// AOIFilterBasic selects the uniform grid BasicAOIFilter (default)
+	AOIFilterBasic = "basic"
+
+	// AOIFilterAdvanced selects the quadtree-backed AdvancedAOIFilter
+	AOIFilterAdvanced = "advanced"
+)
+
+// aoiFilterTypeFromConfig returns the AOI filter type requested by a cell's game config
+func aoiFilterTypeFromConfig(gameConfig map[string]interface{}) (string, error) {
+	value, exists := gameConfig[AOIFilterConfigKey]
+	if !exists {
+		return AOIFilterBasic, nil
+	}
+
+	filterType, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%s must be a string, got %T", AOIFilterConfigKey, value)
+	}
+
+	switch filterType {
+	case AOIFilterBasic, AOIFilterAdvanced:
+		return filterType, nil
+	default:
+		return "", fmt.Errorf("unknown %s %q", AOIFilterConfigKey, filterType)
+	}
+}
+
+// newAOIFilter creates an AOI filter of the given type
+func newAOIFilter(filterType string) AOIFilter {
+	if filterType == AOIFilterAdvanced {
+		return NewAdvancedAOIFilter()
+	}
+	return NewBasicAOIFilter()
 }
 
 // AOIConfiguration holds configuration for Area of Interest management
@@ -129,6 +384,38 @@ type AOIManager struct {
 	zones      map[PlayerID]*InterestZone
 	updateTick int64
 	filter     AOIFilter
+
+	// Delta tracking: what each observer was last told about each visible entity
+	views   map[PlayerID]map[PlayerID]*aoiViewEntry
+	indexed map[PlayerID]struct{}
+}
+
+// aoiViewEntry records the last update an observer received about an entity
+type aoiViewEntry struct {
+	position     WorldPosition
+	lastSentTick int64
+}
+
+// AOIEntityUpdate describes a visible entity in an AOI delta
+type AOIEntityUpdate struct {
+	PlayerID PlayerID      `json:"playerId"`
+	Position WorldPosition `json:"position"`
+	Distance float64       `json:"distance"`
+	LODLevel int           `json:"lodLevel"`
+}
+
+// AOIDelta describes how a player's view changed since the previous tick
+type AOIDelta struct {
+	PlayerID PlayerID          `json:"playerId"`
+	Tick     int64             `json:"tick"`
+	Entered  []AOIEntityUpdate `json:"entered,omitempty"`
+	Left     []PlayerID        `json:"left,omitempty"`
+	Updated  []AOIEntityUpdate `json:"updated,omitempty"`
+}
+
+// IsEmpty reports whether the delta carries no changes
+func (d *AOIDelta) IsEmpty() bool {
+	return len(d.Entered) == 0 && len(d.Left) == 0 && len(d.Updated) == 0
 }
 
 // NewAOIManager creates a new AOI manager
@@ -138,6 +425,8 @@ func NewAOIManager(config AOIConfiguration) *AOIManager {
 		zones:      make(map[PlayerID]*InterestZone),
 		updateTick: 0,
 		filter:     NewBasicAOIFilter(),
+		views:      make(map[PlayerID]map[PlayerID]*aoiViewEntry),
+		indexed:    make(map[PlayerID]struct{}),
 	}
 }
 
@@ -159,6 +448,14 @@ func (m *AOIManager) UpdatePlayerZone(playerID PlayerID, position WorldPosition)
 // RemovePlayerZone removes a player's interest zone
 func (m *AOIManager) RemovePlayerZone(playerID PlayerID) {
 	delete(m.zones, playerID)
+	delete(m.views, playerID)
+}
+
+// SetZonePriority sets the priority of a player's interest zone
+func (m *AOIManager) SetZonePriority(playerID PlayerID, priority int) {
+	if zone, exists := m.zones[playerID]; exists {
+		zone.Priority = priority
+	}
 }
 
 // GetPlayersInZone returns all players within a player's interest zone
@@ -206,3 +503,134 @@ func (m *AOIManager) GetLODLevel(distance float64) int {
 
 	return len(m.config.LODThresholds) // Lowest detail
 }
+
+// ComputeDeltas returns the enter, leave and update deltas for every interest zone
+// since the previous call. It is meant to be called once per tick.
+//
+// Each observer sees at most MaxPlayers entities, nearest first. Entering and leaving
+// entities are always reported; updates for visible entities are thinned by LOD band,
+// so an entity in band L is refreshed every 2^L ticks, divided by the zone priority.
+// Observers without changes are omitted from the result.
+func (m *AOIManager) ComputeDeltas(currentTick int64, allPlayers map[PlayerID]*PlayerState) map[PlayerID]*AOIDelta {
+	m.updateTick = currentTick
+	m.syncIndex(allPlayers)
+
+	deltas := make(map[PlayerID]*AOIDelta)
+
+	for observerID, zone := range m.zones {
+		visible := m.visibleEntities(observerID, zone, allPlayers)
+
+		view, exists := m.views[observerID]
+		if !exists {
+			view = make(map[PlayerID]*aoiViewEntry)
+			m.views[observerID] = view
+		}
+
+		delta := &AOIDelta{PlayerID: observerID, Tick: currentTick}
+
+		for _, entity := range visible {
+			entry, seen := view[entity.PlayerID]
+			if !seen {
+				view[entity.PlayerID] = &aoiViewEntry{position: entity.Position, lastSentTick: currentTick}
+				delta.Entered = append(delta.Entered, entity)
+				continue
+			}
+
+			if entry.position == entity.Position {
+				continue
+			}
+			if currentTick-entry.lastSentTick < m.updateInterval(entity.LODLevel, zone.Priority) {
+				continue
+			}
+
+			entry.position = entity.Position
+			entry.lastSentTick = currentTick
+			delta.Updated = append(delta.Updated, entity)
+		}
+
+		// Every visible entity is now in the view, so any surplus has left the zone
+		if len(view) > len(visible) {
+			stillVisible := make(map[PlayerID]struct{}, len(visible))
+			for _, entity := range visible {
+				stillVisible[entity.PlayerID] = struct{}{}
+			}
+			for entityID := range view {
+				if _, ok := stillVisible[entityID]; !ok {
+					delete(view, entityID)
+					delta.Left = append(delta.Left, entityID)
+				}
+			}
+		}
+
+		if !delta.IsEmpty() {
+			sort.Slice(delta.Left, func(i, j int) bool { return delta.Left[i] < delta.Left[j] })
+			deltas[observerID] = delta
+		}
+	}
+
+	return deltas
+}
+
+// visibleEntities returns the entities inside an observer's zone, nearest first,
+// capped at MaxPlayers
+func (m *AOIManager) visibleEntities(observerID PlayerID, zone *InterestZone, allPlayers map[PlayerID]*PlayerState) []AOIEntityUpdate {
+	var visible []AOIEntityUpdate
+
+	for _, id := range m.filter.GetPlayersInRange(zone.Center, zone.Radius) {
+		if id == observerID {
+			continue // Don't include the player themselves
+		}
+		player, exists := allPlayers[id]
+		if !exists {
+			continue
+		}
+
+		dist := distance(zone.Center, player.Position)
+		visible = append(visible, AOIEntityUpdate{
+			PlayerID: id,
+			Position: player.Position,
+			Distance: dist,
+			LODLevel: m.GetLODLevel(dist),
+		})
+	}
+
+	sort.Slice(visible, func(i, j int) bool {
+		if visible[i].Distance != visible[j].Distance {
+			return visible[i].Distance < visible[j].Distance
+		}
+		return visible[i].PlayerID < visible[j].PlayerID
+	})
+
+	if m.config.MaxPlayers > 0 && len(visible) > m.config.MaxPlayers {
+		visible = visible[:m.config.MaxPlayers]
+	}
+
+	return visible
+}
+
+// updateInterval returns the number of ticks between updates for an LOD band
+func (m *AOIManager) updateInterval(lodLevel, priority int) int64 {
+	interval := int64(1) << uint(lodLevel)
+	if priority > 1 {
+		interval /= int64(priority)
+	}
+	if interval < 1 {
+		interval = 1
+	}
+	return interval
+}
+
+// syncIndex brings the manager's spatial index in line with the current player positions
+func (m *AOIManager) syncIndex(allPlayers map[PlayerID]*PlayerState) {
+	for id := range m.indexed {
+		if _, exists := allPlayers[id]; !exists {
+			m.filter.RemovePlayer(id)
+			delete(m.indexed, id)
+		}
+	}
+
+	for id, player := range allPlayers {
+		m.filter.UpdatePlayer(id, player.Position)
+		m.indexed[id] = struct{}{}
+	}
+}
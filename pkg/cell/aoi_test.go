@@ -0,0 +1,414 @@
+package cell
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBasicAOIFilter_GetPlayersInRange(t *testing.T) {
+	filter := NewBasicAOIFilter()
+
+	filter.UpdatePlayer("near", WorldPosition{X: 10, Y: 10})
+	filter.UpdatePlayer("edge", WorldPosition{X: 50, Y: 0})
+	filter.UpdatePlayer("far", WorldPosition{X: 400, Y: 400})
+	filter.UpdatePlayer("negative", WorldPosition{X: -30, Y: -40})
+
+	got := filter.GetPlayersInRange(WorldPosition{X: 0, Y: 0}, 50)
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+
+	expected := []PlayerID{"edge", "near", "negative"}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, got)
+			break
+		}
+	}
+}
+
+func TestBasicAOIFilter_UpdateAndRemove(t *testing.T) {
+	filter := NewBasicAOIFilter()
+
+	filter.UpdatePlayer("p1", WorldPosition{X: 10, Y: 10})
+	if got := filter.GetPlayersInRange(WorldPosition{X: 0, Y: 0}, 20); len(got) != 1 {
+		t.Fatalf("Expected 1 player in range, got %d", len(got))
+	}
+
+	// Move the player across several grid buckets
+	filter.UpdatePlayer("p1", WorldPosition{X: 950, Y: 950})
+	if got := filter.GetPlayersInRange(WorldPosition{X: 0, Y: 0}, 20); len(got) != 0 {
+		t.Errorf("Expected no players at old position, got %v", got)
+	}
+	if got := filter.GetPlayersInRange(WorldPosition{X: 950, Y: 950}, 1); len(got) != 1 {
+		t.Errorf("Expected player at new position, got %v", got)
+	}
+
+	filter.RemovePlayer("p1")
+	if got := filter.GetPlayersInRange(WorldPosition{X: 950, Y: 950}, 1000); len(got) != 0 {
+		t.Errorf("Expected no players after removal, got %v", got)
+	}
+
+	// Removing an unknown player is a no-op
+	filter.RemovePlayer("unknown")
+}
+
+func TestBasicAOIFilter_ExtremeQueries(t *testing.T) {
+	filter := NewBasicAOIFilter()
+
+	filter.UpdatePlayer("origin", WorldPosition{X: 0, Y: 0})
+	filter.UpdatePlayer("far", WorldPosition{X: 5e5, Y: -5e5})
+	filter.UpdatePlayer("nan", WorldPosition{X: math.NaN(), Y: 0})
+
+	// A radius covering far more buckets than are occupied scans the occupied
+	// ones instead of every bucket in range
+	done := make(chan []PlayerID, 1)
+	go func() { done <- filter.GetPlayersInRange(WorldPosition{X: 0, Y: 0}, 1e6) }()
+	select {
+	case got := <-done:
+		if len(got) != 2 {
+			t.Errorf("Expected both finite players in range, got %v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wide query did not finish in time")
+	}
+
+	if got := filter.GetPlayersInRange(WorldPosition{X: 1e300, Y: 1e300}, 10); len(got) != 0 {
+		t.Errorf("Expected no players near a far-out position, got %v", got)
+	}
+
+	invalid := []struct {
+		name   string
+		center WorldPosition
+		radius float64
+	}{
+		{name: "Infinite radius", center: WorldPosition{}, radius: math.Inf(1)},
+		{name: "NaN radius", center: WorldPosition{}, radius: math.NaN()},
+		{name: "NaN center", center: WorldPosition{X: math.NaN()}, radius: 10},
+		{name: "Infinite center", center: WorldPosition{Y: math.Inf(-1)}, radius: 10},
+	}
+	for _, tt := range invalid {
+		if got := filter.GetPlayersInRange(tt.center, tt.radius); len(got) != 0 {
+			t.Errorf("%s: expected no players, got %v", tt.name, got)
+		}
+	}
+}
+
+func TestCell_SpatialIndexTracksPlayers(t *testing.T) {
+	spec := CellSpec{
+		ID:         "aoi-cell",
+		Boundaries: createTestBounds(),
+		Capacity: CellCapacity{
+			MaxPlayers: 100,
+		},
+	}
+
+	cell, err := NewCell(spec)
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+	if err := cell.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start cell: %v", err)
+	}
+	defer cell.Stop()
+	time.Sleep(time.Millisecond * 150)
+
+	for i := 0; i < 10; i++ {
+		player := &PlayerState{
+			ID:       PlayerID(fmt.Sprintf("player-%d", i)),
+			Position: WorldPosition{X: float64(i * 100), Y: 500},
+		}
+		if err := cell.AddPlayer(player); err != nil {
+			t.Fatalf("Failed to add player: %v", err)
+		}
+	}
+
+	if got := cell.GetPlayersInArea(WorldPosition{X: 0, Y: 500}, 150); len(got) != 2 {
+		t.Errorf("Expected 2 players in area, got %d", len(got))
+	}
+
+	if err := cell.UpdatePlayerPosition("player-9", WorldPosition{X: 50, Y: 500}); err != nil {
+		t.Fatalf("Failed to update player position: %v", err)
+	}
+	if got := cell.GetPlayersInArea(WorldPosition{X: 0, Y: 500}, 150); len(got) != 3 {
+		t.Errorf("Expected 3 players in area after move, got %d", len(got))
+	}
+
+	if err := cell.RemovePlayer("player-0"); err != nil {
+		t.Fatalf("Failed to remove player: %v", err)
+	}
+	if got := cell.GetAOIFilter().GetPlayersInRange(WorldPosition{X: 0, Y: 500}, 150); len(got) != 2 {
+		t.Errorf("Expected 2 players in range after removal, got %d", len(got))
+	}
+}
+
+func TestAdvancedAOIFilter_MatchesBruteForce(t *testing.T) {
+	filter := NewAdvancedAOIFilter().(*AdvancedAOIFilter)
+	positions := make(map[PlayerID]WorldPosition)
+
+	// Dense hub around the origin plus a sparse spread further out
+	for i := 0; i < 500; i++ {
+		id := PlayerID(fmt.Sprintf("hub-%d", i))
+		pos := WorldPosition{X: float64(i%25) * 2, Y: float64(i/25) * 2}
+		filter.UpdatePlayer(id, pos)
+		positions[id] = pos
+	}
+	for i := 0; i < 100; i++ {
+		id := PlayerID(fmt.Sprintf("sparse-%d", i))
+		pos := WorldPosition{X: float64(i*37%2000) - 1000, Y: float64(i*53%2000) - 1000}
+		filter.UpdatePlayer(id, pos)
+		positions[id] = pos
+	}
+
+	center := WorldPosition{X: 20, Y: 20}
+	radius := 150.0
+
+	expected := 0
+	for _, pos := range positions {
+		if distance(center, pos) <= radius {
+			expected++
+		}
+	}
+
+	if got := filter.GetPlayersInRange(center, radius); len(got) != expected {
+		t.Errorf("Expected %d players in range, got %d", expected, len(got))
+	}
+}
+
+func TestAdvancedAOIFilter_SubdivideAndCollapse(t *testing.T) {
+	filter := NewAdvancedAOIFilter().(*AdvancedAOIFilter)
+
+	if leaves := filter.tree.leafCount(); leaves != 0 {
+		t.Fatalf("Expected empty tree, got %d leaves", leaves)
+	}
+
+	for i := 0; i < 200; i++ {
+		filter.UpdatePlayer(PlayerID(fmt.Sprintf("p-%d", i)), WorldPosition{X: float64(i % 20), Y: float64(i / 20)})
+	}
+
+	dense := filter.tree.leafCount()
+	if dense <= 1 {
+		t.Fatalf("Expected tree to subdivide under load, got %d leaves", dense)
+	}
+
+	for i := 0; i < 195; i++ {
+		filter.RemovePlayer(PlayerID(fmt.Sprintf("p-%d", i)))
+	}
+
+	if sparse := filter.tree.leafCount(); sparse >= dense {
+		t.Errorf("Expected tree to collapse as players leave, had %d leaves, now %d", dense, sparse)
+	}
+	if got := filter.GetPlayersInRange(WorldPosition{X: 10, Y: 5}, 100); len(got) != 5 {
+		t.Errorf("Expected 5 remaining players, got %d", len(got))
+	}
+}
+
+func TestAdvancedAOIFilter_GetNearestPlayers(t *testing.T) {
+	filter := NewAdvancedAOIFilter().(*AdvancedAOIFilter)
+
+	for i := 0; i < 50; i++ {
+		filter.UpdatePlayer(PlayerID(fmt.Sprintf("p-%02d", i)), WorldPosition{X: float64(i * 10), Y: 0})
+	}
+
+	// Moving a player far away must move it in the index too
+	filter.UpdatePlayer("p-01", WorldPosition{X: 5000, Y: 5000})
+
+	got := filter.GetNearestPlayers(WorldPosition{X: 0, Y: 0}, 3)
+	expected := []PlayerID{"p-00", "p-02", "p-03"}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, got)
+			break
+		}
+	}
+
+	if got := filter.GetNearestPlayers(WorldPosition{X: 0, Y: 0}, 100); len(got) != 50 {
+		t.Errorf("Expected all 50 players when k exceeds population, got %d", len(got))
+	}
+}
+
+func TestAdvancedAOIFilter_NeighborCellsWhileIndexing(t *testing.T) {
+	filter := NewAdvancedAOIFilter().(*AdvancedAOIFilter)
+
+	mesh := NewCellMesh()
+	mesh.AddCell("left", createCustomBounds(0, 500, 0, 1000))
+	mesh.AddCell("right", createCustomBounds(500, 1000, 0, 1000))
+
+	// Attaching the mesh and indexing players share the filter safely
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			filter.UpdatePlayer(PlayerID(fmt.Sprintf("p-%d", i)), WorldPosition{X: float64(i), Y: float64(i)})
+		}
+	}()
+	filter.SetCellMesh("left", mesh)
+	wg.Wait()
+
+	if got := filter.GetNeighborCells(WorldPosition{X: 400, Y: 500}); len(got) != 1 || got[0] != "right" {
+		t.Errorf("Expected right within the advanced filter's radius, got %v", got)
+	}
+	if got := filter.GetPlayersInRange(WorldPosition{X: 0, Y: 0}, math.Inf(1)); len(got) != 0 {
+		t.Errorf("Expected an infinite radius to be rejected, got %d players", len(got))
+	}
+	filter.UpdatePlayer("nan", WorldPosition{X: math.NaN(), Y: 0})
+	if got := filter.GetPlayersInRange(WorldPosition{X: 0, Y: 0}, 1000); len(got) != 100 {
+		t.Errorf("Expected the NaN position to be ignored, got %d players", len(got))
+	}
+}
+
+func TestNewCell_AOIFilterFromGameConfig(t *testing.T) {
+	cell, err := NewCell(CellSpec{
+		ID:         "hub-cell",
+		Boundaries: createTestBounds(),
+		GameConfig: map[string]interface{}{AOIFilterConfigKey: AOIFilterAdvanced},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+	if _, ok := cell.GetAOIFilter().(*AdvancedAOIFilter); !ok {
+		t.Errorf("Expected AdvancedAOIFilter, got %T", cell.GetAOIFilter())
+	}
+
+	cell, err = NewCell(CellSpec{ID: "plain-cell", Boundaries: createTestBounds()})
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+	if _, ok := cell.GetAOIFilter().(*BasicAOIFilter); !ok {
+		t.Errorf("Expected BasicAOIFilter by default, got %T", cell.GetAOIFilter())
+	}
+
+	_, err = NewCell(CellSpec{
+		ID:         "bad-cell",
+		Boundaries: createTestBounds(),
+		GameConfig: map[string]interface{}{AOIFilterConfigKey: "octree"},
+	})
+	if err == nil {
+		t.Error("Expected error for unknown AOI filter type")
+	}
+}
+
+func TestAOIManager_ComputeDeltas(t *testing.T) {
+	config := DefaultAOIConfiguration()
+	config.EnableLOD = false
+	manager := NewAOIManager(config)
+
+	players := map[PlayerID]*PlayerState{
+		"observer": {ID: "observer", Position: WorldPosition{X: 0, Y: 0}},
+		"near":     {ID: "near", Position: WorldPosition{X: 10, Y: 0}},
+		"far":      {ID: "far", Position: WorldPosition{X: 500, Y: 0}},
+	}
+	manager.UpdatePlayerZone("observer", players["observer"].Position)
+
+	deltas := manager.ComputeDeltas(1, players)
+	delta := deltas["observer"]
+	if delta == nil || len(delta.Entered) != 1 || delta.Entered[0].PlayerID != "near" {
+		t.Fatalf("Expected near to enter, got %+v", delta)
+	}
+
+	// Nothing moved, so there is nothing to send
+	if deltas := manager.ComputeDeltas(2, players); deltas["observer"] != nil {
+		t.Errorf("Expected no delta without changes, got %+v", deltas["observer"])
+	}
+
+	players["near"].Position = WorldPosition{X: 20, Y: 0}
+	players["far"].Position = WorldPosition{X: 30, Y: 0}
+	delta = manager.ComputeDeltas(3, players)["observer"]
+	if delta == nil || len(delta.Updated) != 1 || len(delta.Entered) != 1 || delta.Entered[0].PlayerID != "far" {
+		t.Fatalf("Expected near update and far enter, got %+v", delta)
+	}
+
+	delete(players, "near")
+	delta = manager.ComputeDeltas(4, players)["observer"]
+	if delta == nil || len(delta.Left) != 1 || delta.Left[0] != "near" {
+		t.Fatalf("Expected near to leave, got %+v", delta)
+	}
+}
+
+func TestAOIManager_ComputeDeltasRespectsMaxPlayers(t *testing.T) {
+	config := DefaultAOIConfiguration()
+	config.MaxPlayers = 3
+	manager := NewAOIManager(config)
+
+	players := map[PlayerID]*PlayerState{
+		"observer": {ID: "observer", Position: WorldPosition{X: 0, Y: 0}},
+	}
+	for i := 1; i <= 10; i++ {
+		id := PlayerID(fmt.Sprintf("p-%02d", i))
+		players[id] = &PlayerState{ID: id, Position: WorldPosition{X: float64(i), Y: 0}}
+	}
+	manager.UpdatePlayerZone("observer", players["observer"].Position)
+
+	delta := manager.ComputeDeltas(1, players)["observer"]
+	if delta == nil || len(delta.Entered) != 3 {
+		t.Fatalf("Expected 3 nearest entities, got %+v", delta)
+	}
+	for i, entity := range delta.Entered {
+		if expected := PlayerID(fmt.Sprintf("p-%02d", i+1)); entity.PlayerID != expected {
+			t.Errorf("Expected %s at position %d, got %s", expected, i, entity.PlayerID)
+		}
+	}
+}
+
+func TestAOIManager_ComputeDeltasThinsByLOD(t *testing.T) {
+	config := DefaultAOIConfiguration()
+	config.DefaultRadius = 1000
+	manager := NewAOIManager(config)
+
+	players := map[PlayerID]*PlayerState{
+		"observer": {ID: "observer", Position: WorldPosition{X: 0, Y: 0}},
+		"close":    {ID: "close", Position: WorldPosition{X: 10, Y: 0}},    // LOD 0
+		"distant":  {ID: "distant", Position: WorldPosition{X: 400, Y: 0}}, // LOD 3
+	}
+	manager.UpdatePlayerZone("observer", players["observer"].Position)
+	manager.ComputeDeltas(0, players)
+
+	closeUpdates, distantUpdates := 0, 0
+	for tick := int64(1); tick <= 16; tick++ {
+		players["close"].Position.Y = float64(tick)
+		players["distant"].Position.Y = float64(tick)
+
+		delta := manager.ComputeDeltas(tick, players)["observer"]
+		if delta == nil {
+			continue
+		}
+		for _, entity := range delta.Updated {
+			switch entity.PlayerID {
+			case "close":
+				closeUpdates++
+			case "distant":
+				distantUpdates++
+			}
+		}
+	}
+
+	if closeUpdates != 16 {
+		t.Errorf("Expected close entity to update every tick, got %d updates", closeUpdates)
+	}
+	if distantUpdates != 2 {
+		t.Errorf("Expected distant entity to update every 8 ticks, got %d updates", distantUpdates)
+	}
+
+	// A higher priority zone refreshes distant entities more often
+	manager.SetZonePriority("observer", 4)
+	distantUpdates = 0
+	for tick := int64(17); tick <= 32; tick++ {
+		players["distant"].Position.Y = float64(tick)
+		if delta := manager.ComputeDeltas(tick, players)["observer"]; delta != nil {
+			distantUpdates += len(delta.Updated)
+		}
+	}
+	if distantUpdates != 8 {
+		t.Errorf("Expected priority 4 to refresh distant entity every 2 ticks, got %d updates", distantUpdates)
+	}
+}
@@ -2,7 +2,6 @@ package cell
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"math"
 	"sync"
@@ -13,6 +12,8 @@ import (
 type Cell struct {
 	state      *CellState
 	aoi        AOIFilter
+	aoiType    string
+	codec      StateCodec
 	metrics    *CellMetrics
 	shutdown   chan struct{}
 	ticker     *time.Ticker
@@ -21,15 +22,28 @@ type Cell struct {
 
 	// Configuration
 	tickRate                time.Duration
-	syncRadius              float64
+	aoiConfig               AOIConfiguration
 	checkpointInterval      time.Duration
+	checkpointStore         CheckpointStore
+	deltasPerSnapshot       int
 	gracefulShutdownTimeout time.Duration
 
+	// Changes since the last checkpoint, for delta checkpoints
+	changes *changeTracker
+
 	// Threshold monitoring
 	splitThreshold    float64
+	splitHysteresis   float64       // A breach ends only once density falls this far below the threshold
+	sustainedBreach   time.Duration // How long a breach must last before a split is requested
 	thresholdBreached bool
+	splitRequested    bool
+	splitRetryAt      time.Time // A declined split is not requested again before this
 	onSplitNeeded     func(cellID CellID, densityRatio float64)
 
+	// Ghost replication of border players from neighboring cells
+	ghosts      map[CellID]map[PlayerID]*GhostEntity
+	onGhostSync func(cellID CellID)
+
 	mu sync.RWMutex
 }
 
@@ -43,6 +57,25 @@ func NewCell(spec CellSpec) (*Cell, error) {
 		spec.Capacity.MaxPlayers = 100 // Default
 	}
 
+	aoiType, err := aoiFilterTypeFromConfig(spec.GameConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid game config: %w", err)
+	}
+
+	codec, err := stateCodecFromConfig(spec.GameConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid game config: %w", err)
+	}
+
+	// Copy the game config so cell game state changes never leak into the spec
+	var gameState map[string]interface{}
+	if spec.GameConfig != nil {
+		gameState = make(map[string]interface{}, len(spec.GameConfig))
+		for k, v := range spec.GameConfig {
+			gameState[k] = v
+		}
+	}
+
 	cell := &Cell{
 		state: &CellState{
 			ID:          spec.ID,
@@ -57,21 +90,27 @@ func NewCell(spec CellSpec) (*Cell, error) {
 			PlayerCount: 0,
 			Neighbors:   make([]CellID, 0),
 			Tick:        0,
-			GameState:   spec.GameConfig,
+			GameState:   gameState,
 			Phase:       "Initializing",
 			Ready:       false,
 		},
-		aoi:                     NewBasicAOIFilter(),
+		aoi:                     newAOIFilter(aoiType),
+		aoiType:                 aoiType,
+		codec:                   codec,
 		metrics:                 &CellMetrics{},
 		shutdown:                make(chan struct{}),
 		tickRate:                time.Millisecond * 50, // 20 TPS
-		syncRadius:              100.0,                 // Default sync radius
-		checkpointInterval:      time.Second * 30,      // Checkpoint every 30 seconds
-		gracefulShutdownTimeout: time.Second * 5,       // Configurable shutdown timeout
+		aoiConfig:               DefaultAOIConfiguration(),
+		checkpointInterval:      time.Second * 30, // Checkpoint every 30 seconds
+		gracefulShutdownTimeout: time.Second * 5,  // Configurable shutdown timeout
+		deltasPerSnapshot:       defaultDeltasPerSnapshot,
+		changes:                 newChangeTracker(),
 		startTime:               time.Now(),
 		splitThreshold:          0.8, // Default 80% capacity
 		thresholdBreached:       false,
 		onSplitNeeded:           nil, // Will be set by manager
+		ghosts:                  make(map[CellID]map[PlayerID]*GhostEntity),
+		onGhostSync:             nil, // Will be set by manager
 	}
 
 	return cell, nil
@@ -164,6 +203,12 @@ func (c *Cell) tick() {
 	// Update metrics
 	c.updateMetrics()
 
+	// Publish border players to neighbors and drop stale ghosts
+	if c.onGhostSync != nil && c.state.Tick%int64(c.ghostUpdateFrequency()) == 0 {
+		go c.onGhostSync(c.state.ID)
+	}
+	c.pruneExpiredGhosts(c.state.UpdatedAt)
+
 	c.mu.Unlock()
 
 	// Calculate tick performance
@@ -178,8 +223,9 @@ func (c *Cell) updatePlayerStates() {
 
 	// Check for disconnected players (haven't been seen in 30 seconds)
 	for _, player := range c.state.Players {
-		if now.Sub(player.LastSeen) > time.Second*30 {
+		if player.Connected && now.Sub(player.LastSeen) > time.Second*30 {
 			player.Connected = false
+			c.changes.markPlayer(player.ID)
 		}
 	}
 }
@@ -198,20 +244,24 @@ func (c *Cell) updateMetrics() {
 	}
 
 	// Check for threshold breach
-	if c.metrics.DensityRatio >= c.splitThreshold {
-		if !c.thresholdBreached {
-			// First time breaching threshold
-			c.thresholdBreached = true
-			c.metrics.ThresholdBreachTime = time.Now()
-
-			// Notify manager that split is needed
-			if c.onSplitNeeded != nil {
-				go c.onSplitNeeded(c.state.ID, c.metrics.DensityRatio)
-			}
-		}
-	} else {
-		// Reset threshold breach flag if density drops below threshold
+	if c.metrics.DensityRatio >= c.splitThreshold && !c.thresholdBreached {
+		// First time breaching threshold
+		c.thresholdBreached = true
+		c.metrics.ThresholdBreachTime = time.Now()
+	} else if c.thresholdBreached && c.metrics.DensityRatio < c.splitThreshold-c.splitHysteresis {
+		// Reset threshold breach once density drops out of the hysteresis band
 		c.thresholdBreached = false
+		c.splitRequested = false
+	}
+
+	// Notify manager that split is needed once the breach has lasted long enough,
+	// so brief spikes don't cause splits
+	if c.thresholdBreached && !c.splitRequested && time.Since(c.metrics.ThresholdBreachTime) >= c.sustainedBreach &&
+		!time.Now().Before(c.splitRetryAt) {
+		c.splitRequested = true
+		if c.onSplitNeeded != nil {
+			go c.onSplitNeeded(c.state.ID, c.metrics.DensityRatio)
+		}
 	}
 }
 
@@ -232,21 +282,52 @@ func (c *Cell) checkpointLoop(ctx context.Context) {
 	}
 }
 
-// createCheckpoint creates a checkpoint of the current cell state
+// createCheckpoint creates a checkpoint of the current cell state and persists it
+// to the configured checkpoint store, if any
 func (c *Cell) createCheckpoint() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	c.mu.RLock()
+	store := c.checkpointStore
+	c.mu.RUnlock()
 
-	// TODO: Implement persistent storage for checkpoints
-	// Next steps:
-	// 1. Add file-based persistence to local disk
-	// 2. Integrate with cloud storage (S3, GCS, etc.) for production
-	// 3. Add checkpoint versioning and retention policies
-	// 4. Implement delta checkpoints for efficiency
+	if store == nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.metrics.LastCheckpoint = time.Now()
+		c.metrics.StateSize = int64(len(c.state.Players) * 1024) // Rough estimate
+		return
+	}
 
-	// For now, we just update the metrics
-	c.metrics.LastCheckpoint = time.Now()
-	c.metrics.StateSize = int64(len(c.state.Players) * 1024) // Rough estimate
+	if _, err := c.persistCheckpoint(store); err != nil {
+		// Log error but keep the checkpoint loop running
+		fmt.Printf("Failed to checkpoint cell %s: %v\n", c.state.ID, err)
+	}
+}
+
+// persistCheckpoint writes a full snapshot or a delta to the store and records it in the metrics
+func (c *Cell) persistCheckpoint(store CheckpointStore) (*CheckpointInfo, error) {
+	kind, data, err := c.nextCheckpoint()
+	if err == nil {
+		info, saveErr := store.Save(c.state.ID, kind, data)
+		if info != nil {
+			c.mu.Lock()
+			c.metrics.LastCheckpoint = info.CreatedAt
+			if kind == CheckpointFull {
+				c.metrics.StateSize = info.Size
+			}
+			c.mu.Unlock()
+			return info, saveErr
+		}
+		err = fmt.Errorf("failed to save checkpoint: %w", saveErr)
+	} else {
+		err = fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+
+	// The tracked changes are gone, so the chain can only continue from a new snapshot
+	c.mu.Lock()
+	c.changes.needsFull = true
+	c.mu.Unlock()
+
+	return nil, err
 }
 
 // AddPlayer adds a player to the cell
@@ -272,6 +353,8 @@ func (c *Cell) AddPlayer(player *PlayerState) error {
 
 	c.state.Players[player.ID] = player
 	c.state.PlayerCount = len(c.state.Players)
+	c.aoi.UpdatePlayer(player.ID, player.Position)
+	c.changes.markPlayer(player.ID)
 
 	return nil
 }
@@ -287,6 +370,8 @@ func (c *Cell) RemovePlayer(playerID PlayerID) error {
 
 	delete(c.state.Players, playerID)
 	c.state.PlayerCount = len(c.state.Players)
+	c.aoi.RemovePlayer(playerID)
+	c.changes.markPlayerRemoved(playerID)
 
 	return nil
 }
@@ -309,6 +394,8 @@ func (c *Cell) UpdatePlayerPosition(playerID PlayerID, position WorldPosition) e
 	player.Position = position
 	player.LastSeen = time.Now()
 	player.Connected = true
+	c.aoi.UpdatePlayer(playerID, position)
+	c.changes.markPlayer(playerID)
 
 	return nil
 }
@@ -321,15 +408,15 @@ func (c *Cell) GetPlayer(playerID PlayerID) *PlayerState {
 	return c.state.Players[playerID]
 }
 
-// GetPlayersInArea returns players within a specific area
+// GetPlayersInArea returns players within a specific area using the AOI spatial index
 func (c *Cell) GetPlayersInArea(center WorldPosition, radius float64) []*PlayerState {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
 	var players []*PlayerState
 
-	for _, player := range c.state.Players {
-		if c.calculateDistance(center, player.Position) <= radius {
+	for _, id := range c.aoi.GetPlayersInRange(center, radius) {
+		if player, exists := c.state.Players[id]; exists {
 			players = append(players, player)
 		}
 	}
@@ -337,11 +424,23 @@ func (c *Cell) GetPlayersInArea(center WorldPosition, radius float64) []*PlayerS
 	return players
 }
 
+// GetAOIFilter returns the AOI filter indexing this cell's players
+func (c *Cell) GetAOIFilter() AOIFilter {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.aoi
+}
+
 // GetState returns a copy of the current cell state
 func (c *Cell) GetState() CellState {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	return c.snapshotLocked()
+}
+
+// snapshotLocked copies the cell state; the caller must hold the cell lock
+func (c *Cell) snapshotLocked() CellState {
 	// Create a deep copy of the state
 	stateCopy := *c.state
 	stateCopy.Players = make(map[PlayerID]*PlayerState)
@@ -351,6 +450,13 @@ func (c *Cell) GetState() CellState {
 		stateCopy.Players[id] = &playerCopy
 	}
 
+	if c.state.GameState != nil {
+		stateCopy.GameState = make(map[string]interface{}, len(c.state.GameState))
+		for k, v := range c.state.GameState {
+			stateCopy.GameState[k] = v
+		}
+	}
+
 	return stateCopy
 }
 
@@ -393,29 +499,59 @@ func (c *Cell) GetMetrics() map[string]float64 {
 	}
 }
 
-// Checkpoint creates a serialized checkpoint of the cell state
+// Checkpoint creates a serialized full snapshot of the cell state using the cell's state codec
 func (c *Cell) Checkpoint() ([]byte, error) {
 	state := c.GetState()
-	return json.Marshal(state)
+	return c.codec.Marshal(state)
 }
 
-// Restore restores the cell state from a checkpoint
-func (c *Cell) Restore(checkpoint []byte) error {
+// Restore restores the cell state from a full snapshot, then replays any deltas
+// taken after it in order. Each checkpoint is decoded with the codec that wrote it.
+func (c *Cell) Restore(checkpoint []byte, deltas ...[]byte) error {
+	var state CellState
+	if err := decodeState(checkpoint, &state); err != nil {
+		return fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+	}
+	if state.Players == nil {
+		state.Players = make(map[PlayerID]*PlayerState)
+	}
+
+	for i, data := range deltas {
+		var delta StateDelta
+		if err := decodeState(data, &delta); err != nil {
+			return fmt.Errorf("failed to unmarshal delta %d: %w", i+1, err)
+		}
+		if delta.Sequence != i+1 {
+			return fmt.Errorf("delta %d is out of order: has sequence %d", i+1, delta.Sequence)
+		}
+		applyDelta(&state, &delta)
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	var state CellState
-	if err := json.Unmarshal(checkpoint, &state); err != nil {
-		return fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+	// Rebuild the spatial index from the restored players
+	for id := range c.state.Players {
+		c.aoi.RemovePlayer(id)
+	}
+	for id, player := range state.Players {
+		c.aoi.UpdatePlayer(id, player.Position)
 	}
 
-	// Restore the state but keep the current runtime information
+	// Restore the state and lineage but keep the current runtime information
+	c.state.ParentID = state.ParentID
+	c.state.Generation = state.Generation
+	c.state.SiblingIDs = state.SiblingIDs
+	c.state.SplitParent = state.SplitParent
 	c.state.Players = state.Players
 	c.state.PlayerCount = state.PlayerCount
 	c.state.GameState = state.GameState
 	c.state.Tick = state.Tick
 	c.state.UpdatedAt = time.Now()
 
+	// Later deltas must build on a snapshot of the restored state
+	c.changes = newChangeTracker()
+
 	return nil
 }
 
@@ -446,6 +582,22 @@ func (c *Cell) calculateDistance(pos1, pos2 WorldPosition) float64 {
 	return math.Sqrt(dx*dx + dy*dy)
 }
 
+// inheritedGameConfig returns a game config carrying this cell's AOI filter and state
+// codec selection, used so cells created by splits and merges keep them
+func (c *Cell) inheritedGameConfig() map[string]interface{} {
+	return map[string]interface{}{
+		AOIFilterConfigKey:  c.aoiType,
+		StateCodecConfigKey: c.codec.Name(),
+	}
+}
+
+// setNeighbors replaces the cell's neighbor list with the current cell mesh adjacency
+func (c *Cell) setNeighbors(neighbors []CellID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.state.Neighbors = neighbors
+}
+
 // SetSplitThreshold sets the density threshold for triggering cell splits
 func (c *Cell) SetSplitThreshold(threshold float64) {
 	c.mu.Lock()
@@ -453,6 +605,24 @@ func (c *Cell) SetSplitThreshold(threshold float64) {
 	c.splitThreshold = threshold
 }
 
+// SetSplitTrigger sets how far below the split threshold density must fall to
+// end a breach, and how long a breach must last before a split is requested
+func (c *Cell) SetSplitTrigger(hysteresis float64, sustainedBreach time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.splitHysteresis = max(hysteresis, 0)
+	c.sustainedBreach = max(sustainedBreach, 0)
+}
+
+// rearmSplitRequest lets a cell whose split was declined request it again once
+// the delay has passed, if its breach still lasts by then
+func (c *Cell) rearmSplitRequest(delay time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.splitRequested = false
+	c.splitRetryAt = time.Now().Add(delay)
+}
+
 // SetOnSplitNeeded sets the callback function called when a split is needed
 func (c *Cell) SetOnSplitNeeded(callback func(cellID CellID, densityRatio float64)) {
 	c.mu.Lock()
@@ -460,6 +630,45 @@ func (c *Cell) SetOnSplitNeeded(callback func(cellID CellID, densityRatio float6
 	c.onSplitNeeded = callback
 }
 
+// SetCheckpointStore sets the store that periodic checkpoints are persisted to
+func (c *Cell) SetCheckpointStore(store CheckpointStore) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checkpointStore = store
+}
+
+// SetCheckpointInterval sets how often the cell checkpoints; it takes effect when the cell starts
+func (c *Cell) SetCheckpointInterval(interval time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if interval > 0 {
+		c.checkpointInterval = interval
+	}
+}
+
+// SetTickRate sets the interval between simulation ticks; it takes effect when the cell starts
+func (c *Cell) SetTickRate(interval time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if interval > 0 {
+		c.tickRate = interval
+	}
+}
+
+// SetAOIConfiguration sets the AOI configuration used for ghost replication
+func (c *Cell) SetAOIConfiguration(config AOIConfiguration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.aoiConfig = config
+}
+
+// SetOnGhostSync sets the callback function called when border players should be replicated
+func (c *Cell) SetOnGhostSync(callback func(cellID CellID)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onGhostSync = callback
+}
+
 // GetDensityRatio returns the current player density ratio
 func (c *Cell) GetDensityRatio() float64 {
 	c.mu.RLock()
@@ -188,3 +188,62 @@ func TestCell_GetMetrics(t *testing.T) {
 		}
 	}
 }
+
+func TestCell_SplitTriggerSustainedBreachAndHysteresis(t *testing.T) {
+	cell, err := NewCell(CellSpec{
+		ID:         "trigger-cell",
+		Boundaries: createTestBounds(),
+		Capacity:   CellCapacity{MaxPlayers: 10},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+
+	requests := make(chan float64, 10)
+	cell.SetOnSplitNeeded(func(_ CellID, densityRatio float64) { requests <- densityRatio })
+	cell.SetSplitTrigger(0.2, time.Minute)
+
+	// tickAt sets the player count and runs the threshold check
+	tickAt := func(players int) {
+		cell.mu.Lock()
+		defer cell.mu.Unlock()
+		cell.state.PlayerCount = players
+		cell.updateMetrics()
+	}
+	expectRequests := func(want int) {
+		t.Helper()
+		time.Sleep(20 * time.Millisecond)
+		if got := len(requests); got != want {
+			t.Fatalf("Expected %d split requests, got %d", want, got)
+		}
+	}
+
+	// A spike above the threshold does not split straight away
+	tickAt(9)
+	expectRequests(0)
+	if !cell.IsThresholdBreached() {
+		t.Fatalf("Expected the threshold to be breached")
+	}
+
+	// Dipping into the hysteresis band keeps the breach going
+	tickAt(7)
+	if !cell.IsThresholdBreached() {
+		t.Errorf("Expected the breach to survive a dip within the hysteresis band")
+	}
+
+	// Once the breach has lasted long enough the split is requested, once
+	cell.mu.Lock()
+	cell.metrics.ThresholdBreachTime = time.Now().Add(-2 * time.Minute)
+	cell.mu.Unlock()
+	tickAt(9)
+	tickAt(9)
+	expectRequests(1)
+
+	// Falling below the band ends the breach and re-arms the trigger
+	tickAt(5)
+	if cell.IsThresholdBreached() {
+		t.Errorf("Expected the breach to end below the hysteresis band")
+	}
+	tickAt(9)
+	expectRequests(1)
+}
@@ -0,0 +1,505 @@
+package cell
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCheckpointNotFound is returned when no checkpoint exists for a cell or version
+	ErrCheckpointNotFound = errors.New("checkpoint not found")
+
+	// ErrCheckpointCorrupt is returned when a checkpoint fails its checksum or header validation
+	ErrCheckpointCorrupt = errors.New("checkpoint corrupt")
+
+	// ErrCheckpointNoBase is returned when a delta checkpoint has no full snapshot to build on
+	ErrCheckpointNoBase = errors.New("checkpoint has no base snapshot")
+)
+
+// CheckpointKind distinguishes full snapshots from incremental checkpoints
+type CheckpointKind string
+
+const (
+	// CheckpointFull is a complete snapshot of the cell state
+	CheckpointFull CheckpointKind = "full"
+
+	// CheckpointDelta records only the changes since the previous checkpoint
+	CheckpointDelta CheckpointKind = "delta"
+)
+
+// CheckpointInfo describes a stored checkpoint
+type CheckpointInfo struct {
+	CellID      CellID         `json:"cellId"`
+	Version     int64          `json:"version"`
+	Kind        CheckpointKind `json:"kind"`
+	BaseVersion int64          `json:"baseVersion"` // Full snapshot a delta builds on (own version for full snapshots)
+	Checksum    string         `json:"checksum"`
+	Size        int64          `json:"size"`
+	CreatedAt   time.Time      `json:"createdAt"`
+}
+
+// CheckpointStore persists versioned cell checkpoints
+type CheckpointStore interface {
+	// Save stores a checkpoint as the next version for a cell. A delta builds on
+	// the most recent full snapshot saved for the cell.
+	Save(cellID CellID, kind CheckpointKind, data []byte) (*CheckpointInfo, error)
+
+	// Load returns a specific checkpoint version after verifying its checksum
+	Load(cellID CellID, version int64) ([]byte, *CheckpointInfo, error)
+
+	// List returns the checkpoints stored for a cell, newest first
+	List(cellID CellID) ([]CheckpointInfo, error)
+
+	// Cells returns the IDs of every cell with stored checkpoints
+	Cells() ([]CellID, error)
+
+	// Prune removes checkpoints created before the cutoff, always keeping the
+	// newest one and every checkpoint it needs to be restored
+	Prune(cellID CellID, before time.Time) (int, error)
+}
+
+const (
+	checkpointMagic     = "fleetforge-checkpoint"
+	checkpointFormat    = 1
+	checkpointExtension = ".ckpt"
+)
+
+// LocalCheckpointStore stores checkpoints as files on the local filesystem.
+// Each cell gets its own directory and each version is written to a temporary
+// file and renamed into place, so a crash never leaves a partial checkpoint.
+type LocalCheckpointStore struct {
+	baseDir   string
+	retention time.Duration
+	versions  map[CellID]int64
+	bases     map[CellID]int64
+	mu        sync.Mutex
+}
+
+// NewLocalCheckpointStore creates a filesystem checkpoint store rooted at baseDir.
+// A retention of zero keeps every checkpoint.
+func NewLocalCheckpointStore(baseDir string, retention time.Duration) (*LocalCheckpointStore, error) {
+	if baseDir == "" {
+		return nil, fmt.Errorf("checkpoint directory cannot be empty")
+	}
+
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+
+	return &LocalCheckpointStore{
+		baseDir:   baseDir,
+		retention: retention,
+		versions:  make(map[CellID]int64),
+		bases:     make(map[CellID]int64),
+	}, nil
+}
+
+// Save stores a checkpoint as the next version for a cell and applies the retention policy
+func (s *LocalCheckpointStore) Save(cellID CellID, kind CheckpointKind, data []byte) (*CheckpointInfo, error) {
+	if kind != CheckpointFull && kind != CheckpointDelta {
+		return nil, fmt.Errorf("unknown checkpoint kind %q", kind)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := s.cellDir(cellID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory for cell %s: %w", cellID, err)
+	}
+
+	version, err := s.nextVersion(cellID)
+	if err != nil {
+		return nil, err
+	}
+
+	baseVersion := version
+	if kind == CheckpointDelta {
+		baseVersion, err = s.latestBase(cellID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sum := sha256.Sum256(data)
+	info := &CheckpointInfo{
+		CellID:      cellID,
+		Version:     version,
+		Kind:        kind,
+		BaseVersion: baseVersion,
+		Checksum:    hex.EncodeToString(sum[:]),
+		Size:        int64(len(data)),
+		CreatedAt:   time.Now(),
+	}
+
+	tmp, err := os.CreateTemp(dir, "tmp-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary checkpoint file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // No-op once renamed
+
+	header := fmt.Sprintf("%s %d %d %d %s %d %s %d\n",
+		checkpointMagic, checkpointFormat, info.Version, info.CreatedAt.UnixNano(), info.Checksum, info.Size,
+		info.Kind, info.BaseVersion)
+
+	if _, err := tmp.WriteString(header); err == nil {
+		_, err = tmp.Write(data)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write checkpoint for cell %s: %w", cellID, err)
+	}
+
+	if err := os.Rename(tmpName, s.checkpointPath(cellID, version)); err != nil {
+		return nil, fmt.Errorf("failed to commit checkpoint for cell %s: %w", cellID, err)
+	}
+
+	s.versions[cellID] = version
+	if kind == CheckpointFull {
+		s.bases[cellID] = version
+	}
+
+	if s.retention > 0 {
+		if _, err := s.pruneLocked(cellID, info.CreatedAt.Add(-s.retention)); err != nil {
+			return info, fmt.Errorf("checkpoint saved but retention cleanup failed: %w", err)
+		}
+	}
+
+	return info, nil
+}
+
+// Load returns a specific checkpoint version after verifying its checksum
+func (s *LocalCheckpointStore) Load(cellID CellID, version int64) ([]byte, *CheckpointInfo, error) {
+	f, err := os.Open(s.checkpointPath(cellID, version))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("cell %s version %d: %w", cellID, version, ErrCheckpointNotFound)
+		}
+		return nil, nil, fmt.Errorf("failed to open checkpoint: %w", err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	info, err := readCheckpointHeader(reader, cellID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	if int64(len(data)) != info.Size {
+		return nil, nil, fmt.Errorf("cell %s version %d: size %d does not match header size %d: %w",
+			cellID, version, len(data), info.Size, ErrCheckpointCorrupt)
+	}
+
+	sum := sha256.Sum256(data)
+	if hex.EncodeToString(sum[:]) != info.Checksum {
+		return nil, nil, fmt.Errorf("cell %s version %d: checksum mismatch: %w", cellID, version, ErrCheckpointCorrupt)
+	}
+
+	return data, info, nil
+}
+
+// List returns the checkpoints stored for a cell, newest first. Files whose
+// header cannot be read are skipped; Load reports them as corrupt.
+func (s *LocalCheckpointStore) List(cellID CellID) ([]CheckpointInfo, error) {
+	versions, err := s.listVersions(cellID)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]CheckpointInfo, 0, len(versions))
+	for i := len(versions) - 1; i >= 0; i-- {
+		info, err := s.statCheckpoint(cellID, versions[i])
+		if err != nil {
+			continue
+		}
+		infos = append(infos, *info)
+	}
+
+	return infos, nil
+}
+
+// Cells returns the IDs of every cell with stored checkpoints
+func (s *LocalCheckpointStore) Cells() ([]CellID, error) {
+	entries, err := os.ReadDir(s.baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checkpoint directory: %w", err)
+	}
+
+	cellIDs := make([]CellID, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		cellID := CellID(entry.Name())
+		versions, err := s.listVersions(cellID)
+		if err != nil || len(versions) == 0 {
+			continue
+		}
+		cellIDs = append(cellIDs, cellID)
+	}
+
+	return cellIDs, nil
+}
+
+// Prune removes checkpoints created before the cutoff, always keeping the newest one
+func (s *LocalCheckpointStore) Prune(cellID CellID, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.pruneLocked(cellID, before)
+}
+
+// pruneLocked removes expired checkpoints; the caller must hold the store lock
+func (s *LocalCheckpointStore) pruneLocked(cellID CellID, before time.Time) (int, error) {
+	versions, err := s.listVersions(cellID)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(versions) == 0 {
+		return 0, nil
+	}
+
+	// Find the oldest checkpoint still inside the retention window. The newest
+	// checkpoint is always kept so a cell can always be recovered.
+	keepFrom := versions[len(versions)-1]
+	for _, version := range versions {
+		info, err := s.statCheckpoint(cellID, version)
+		if err == nil && !info.CreatedAt.Before(before) {
+			keepFrom = version
+			break
+		}
+	}
+
+	// A delta is useless without its base snapshot and the deltas before it
+	if info, err := s.statCheckpoint(cellID, keepFrom); err == nil && info.Kind == CheckpointDelta {
+		keepFrom = info.BaseVersion
+	}
+
+	removed := 0
+	for _, version := range versions {
+		if version >= keepFrom {
+			break
+		}
+		if err := os.Remove(s.checkpointPath(cellID, version)); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to remove checkpoint version %d: %w", version, err)
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// latestBase returns the newest full snapshot version for a cell; the caller must hold the store lock
+func (s *LocalCheckpointStore) latestBase(cellID CellID) (int64, error) {
+	if version, exists := s.bases[cellID]; exists {
+		return version, nil
+	}
+
+	versions, err := s.listVersions(cellID)
+	if err != nil {
+		return 0, err
+	}
+	for i := len(versions) - 1; i >= 0; i-- {
+		info, err := s.statCheckpoint(cellID, versions[i])
+		if err != nil {
+			continue
+		}
+		if info.Kind == CheckpointFull {
+			s.bases[cellID] = info.Version
+			return info.Version, nil
+		}
+	}
+
+	return 0, fmt.Errorf("cell %s: %w", cellID, ErrCheckpointNoBase)
+}
+
+// nextVersion returns the next version number for a cell; the caller must hold the store lock
+func (s *LocalCheckpointStore) nextVersion(cellID CellID) (int64, error) {
+	if version, exists := s.versions[cellID]; exists {
+		return version + 1, nil
+	}
+
+	versions, err := s.listVersions(cellID)
+	if err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 {
+		return 1, nil
+	}
+	return versions[len(versions)-1] + 1, nil
+}
+
+// listVersions returns the stored versions for a cell in ascending order
+func (s *LocalCheckpointStore) listVersions(cellID CellID) ([]int64, error) {
+	entries, err := os.ReadDir(s.cellDir(cellID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list checkpoints for cell %s: %w", cellID, err)
+	}
+
+	versions := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, checkpointExtension) {
+			continue
+		}
+		version, err := strconv.ParseInt(strings.TrimSuffix(name, checkpointExtension), 10, 64)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions, nil
+}
+
+// statCheckpoint reads only the header of a checkpoint file
+func (s *LocalCheckpointStore) statCheckpoint(cellID CellID, version int64) (*CheckpointInfo, error) {
+	f, err := os.Open(s.checkpointPath(cellID, version))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return readCheckpointHeader(bufio.NewReader(f), cellID)
+}
+
+// cellDir returns the directory holding a cell's checkpoints
+func (s *LocalCheckpointStore) cellDir(cellID CellID) string {
+	return filepath.Join(s.baseDir, filepath.Base(string(cellID)))
+}
+
+// checkpointPath returns the file path for a checkpoint version
+func (s *LocalCheckpointStore) checkpointPath(cellID CellID, version int64) string {
+	return filepath.Join(s.cellDir(cellID), fmt.Sprintf("%020d%s", version, checkpointExtension))
+}
+
+// readCheckpointHeader parses the single-line header at the start of a checkpoint file
+func readCheckpointHeader(reader *bufio.Reader, cellID CellID) (*CheckpointInfo, error) {
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("cell %s: unreadable header: %w", cellID, ErrCheckpointCorrupt)
+	}
+
+	fields := strings.Fields(string(bytes.TrimSpace(line)))
+	if len(fields) != 8 || fields[0] != checkpointMagic || fields[1] != strconv.Itoa(checkpointFormat) {
+		return nil, fmt.Errorf("cell %s: invalid header: %w", cellID, ErrCheckpointCorrupt)
+	}
+
+	version, err1 := strconv.ParseInt(fields[2], 10, 64)
+	createdAt, err2 := strconv.ParseInt(fields[3], 10, 64)
+	size, err3 := strconv.ParseInt(fields[5], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return nil, fmt.Errorf("cell %s: invalid header fields: %w", cellID, ErrCheckpointCorrupt)
+	}
+
+	baseVersion, err := strconv.ParseInt(fields[7], 10, 64)
+	kind := CheckpointKind(fields[6])
+	if err != nil || (kind != CheckpointFull && kind != CheckpointDelta) {
+		return nil, fmt.Errorf("cell %s: invalid header fields: %w", cellID, ErrCheckpointCorrupt)
+	}
+
+	return &CheckpointInfo{
+		CellID:      cellID,
+		Version:     version,
+		Kind:        kind,
+		BaseVersion: baseVersion,
+		Checksum:    fields[4],
+		Size:        size,
+		CreatedAt:   time.Unix(0, createdAt),
+	}, nil
+}
+
+// LoadCheckpointChain loads the full snapshot a checkpoint builds on followed by
+// every delta up to and including that checkpoint, ready to pass to Cell.Restore
+func LoadCheckpointChain(store CheckpointStore, cellID CellID, version int64) ([]byte, [][]byte, error) {
+	data, info, err := store.Load(cellID, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	if info.Kind == CheckpointFull {
+		return data, nil, nil
+	}
+
+	base, baseInfo, err := store.Load(cellID, info.BaseVersion)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load base of version %d: %w", version, err)
+	}
+	if baseInfo.Kind != CheckpointFull {
+		return nil, nil, fmt.Errorf("cell %s version %d: base %d is not a full snapshot: %w",
+			cellID, version, info.BaseVersion, ErrCheckpointCorrupt)
+	}
+
+	deltas := make([][]byte, 0, version-info.BaseVersion)
+	for v := info.BaseVersion + 1; v < version; v++ {
+		delta, deltaInfo, err := store.Load(cellID, v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load delta %d of version %d: %w", v, version, err)
+		}
+		if deltaInfo.Kind != CheckpointDelta || deltaInfo.BaseVersion != info.BaseVersion {
+			return nil, nil, fmt.Errorf("cell %s version %d: broken delta chain at %d: %w",
+				cellID, version, v, ErrCheckpointCorrupt)
+		}
+		deltas = append(deltas, delta)
+	}
+
+	return base, append(deltas, data), nil
+}
+
+// RecoverCheckpoint restores a cell from the newest checkpoint chain that loads
+// and replays cleanly, falling back to older versions when newer ones are corrupt.
+// It returns a nil info when the cell has no checkpoints at all.
+func RecoverCheckpoint(store CheckpointStore, cell *Cell) (*CheckpointInfo, error) {
+	cellID := cell.GetState().ID
+
+	infos, err := store.List(cellID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for i := range infos {
+		base, deltas, err := LoadCheckpointChain(store, cellID, infos[i].Version)
+		if err == nil {
+			err = cell.Restore(base, deltas...)
+		}
+		if err != nil {
+			lastErr = err
+			fmt.Printf("Skipping checkpoint version %d of cell %s: %v\n", infos[i].Version, cellID, err)
+			continue
+		}
+		return &infos[i], nil
+	}
+
+	if lastErr != nil {
+		return nil, fmt.Errorf("cell %s has no usable checkpoint: %w", cellID, lastErr)
+	}
+	return nil, nil
+}
@@ -0,0 +1,208 @@
+package cell
+
+import (
+	"fmt"
+	"sort"
+)
+
+// defaultDeltasPerSnapshot is how many delta checkpoints are written between full snapshots
+const defaultDeltasPerSnapshot = 10
+
+// StateDelta records the players and game state keys that changed between two checkpoints
+type StateDelta struct {
+	Sequence         int                       `json:"sequence"` // Position in the chain after the base snapshot, starting at 1
+	Tick             int64                     `json:"tick"`
+	Players          map[PlayerID]*PlayerState `json:"players,omitempty"`
+	RemovedPlayers   []PlayerID                `json:"removedPlayers,omitempty"`
+	GameState        map[string]interface{}    `json:"gameState,omitempty"`
+	RemovedGameState []string                  `json:"removedGameState,omitempty"`
+}
+
+// changeTracker records which parts of a cell's state changed since its last checkpoint
+type changeTracker struct {
+	players   map[PlayerID]bool // true when the player was removed
+	gameState map[string]bool   // true when the key was removed
+
+	// sequence counts deltas written since the last full snapshot
+	sequence int
+	// needsFull forces the next checkpoint to be a full snapshot
+	needsFull bool
+}
+
+// newChangeTracker creates a tracker that starts with a full snapshot
+func newChangeTracker() *changeTracker {
+	return &changeTracker{
+		players:   make(map[PlayerID]bool),
+		gameState: make(map[string]bool),
+		needsFull: true,
+	}
+}
+
+// markPlayer records that a player was added or updated
+func (t *changeTracker) markPlayer(id PlayerID) {
+	t.players[id] = false
+}
+
+// markPlayerRemoved records that a player left the cell
+func (t *changeTracker) markPlayerRemoved(id PlayerID) {
+	t.players[id] = true
+}
+
+// markGameState records that a game state key was set or removed
+func (t *changeTracker) markGameState(key string, removed bool) {
+	t.gameState[key] = removed
+}
+
+// reset clears recorded changes after a checkpoint of the given kind was taken
+func (t *changeTracker) reset(kind CheckpointKind) {
+	t.players = make(map[PlayerID]bool)
+	t.gameState = make(map[string]bool)
+	if kind == CheckpointFull {
+		t.sequence = 0
+		t.needsFull = false
+	} else {
+		t.sequence++
+	}
+}
+
+// CheckpointDelta returns the changes since the previous checkpoint and starts
+// tracking a new delta. It fails if no full snapshot has been taken yet.
+func (c *Cell) CheckpointDelta() ([]byte, error) {
+	c.mu.Lock()
+	if c.changes.needsFull {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("cell %s: %w", c.state.ID, ErrCheckpointNoBase)
+	}
+	delta := c.collectDeltaLocked()
+	c.changes.reset(CheckpointDelta)
+	c.mu.Unlock()
+
+	return c.codec.Marshal(delta)
+}
+
+// nextCheckpoint takes a full snapshot or a delta, depending on how many deltas
+// were written since the last snapshot, and resets change tracking
+func (c *Cell) nextCheckpoint() (CheckpointKind, []byte, error) {
+	c.mu.Lock()
+	if c.changes.needsFull || c.changes.sequence >= c.deltasPerSnapshot {
+		state := c.snapshotLocked()
+		c.changes.reset(CheckpointFull)
+		c.mu.Unlock()
+
+		data, err := c.codec.Marshal(state)
+		return CheckpointFull, data, err
+	}
+
+	delta := c.collectDeltaLocked()
+	c.changes.reset(CheckpointDelta)
+	c.mu.Unlock()
+
+	data, err := c.codec.Marshal(delta)
+	return CheckpointDelta, data, err
+}
+
+// collectDeltaLocked copies the changed state; the caller must hold the cell lock
+func (c *Cell) collectDeltaLocked() *StateDelta {
+	delta := &StateDelta{
+		Sequence: c.changes.sequence + 1,
+		Tick:     c.state.Tick,
+	}
+
+	for id, removed := range c.changes.players {
+		player, exists := c.state.Players[id]
+		if removed || !exists {
+			delta.RemovedPlayers = append(delta.RemovedPlayers, id)
+			continue
+		}
+		if delta.Players == nil {
+			delta.Players = make(map[PlayerID]*PlayerState)
+		}
+		playerCopy := *player
+		delta.Players[id] = &playerCopy
+	}
+
+	for key, removed := range c.changes.gameState {
+		value, exists := c.state.GameState[key]
+		if removed || !exists {
+			delta.RemovedGameState = append(delta.RemovedGameState, key)
+			continue
+		}
+		if delta.GameState == nil {
+			delta.GameState = make(map[string]interface{})
+		}
+		delta.GameState[key] = value
+	}
+
+	// Keep the encoding stable for identical changes
+	sort.Slice(delta.RemovedPlayers, func(i, j int) bool { return delta.RemovedPlayers[i] < delta.RemovedPlayers[j] })
+	sort.Strings(delta.RemovedGameState)
+
+	return delta
+}
+
+// applyDelta replays a delta onto a restored state
+func applyDelta(state *CellState, delta *StateDelta) {
+	if state.Players == nil {
+		state.Players = make(map[PlayerID]*PlayerState)
+	}
+	for _, id := range delta.RemovedPlayers {
+		delete(state.Players, id)
+	}
+	for id, player := range delta.Players {
+		state.Players[id] = player
+	}
+	state.PlayerCount = len(state.Players)
+
+	if len(delta.GameState) > 0 && state.GameState == nil {
+		state.GameState = make(map[string]interface{})
+	}
+	for _, key := range delta.RemovedGameState {
+		delete(state.GameState, key)
+	}
+	for key, value := range delta.GameState {
+		state.GameState[key] = value
+	}
+
+	state.Tick = delta.Tick
+}
+
+// SetGameStateValue sets a cell-wide game state value
+func (c *Cell) SetGameStateValue(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state.GameState == nil {
+		c.state.GameState = make(map[string]interface{})
+	}
+	c.state.GameState[key] = value
+	c.changes.markGameState(key, false)
+}
+
+// DeleteGameStateValue removes a cell-wide game state value
+func (c *Cell) DeleteGameStateValue(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.state.GameState[key]; !exists {
+		return
+	}
+	delete(c.state.GameState, key)
+	c.changes.markGameState(key, true)
+}
+
+// GetGameStateValue returns a cell-wide game state value
+func (c *Cell) GetGameStateValue(key string) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	value, exists := c.state.GameState[key]
+	return value, exists
+}
+
+// SetDeltasPerSnapshot sets how many delta checkpoints are written between full
+// snapshots. Zero or less disables delta checkpoints.
+func (c *Cell) SetDeltasPerSnapshot(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deltasPerSnapshot = max(n, 0)
+}
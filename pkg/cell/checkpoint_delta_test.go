@@ -0,0 +1,151 @@
+package cell
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCell_RestoreReplaysDeltaChain(t *testing.T) {
+	cell, err := NewCell(CellSpec{ID: "delta-cell", Boundaries: createTestBounds()})
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+	if err := cell.Start(context.Background()); err != nil {
+		t.Fatalf("Failed to start cell: %v", err)
+	}
+	defer cell.Stop()
+
+	// Allow some time for the cell to start
+	time.Sleep(time.Millisecond * 200)
+
+	for _, id := range []PlayerID{"p1", "p2"} {
+		if err := cell.AddPlayer(&PlayerState{ID: id, Position: WorldPosition{X: 10, Y: 10}}); err != nil {
+			t.Fatalf("Failed to add player: %v", err)
+		}
+	}
+	cell.SetGameStateValue("weather", "rain")
+	cell.SetGameStateValue("event", "siege")
+
+	kind, base, err := cell.nextCheckpoint()
+	if err != nil || kind != CheckpointFull {
+		t.Fatalf("Expected first checkpoint to be a full snapshot, got %s (err %v)", kind, err)
+	}
+
+	// First delta: p1 moves, p2 leaves, p3 joins, weather changes
+	if err := cell.UpdatePlayerPosition("p1", WorldPosition{X: 500, Y: 500}); err != nil {
+		t.Fatalf("Failed to move player: %v", err)
+	}
+	if err := cell.RemovePlayer("p2"); err != nil {
+		t.Fatalf("Failed to remove player: %v", err)
+	}
+	if err := cell.AddPlayer(&PlayerState{ID: "p3", Position: WorldPosition{X: 20, Y: 20}}); err != nil {
+		t.Fatalf("Failed to add player: %v", err)
+	}
+	cell.SetGameStateValue("weather", "clear")
+
+	kind, delta1, err := cell.nextCheckpoint()
+	if err != nil || kind != CheckpointDelta {
+		t.Fatalf("Expected a delta checkpoint, got %s (err %v)", kind, err)
+	}
+
+	// Second delta: the event ends
+	cell.DeleteGameStateValue("event")
+	delta2, err := cell.CheckpointDelta()
+	if err != nil {
+		t.Fatalf("Failed to create delta checkpoint: %v", err)
+	}
+
+	if len(delta2) >= len(base) {
+		t.Errorf("Expected a game state delta (%d bytes) to be smaller than the snapshot (%d bytes)", len(delta2), len(base))
+	}
+
+	restored, err := NewCell(CellSpec{ID: "delta-cell", Boundaries: createTestBounds()})
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+	if err := restored.Restore(base, delta1, delta2); err != nil {
+		t.Fatalf("Failed to restore delta chain: %v", err)
+	}
+
+	state := restored.GetState()
+	if state.PlayerCount != 2 || state.Players["p2"] != nil || state.Players["p3"] == nil {
+		t.Errorf("Expected players p1 and p3 after replay, got %v", state.Players)
+	}
+	if pos := state.Players["p1"].Position; pos.X != 500 || pos.Y != 500 {
+		t.Errorf("Expected p1 at (500, 500), got %v", pos)
+	}
+	if state.GameState["weather"] != "clear" {
+		t.Errorf("Expected weather to be clear, got %v", state.GameState["weather"])
+	}
+	if _, exists := state.GameState["event"]; exists {
+		t.Errorf("Expected event to be removed by the second delta")
+	}
+
+	// Deltas replayed out of order are rejected
+	if err := restored.Restore(base, delta2); err == nil {
+		t.Errorf("Expected an error when skipping a delta")
+	}
+}
+
+func TestCell_NextCheckpointTakesPeriodicFullSnapshots(t *testing.T) {
+	cell, err := NewCell(CellSpec{ID: "snapshot-cell", Boundaries: createTestBounds()})
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+	cell.SetDeltasPerSnapshot(2)
+
+	if _, err := cell.CheckpointDelta(); !errors.Is(err, ErrCheckpointNoBase) {
+		t.Errorf("Expected ErrCheckpointNoBase before the first snapshot, got %v", err)
+	}
+
+	expected := []CheckpointKind{CheckpointFull, CheckpointDelta, CheckpointDelta, CheckpointFull, CheckpointDelta}
+	for i, want := range expected {
+		kind, _, err := cell.nextCheckpoint()
+		if err != nil {
+			t.Fatalf("Failed to create checkpoint %d: %v", i, err)
+		}
+		if kind != want {
+			t.Errorf("Checkpoint %d: expected %s, got %s", i, want, kind)
+		}
+	}
+}
+
+func TestLocalCheckpointStore_DeltaChainAndRetention(t *testing.T) {
+	store, err := NewLocalCheckpointStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("Failed to create checkpoint store: %v", err)
+	}
+
+	if _, err := store.Save("cell-a", CheckpointDelta, []byte("{}")); !errors.Is(err, ErrCheckpointNoBase) {
+		t.Errorf("Expected ErrCheckpointNoBase for a delta without a base, got %v", err)
+	}
+
+	kinds := []CheckpointKind{CheckpointFull, CheckpointDelta, CheckpointFull, CheckpointDelta, CheckpointDelta}
+	for i, kind := range kinds {
+		if _, err := store.Save("cell-a", kind, []byte{byte('a' + i)}); err != nil {
+			t.Fatalf("Failed to save checkpoint: %v", err)
+		}
+	}
+
+	base, deltas, err := LoadCheckpointChain(store, "cell-a", 5)
+	if err != nil {
+		t.Fatalf("Failed to load checkpoint chain: %v", err)
+	}
+	if string(base) != "c" || len(deltas) != 2 || string(deltas[0]) != "d" || string(deltas[1]) != "e" {
+		t.Errorf("Expected base c with deltas d, e; got %s with %q", base, deltas)
+	}
+
+	// Everything is expired, but the newest delta still needs its base and earlier deltas
+	removed, err := store.Prune("cell-a", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to prune checkpoints: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 checkpoints pruned, got %d", removed)
+	}
+	if _, _, err := LoadCheckpointChain(store, "cell-a", 5); err != nil {
+		t.Errorf("Expected the newest chain to survive pruning: %v", err)
+	}
+}
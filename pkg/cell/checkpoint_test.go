@@ -0,0 +1,316 @@
+package cell
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLocalCheckpointStore_SaveAndLoadVersions(t *testing.T) {
+	store, err := NewLocalCheckpointStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("Failed to create checkpoint store: %v", err)
+	}
+
+	first, err := store.Save("cell-a", CheckpointFull, []byte(`{"version":1}`))
+	if err != nil {
+		t.Fatalf("Failed to save checkpoint: %v", err)
+	}
+	second, err := store.Save("cell-a", CheckpointFull, []byte(`{"version":2}`))
+	if err != nil {
+		t.Fatalf("Failed to save checkpoint: %v", err)
+	}
+
+	if first.Version != 1 || second.Version != 2 {
+		t.Errorf("Expected versions 1 and 2, got %d and %d", first.Version, second.Version)
+	}
+
+	data, info, err := store.Load("cell-a", 1)
+	if err != nil {
+		t.Fatalf("Failed to load checkpoint: %v", err)
+	}
+	if string(data) != `{"version":1}` || info.Checksum != first.Checksum {
+		t.Errorf("Loaded checkpoint does not match saved one: %s", data)
+	}
+
+	infos, err := store.List("cell-a")
+	if err != nil {
+		t.Fatalf("Failed to list checkpoints: %v", err)
+	}
+	if len(infos) != 2 || infos[0].Version != 2 {
+		t.Errorf("Expected 2 checkpoints newest first, got %v", infos)
+	}
+
+	if _, _, err := store.Load("cell-a", 3); !errors.Is(err, ErrCheckpointNotFound) {
+		t.Errorf("Expected ErrCheckpointNotFound, got %v", err)
+	}
+
+	// A new store over the same directory continues the version sequence
+	reopened, err := NewLocalCheckpointStore(store.baseDir, 0)
+	if err != nil {
+		t.Fatalf("Failed to reopen checkpoint store: %v", err)
+	}
+	third, err := reopened.Save("cell-a", CheckpointFull, []byte(`{"version":3}`))
+	if err != nil {
+		t.Fatalf("Failed to save checkpoint: %v", err)
+	}
+	if third.Version != 3 {
+		t.Errorf("Expected version 3 after reopening, got %d", third.Version)
+	}
+}
+
+func TestLocalCheckpointStore_DetectsCorruption(t *testing.T) {
+	store, err := NewLocalCheckpointStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("Failed to create checkpoint store: %v", err)
+	}
+
+	info, err := store.Save("cell-a", CheckpointFull, []byte(`{"players":{}}`))
+	if err != nil {
+		t.Fatalf("Failed to save checkpoint: %v", err)
+	}
+
+	path := store.checkpointPath("cell-a", info.Version)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read checkpoint file: %v", err)
+	}
+	raw[len(raw)-2] = 'X'
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		t.Fatalf("Failed to write checkpoint file: %v", err)
+	}
+
+	if _, _, err := store.Load("cell-a", info.Version); !errors.Is(err, ErrCheckpointCorrupt) {
+		t.Errorf("Expected ErrCheckpointCorrupt, got %v", err)
+	}
+}
+
+func TestLocalCheckpointStore_PruneKeepsNewest(t *testing.T) {
+	store, err := NewLocalCheckpointStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("Failed to create checkpoint store: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := store.Save("cell-a", CheckpointFull, []byte("{}")); err != nil {
+			t.Fatalf("Failed to save checkpoint: %v", err)
+		}
+	}
+
+	removed, err := store.Prune("cell-a", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to prune checkpoints: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 checkpoints pruned, got %d", removed)
+	}
+
+	infos, err := store.List("cell-a")
+	if err != nil {
+		t.Fatalf("Failed to list checkpoints: %v", err)
+	}
+	if len(infos) != 1 || infos[0].Version != 3 {
+		t.Errorf("Expected only the newest checkpoint to remain, got %v", infos)
+	}
+}
+
+func TestCellManager_CheckpointPersistsToStore(t *testing.T) {
+	store, err := NewLocalCheckpointStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("Failed to create checkpoint store: %v", err)
+	}
+
+	manager := NewCellManager().(*DefaultCellManager)
+	defer manager.Shutdown()
+	manager.SetCheckpointStore(store, time.Minute)
+
+	spec := CellSpec{
+		ID:         "persisted-cell",
+		Boundaries: createTestBounds(),
+		Capacity:   CellCapacity{MaxPlayers: 10},
+	}
+	if _, err := manager.CreateCell(spec); err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+
+	// Wait for cell to be ready
+	time.Sleep(time.Millisecond * 150)
+
+	if err := manager.AddPlayer(spec.ID, &PlayerState{ID: "player-1", Position: WorldPosition{X: 10, Y: 10}}); err != nil {
+		t.Fatalf("Failed to add player: %v", err)
+	}
+
+	if err := manager.Checkpoint(spec.ID); err != nil {
+		t.Fatalf("Failed to checkpoint cell: %v", err)
+	}
+
+	infos, err := store.List(spec.ID)
+	if err != nil || len(infos) != 1 {
+		t.Fatalf("Expected 1 stored checkpoint, got %v (err %v)", infos, err)
+	}
+
+	data, _, err := store.Load(spec.ID, infos[0].Version)
+	if err != nil {
+		t.Fatalf("Failed to load checkpoint: %v", err)
+	}
+
+	restored, err := NewCell(spec)
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+	if err := restored.Restore(data); err != nil {
+		t.Fatalf("Failed to restore checkpoint: %v", err)
+	}
+	if state := restored.GetState(); state.PlayerCount != 1 {
+		t.Errorf("Expected 1 restored player, got %d", state.PlayerCount)
+	}
+}
+
+func TestCellManager_RecoversFromNewestValidCheckpoint(t *testing.T) {
+	store, err := NewLocalCheckpointStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("Failed to create checkpoint store: %v", err)
+	}
+
+	spec := CellSpec{
+		ID:         "recovered-cell",
+		Boundaries: createTestBounds(),
+		Capacity:   CellCapacity{MaxPlayers: 10},
+	}
+
+	// Write a good checkpoint with two players, then a corrupt newer one
+	source, err := NewCell(spec)
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+	source.state.Ready = true
+	for _, id := range []PlayerID{"p1", "p2"} {
+		if err := source.AddPlayer(&PlayerState{ID: id, Position: WorldPosition{X: 10, Y: 10}}); err != nil {
+			t.Fatalf("Failed to add player: %v", err)
+		}
+	}
+	source.state.Tick = 42
+	if _, err := source.persistCheckpoint(store); err != nil {
+		t.Fatalf("Failed to persist checkpoint: %v", err)
+	}
+	corrupt, err := store.Save(spec.ID, CheckpointFull, []byte("{}"))
+	if err != nil {
+		t.Fatalf("Failed to save checkpoint: %v", err)
+	}
+	if err := os.WriteFile(store.checkpointPath(spec.ID, corrupt.Version), []byte("garbage"), 0o644); err != nil {
+		t.Fatalf("Failed to corrupt checkpoint: %v", err)
+	}
+
+	manager := NewCellManager().(*DefaultCellManager)
+	defer manager.Shutdown()
+	manager.SetCheckpointStore(store, time.Minute)
+
+	recovered, err := manager.RecoverCells()
+	if err != nil {
+		t.Fatalf("Failed to recover cells: %v", err)
+	}
+	if len(recovered) != 1 || recovered[0] != spec.ID {
+		t.Fatalf("Expected %s to be recovered, got %v", spec.ID, recovered)
+	}
+
+	cell, err := manager.GetCell(spec.ID)
+	if err != nil {
+		t.Fatalf("Failed to get recovered cell: %v", err)
+	}
+	state := cell.GetState()
+	if state.PlayerCount != 2 || state.Tick < 42 {
+		t.Errorf("Expected 2 players from tick 42, got %d players at tick %d", state.PlayerCount, state.Tick)
+	}
+	if state.Capacity.MaxPlayers != 10 {
+		t.Errorf("Expected capacity to be recovered, got %d", state.Capacity.MaxPlayers)
+	}
+
+	// Recovered players are tracked by the manager like any other player
+	if err := manager.RemovePlayer(spec.ID, "p1"); err != nil {
+		t.Errorf("Failed to remove recovered player: %v", err)
+	}
+}
+
+func TestCellManager_RecoveredSplitChildrenMergeBack(t *testing.T) {
+	store, err := NewLocalCheckpointStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("Failed to create checkpoint store: %v", err)
+	}
+
+	// The parent checkpoints before it splits and its children after
+	before := NewCellManagerWithCooldown(time.Millisecond).(*DefaultCellManager)
+	before.SetCheckpointStore(store, time.Hour)
+	spec := CellSpec{
+		ID:         "parent",
+		Boundaries: createTestBounds(),
+		Capacity:   CellCapacity{MaxPlayers: 10},
+	}
+	if _, err := before.CreateCell(spec); err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+	for _, player := range []*PlayerState{
+		{ID: "p1", Position: WorldPosition{X: 100, Y: 500}},
+		{ID: "p2", Position: WorldPosition{X: 900, Y: 500}},
+	} {
+		if err := before.AddPlayer(spec.ID, player); err != nil {
+			t.Fatalf("Failed to add player: %v", err)
+		}
+	}
+	if err := before.Checkpoint(spec.ID); err != nil {
+		t.Fatalf("Failed to checkpoint parent: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	children, err := before.ManualSplitCell(spec.ID, nil)
+	if err != nil {
+		t.Fatalf("Failed to split cell: %v", err)
+	}
+	for _, child := range children {
+		if err := before.Checkpoint(child.GetState().ID); err != nil {
+			t.Fatalf("Failed to checkpoint child: %v", err)
+		}
+	}
+	before.Shutdown()
+
+	// After a restart only the children come back, still knowing their parent
+	manager := NewCellManagerWithCooldown(time.Millisecond).(*DefaultCellManager)
+	defer manager.Shutdown()
+	manager.SetCheckpointStore(store, time.Hour)
+	manager.SetAutoMerge(0.3, time.Hour)
+
+	recovered, err := manager.RecoverCells()
+	if err != nil {
+		t.Fatalf("Failed to recover cells: %v", err)
+	}
+	if len(recovered) != 2 {
+		t.Fatalf("Expected the 2 children to be recovered without the stale parent, got %v", recovered)
+	}
+	for _, cellID := range recovered {
+		cell, err := manager.GetCell(cellID)
+		if err != nil {
+			t.Fatalf("Failed to get recovered cell: %v", err)
+		}
+		if state := cell.GetState(); state.ParentID == nil || *state.ParentID != spec.ID || state.Generation != 1 {
+			t.Errorf("Expected %s to keep its lineage, got parent %v generation %d", cellID, state.ParentID, state.Generation)
+		}
+	}
+
+	// Wait for the recovered cells to be ready
+	time.Sleep(150 * time.Millisecond)
+
+	now := time.Now()
+	manager.evaluateMerges(now)
+	merged := manager.evaluateMerges(now.Add(time.Hour))
+	if len(merged) != 1 {
+		t.Fatalf("Expected the recovered children to merge back, got %d merges", len(merged))
+	}
+	state := merged[0].GetState()
+	if state.ID != spec.ID || state.PlayerCount != 2 || state.Generation != 0 {
+		t.Errorf("Expected the root parent to be restored with 2 players, got %s gen %d with %d", state.ID, state.Generation, state.PlayerCount)
+	}
+	if state.Boundaries.XMin != 0 || state.Boundaries.XMax != 1000 || state.Capacity.MaxPlayers != 10 {
+		t.Errorf("Expected the parent's bounds and capacity, got %+v %+v", state.Boundaries, state.Capacity)
+	}
+}
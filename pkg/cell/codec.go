@@ -0,0 +1,118 @@
+package cell
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// StateCodec encodes cell state and deltas for checkpoints
+type StateCodec interface {
+	// Name returns the codec name used to select it
+	Name() string
+
+	// Marshal encodes a value
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal decodes data produced by Marshal into v
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// State codec selection through CellSpec.GameConfig
+const (
+	// StateCodecConfigKey is the GameConfig key selecting a cell's state codec
+	StateCodecConfigKey = "stateCodec"
+
+	// StateCodecJSON selects the JSON codec (default)
+	StateCodecJSON = "json"
+
+	// StateCodecBinary selects the compact binary codec
+	StateCodecBinary = "binary"
+)
+
+// binaryCodecMagic prefixes binary-encoded state so it can be told apart from JSON
+var binaryCodecMagic = []byte("FFB1")
+
+func init() {
+	// Nested game state values travel as interface values and must be registered
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
+// JSONCodec encodes state as JSON. Numbers in GameState maps decode as float64.
+type JSONCodec struct{}
+
+// Name returns the codec name
+func (JSONCodec) Name() string { return StateCodecJSON }
+
+// Marshal encodes a value as JSON
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes JSON into v
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// BinaryCodec encodes state in a compact binary format that keeps the concrete
+// numeric types of GameState values, so an int stays an int across a round-trip
+type BinaryCodec struct{}
+
+// Name returns the codec name
+func (BinaryCodec) Name() string { return StateCodecBinary }
+
+// Marshal encodes a value in the binary format
+func (BinaryCodec) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(binaryCodecMagic)
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes binary data into v
+func (BinaryCodec) Unmarshal(data []byte, v interface{}) error {
+	if !bytes.HasPrefix(data, binaryCodecMagic) {
+		return fmt.Errorf("data is not binary encoded state")
+	}
+	return gob.NewDecoder(bytes.NewReader(data[len(binaryCodecMagic):])).Decode(v)
+}
+
+// NewStateCodec returns the codec registered under a name; an empty name selects JSON
+func NewStateCodec(name string) (StateCodec, error) {
+	switch name {
+	case "", StateCodecJSON:
+		return JSONCodec{}, nil
+	case StateCodecBinary:
+		return BinaryCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unknown state codec %q", name)
+	}
+}
+
+// stateCodecFromConfig returns the state codec requested by a cell's game config
+func stateCodecFromConfig(gameConfig map[string]interface{}) (StateCodec, error) {
+	value, exists := gameConfig[StateCodecConfigKey]
+	if !exists {
+		return JSONCodec{}, nil
+	}
+
+	name, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a string, got %T", StateCodecConfigKey, value)
+	}
+
+	return NewStateCodec(name)
+}
+
+// decodeState decodes checkpoint data with whichever codec produced it, so cells
+// can restore checkpoints written before their world switched codecs
+func decodeState(data []byte, v interface{}) error {
+	if bytes.HasPrefix(data, binaryCodecMagic) {
+		return BinaryCodec{}.Unmarshal(data, v)
+	}
+	return JSONCodec{}.Unmarshal(data, v)
+}
@@ -0,0 +1,99 @@
+package cell
+
+import (
+	"testing"
+)
+
+func TestBinaryCodec_PreservesNumericTypes(t *testing.T) {
+	state := CellState{
+		ID:      "codec-cell",
+		Players: map[PlayerID]*PlayerState{"p1": {ID: "p1", GameState: map[string]interface{}{"level": 7}}},
+		GameState: map[string]interface{}{
+			"round":   int64(12),
+			"score":   42,
+			"ratio":   0.5,
+			"name":    "arena",
+			"nested":  map[string]interface{}{"count": uint32(3)},
+			"history": []interface{}{1, "two", 3.0},
+		},
+	}
+
+	codec := BinaryCodec{}
+	data, err := codec.Marshal(state)
+	if err != nil {
+		t.Fatalf("Failed to marshal state: %v", err)
+	}
+
+	var decoded CellState
+	if err := decodeState(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal state: %v", err)
+	}
+
+	if v, ok := decoded.GameState["round"].(int64); !ok || v != 12 {
+		t.Errorf("Expected round to stay int64 12, got %T %v", decoded.GameState["round"], decoded.GameState["round"])
+	}
+	if v, ok := decoded.GameState["score"].(int); !ok || v != 42 {
+		t.Errorf("Expected score to stay int 42, got %T %v", decoded.GameState["score"], decoded.GameState["score"])
+	}
+	if nested, ok := decoded.GameState["nested"].(map[string]interface{}); !ok || nested["count"] != uint32(3) {
+		t.Errorf("Expected nested count to stay uint32 3, got %v", decoded.GameState["nested"])
+	}
+	if history, ok := decoded.GameState["history"].([]interface{}); !ok || history[0] != 1 {
+		t.Errorf("Expected history[0] to stay int 1, got %v", decoded.GameState["history"])
+	}
+	if level, ok := decoded.Players["p1"].GameState["level"].(int); !ok || level != 7 {
+		t.Errorf("Expected player level to stay int 7, got %v", decoded.Players["p1"].GameState["level"])
+	}
+
+	// JSON, by contrast, decodes every number as a float64
+	jsonData, err := JSONCodec{}.Marshal(state)
+	if err != nil {
+		t.Fatalf("Failed to marshal state: %v", err)
+	}
+	var fromJSON CellState
+	if err := decodeState(jsonData, &fromJSON); err != nil {
+		t.Fatalf("Failed to unmarshal JSON state: %v", err)
+	}
+	if _, ok := fromJSON.GameState["score"].(float64); !ok {
+		t.Errorf("Expected JSON to decode score as float64, got %T", fromJSON.GameState["score"])
+	}
+}
+
+func TestCell_RestoreAcrossCodecs(t *testing.T) {
+	spec := CellSpec{
+		ID:         "binary-cell",
+		Boundaries: createTestBounds(),
+		GameConfig: map[string]interface{}{StateCodecConfigKey: StateCodecBinary},
+	}
+	cell, err := NewCell(spec)
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+	cell.SetGameStateValue("wave", 3)
+
+	kind, base, err := cell.nextCheckpoint()
+	if err != nil || kind != CheckpointFull {
+		t.Fatalf("Expected a full snapshot, got %s (err %v)", kind, err)
+	}
+	cell.SetGameStateValue("wave", 4)
+	delta, err := cell.CheckpointDelta()
+	if err != nil {
+		t.Fatalf("Failed to create delta checkpoint: %v", err)
+	}
+
+	// A JSON cell can restore the binary chain
+	restored, err := NewCell(CellSpec{ID: "binary-cell", Boundaries: createTestBounds()})
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+	if err := restored.Restore(base, delta); err != nil {
+		t.Fatalf("Failed to restore binary checkpoint: %v", err)
+	}
+	if wave, _ := restored.GetGameStateValue("wave"); wave != 4 {
+		t.Errorf("Expected wave to be int 4, got %T %v", wave, wave)
+	}
+
+	if _, err := NewCell(CellSpec{ID: "bad", GameConfig: map[string]interface{}{StateCodecConfigKey: "xml"}}); err == nil {
+		t.Errorf("Expected an unknown state codec to be rejected")
+	}
+}
@@ -0,0 +1,150 @@
+package cell
+
+import (
+	"time"
+
+	v1 "github.com/astrosteveo/fleetforge/api/v1"
+)
+
+// GhostEntity is a read-only proxy of a player owned by a neighboring cell.
+// Ghosts let players near a cell boundary see players on the other side of it.
+type GhostEntity struct {
+	PlayerID     PlayerID               `json:"playerId"`
+	SourceCellID CellID                 `json:"sourceCellId"`
+	Position     WorldPosition          `json:"position"`
+	GameState    map[string]interface{} `json:"gameState,omitempty"`
+	SourceTick   int64                  `json:"sourceTick"`
+	ExpiresAt    time.Time              `json:"expiresAt"`
+}
+
+// ghostTTLUpdates is how many missed replication rounds a ghost survives before expiring
+const ghostTTLUpdates = 3
+
+// collectGhosts builds ghosts for every player within the sync radius of a neighbor's bounds
+func (c *Cell) collectGhosts(bounds v1.WorldBounds) []GhostEntity {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	expiresAt := time.Now().Add(c.ghostTTL())
+	ghosts := make([]GhostEntity, 0)
+
+	for _, player := range c.state.Players {
+		if distanceToBounds(player.Position, bounds) > c.aoiConfig.SyncRadius {
+			continue
+		}
+
+		ghost := GhostEntity{
+			PlayerID:     player.ID,
+			SourceCellID: c.state.ID,
+			Position:     player.Position,
+			SourceTick:   c.state.Tick,
+			ExpiresAt:    expiresAt,
+		}
+		if player.GameState != nil {
+			ghost.GameState = make(map[string]interface{}, len(player.GameState))
+			for k, v := range player.GameState {
+				ghost.GameState[k] = v
+			}
+		}
+		ghosts = append(ghosts, ghost)
+	}
+
+	return ghosts
+}
+
+// ApplyGhosts replaces every ghost published by a source cell. Players the source
+// no longer publishes, because they moved away from the boundary, disappear at once.
+func (c *Cell) ApplyGhosts(sourceCellID CellID, ghosts []GhostEntity) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(ghosts) == 0 {
+		delete(c.ghosts, sourceCellID)
+		return
+	}
+
+	fromSource := make(map[PlayerID]*GhostEntity, len(ghosts))
+	for i := range ghosts {
+		ghost := ghosts[i]
+		fromSource[ghost.PlayerID] = &ghost
+	}
+	c.ghosts[sourceCellID] = fromSource
+}
+
+// GetGhosts returns copies of all ghosts currently held by the cell
+func (c *Cell) GetGhosts() []GhostEntity {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ghosts := make([]GhostEntity, 0)
+	for _, fromSource := range c.ghosts {
+		for _, ghost := range fromSource {
+			ghosts = append(ghosts, *ghost)
+		}
+	}
+
+	return ghosts
+}
+
+// GetGhostsInArea returns copies of the ghosts within a radius of a position
+func (c *Cell) GetGhostsInArea(center WorldPosition, radius float64) []GhostEntity {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var ghosts []GhostEntity
+	for _, fromSource := range c.ghosts {
+		for _, ghost := range fromSource {
+			if distance(center, ghost.Position) <= radius {
+				ghosts = append(ghosts, *ghost)
+			}
+		}
+	}
+
+	return ghosts
+}
+
+// pruneExpiredGhosts drops ghosts whose source stopped refreshing them
+func (c *Cell) pruneExpiredGhosts(now time.Time) {
+	for sourceID, fromSource := range c.ghosts {
+		for playerID, ghost := range fromSource {
+			if now.After(ghost.ExpiresAt) {
+				delete(fromSource, playerID)
+			}
+		}
+		if len(fromSource) == 0 {
+			delete(c.ghosts, sourceID)
+		}
+	}
+}
+
+// ghostTTL returns how long a ghost stays valid without being refreshed
+func (c *Cell) ghostTTL() time.Duration {
+	return time.Duration(ghostTTLUpdates*c.ghostUpdateFrequency()) * c.tickRate
+}
+
+// ghostUpdateFrequency returns the number of ticks between ghost replication rounds
+func (c *Cell) ghostUpdateFrequency() int {
+	if c.aoiConfig.UpdateFrequency <= 0 {
+		return 1
+	}
+	return c.aoiConfig.UpdateFrequency
+}
+
+// replicateGhosts publishes ghosts of a cell's border players to each of its neighbors
+func (m *DefaultCellManager) replicateGhosts(cellID CellID) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	source, exists := m.cells[cellID]
+	if !exists {
+		return
+	}
+
+	for _, neighborID := range m.mesh.Neighbors(cellID) {
+		neighbor, exists := m.cells[neighborID]
+		if !exists {
+			continue
+		}
+		neighbor.ApplyGhosts(cellID, source.collectGhosts(neighbor.state.Boundaries))
+	}
+}
@@ -0,0 +1,110 @@
+package cell
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCell_ApplyGhostsReplacesSourceSet(t *testing.T) {
+	cell, err := NewCell(CellSpec{ID: "ghost-cell", Boundaries: createTestBounds()})
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+
+	expiresAt := time.Now().Add(time.Minute)
+	cell.ApplyGhosts("east", []GhostEntity{
+		{PlayerID: "p1", SourceCellID: "east", Position: WorldPosition{X: 1010, Y: 10}, ExpiresAt: expiresAt},
+		{PlayerID: "p2", SourceCellID: "east", Position: WorldPosition{X: 1020, Y: 10}, ExpiresAt: expiresAt},
+	})
+	cell.ApplyGhosts("north", []GhostEntity{
+		{PlayerID: "p3", SourceCellID: "north", Position: WorldPosition{X: 10, Y: 1010}, ExpiresAt: expiresAt},
+	})
+
+	if got := cell.GetGhosts(); len(got) != 3 {
+		t.Fatalf("Expected 3 ghosts, got %d", len(got))
+	}
+
+	// p2 moved away from the boundary, so east stops publishing it
+	cell.ApplyGhosts("east", []GhostEntity{
+		{PlayerID: "p1", SourceCellID: "east", Position: WorldPosition{X: 1005, Y: 10}, ExpiresAt: expiresAt},
+	})
+	if got := cell.GetGhosts(); len(got) != 2 {
+		t.Errorf("Expected 2 ghosts after east refresh, got %d", len(got))
+	}
+
+	if got := cell.GetGhostsInArea(WorldPosition{X: 1000, Y: 10}, 10); len(got) != 1 || got[0].PlayerID != "p1" {
+		t.Errorf("Expected only p1 near the east border, got %v", got)
+	}
+
+	// Ghosts that are not refreshed expire
+	cell.pruneExpiredGhosts(expiresAt.Add(time.Second))
+	if got := cell.GetGhosts(); len(got) != 0 {
+		t.Errorf("Expected all ghosts to expire, got %d", len(got))
+	}
+}
+
+func TestCellManager_ReplicatesGhostsAcrossBorder(t *testing.T) {
+	manager := NewCellManager().(*DefaultCellManager)
+	defer manager.Shutdown()
+
+	west, err := manager.CreateCell(CellSpec{
+		ID:         "west",
+		Boundaries: createCustomBounds(0, 500, 0, 1000),
+		Capacity:   CellCapacity{MaxPlayers: 10},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+	east, err := manager.CreateCell(CellSpec{
+		ID:         "east",
+		Boundaries: createCustomBounds(500, 1000, 0, 1000),
+		Capacity:   CellCapacity{MaxPlayers: 10},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	// One player on each side of the border, one deep inside west
+	players := []struct {
+		cell CellID
+		id   PlayerID
+		pos  WorldPosition
+	}{
+		{"west", "border-west", WorldPosition{X: 480, Y: 500}},
+		{"east", "border-east", WorldPosition{X: 520, Y: 500}},
+		{"west", "inland", WorldPosition{X: 50, Y: 500}},
+	}
+	for _, p := range players {
+		if err := manager.AddPlayer(p.cell, &PlayerState{ID: p.id, Position: p.pos}); err != nil {
+			t.Fatalf("Failed to add player %s: %v", p.id, err)
+		}
+	}
+
+	// Default configuration replicates every 5 ticks of 50ms
+	time.Sleep(600 * time.Millisecond)
+
+	eastGhosts := east.GetGhosts()
+	if len(eastGhosts) != 1 || eastGhosts[0].PlayerID != "border-west" {
+		t.Fatalf("Expected east to see border-west as a ghost, got %v", eastGhosts)
+	}
+	if eastGhosts[0].SourceCellID != "west" {
+		t.Errorf("Expected ghost source west, got %s", eastGhosts[0].SourceCellID)
+	}
+
+	westGhosts := west.GetGhosts()
+	if len(westGhosts) != 1 || westGhosts[0].PlayerID != "border-east" {
+		t.Fatalf("Expected west to see border-east as a ghost, got %v", westGhosts)
+	}
+
+	// Moving away from the border removes the ghost on the next refresh
+	if err := manager.UpdatePlayerPosition("west", "border-west", WorldPosition{X: 100, Y: 500}); err != nil {
+		t.Fatalf("Failed to move player: %v", err)
+	}
+	time.Sleep(600 * time.Millisecond)
+
+	if got := east.GetGhosts(); len(got) != 0 {
+		t.Errorf("Expected ghost to expire after player moved away, got %v", got)
+	}
+}
@@ -2,13 +2,21 @@ package cell
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	v1 "github.com/astrosteveo/fleetforge/api/v1"
 )
 
+// ErrCellLimitReached is returned when a split or merge would take the number of
+// cells outside the configured MinCells/MaxCells budget
+var ErrCellLimitReached = errors.New("cell limit reached")
+
 // DefaultCellManager implements the CellManager interface
 type DefaultCellManager struct {
 	cells    map[CellID]*Cell
@@ -22,11 +30,73 @@ type DefaultCellManager struct {
 	defaultSplitThreshold float64
 	splitCooldownDuration time.Duration
 	lastSplitTimes        map[CellID]time.Time
+	splitStrategy         SplitStrategy
+	splitParents          map[CellID]*splitParent
+	splitHysteresis       float64
+	sustainedBreach       time.Duration
+	splitBackoffLevels    map[CellID]int         // Cells born from a split or merge, and how far their cooldown has backed off
+	lineageChanges        map[CellID][]time.Time // Recent splits and merges of each cell, to detect oscillation
+
+	// Automatic merge configuration; a zero scale-down threshold disables merging
+	scaleDownThreshold    float64
+	mergeHysteresis       time.Duration
+	mergeCooldownDuration time.Duration
+	lastMergeTimes        map[CellID]time.Time
+	underloadedSince      map[CellID]time.Time // Keyed by the parent of each underloaded sibling group
+	mergeBlockedUntil     map[CellID]time.Time // End of the cooldown last reported for each sibling group
+	mergeLoopStarted      bool
+
+	// Predictive scaling; a nil predictor disables it
+	predictor             DensityPredictor
+	predictiveThreshold   float64
+	predictionHorizon     time.Duration
+	predictiveLoopStarted bool
+	predictionEventIndex  int                  // Events before this index were fed to the predictor
+	lastPredictedSplits   map[CellID]time.Time // Last pre-split attempt per cell
+
+	// World-level cell budget; zero means unlimited
+	minCells int
+	maxCells int
+
+	// Adjacency graph of live cells
+	mesh *CellMesh
+
+	// Checkpoint persistence
+	checkpointStore    CheckpointStore
+	checkpointInterval time.Duration
+
+	// Simulation tick interval for new cells; zero keeps the cell default
+	tickRate time.Duration
+
+	// Notified of the players each split or merge moves to another cell
+	onPlayersReassigned func(reassignments []PlayerReassignment)
+
+	// Performs automatic splits elsewhere instead of in this process when set
+	onSplitRequested func(cellID CellID, reason string)
 
 	// Metrics
 	metrics *PrometheusMetrics
 }
 
+// splitParent remembers a split cell so its children can later be merged back into it
+type splitParent struct {
+	spec         CellSpec
+	parentID     *CellID
+	generation   int
+	siblingIDs   []CellID
+	children     []CellID
+	backoffLevel int
+}
+
+// state returns what the parent's children checkpoint about it
+func (p *splitParent) state() *SplitParentState {
+	return &SplitParentState{
+		Spec:       p.spec,
+		ParentID:   p.parentID,
+		SiblingIDs: p.siblingIDs,
+	}
+}
+
 // PlayerSessionInfo tracks player session information
 type PlayerSessionInfo struct {
 	PlayerID PlayerID      `json:"playerId"`
@@ -62,6 +132,17 @@ func NewCellManagerWithMetricsAndCooldown(metrics *PrometheusMetrics, cooldownDu
 		defaultSplitThreshold: 0.8, // 80% capacity threshold by default
 		splitCooldownDuration: cooldownDuration,
 		lastSplitTimes:        make(map[CellID]time.Time),
+		splitStrategy:         LongestAxisSplit{},
+		splitParents:          make(map[CellID]*splitParent),
+		splitBackoffLevels:    make(map[CellID]int),
+		lineageChanges:        make(map[CellID][]time.Time),
+		mergeHysteresis:       defaultMergeHysteresis,
+		mergeCooldownDuration: cooldownDuration, // Merges back off as long as splits do
+		lastMergeTimes:        make(map[CellID]time.Time),
+		underloadedSince:      make(map[CellID]time.Time),
+		mergeBlockedUntil:     make(map[CellID]time.Time),
+		lastPredictedSplits:   make(map[CellID]time.Time),
+		mesh:                  NewCellMesh(),
 		metrics:               metrics,
 	}
 }
@@ -81,14 +162,32 @@ func (m *DefaultCellManager) CreateCell(spec CellSpec) (*Cell, error) {
 	}
 
 	// Configure split threshold and callback
-	cell.SetSplitThreshold(m.defaultSplitThreshold)
-	cell.SetOnSplitNeeded(m.handleSplitNeeded)
+	m.configureCell(cell)
+
+	// Recover from the last checkpoint before the cell starts and can report ready
+	restored := m.recoverCell(cell)
 
 	if err := cell.Start(m.ctx); err != nil {
 		return nil, fmt.Errorf("failed to start cell: %w", err)
 	}
 
 	m.cells[spec.ID] = cell
+	m.addToMesh(cell)
+
+	if restored != nil {
+		m.trackRestoredSplitParent(cell)
+		playerCount := m.trackRestoredPlayers(cell)
+		m.events = append(m.events, CellEvent{
+			Type:      CellEventRestored,
+			CellID:    spec.ID,
+			Timestamp: time.Now(),
+			Metadata: map[string]interface{}{
+				"checkpointVersion": restored.Version,
+				"checkpointTime":    restored.CreatedAt,
+				"playerCount":       playerCount,
+			},
+		})
+	}
 
 	// Record cell creation event
 	event := CellEvent{
@@ -140,9 +239,12 @@ func (m *DefaultCellManager) DeleteCell(id CellID) error {
 	}
 
 	delete(m.cells, id)
+	m.removeFromMesh(id)
 
 	// Clean up split time tracking
 	delete(m.lastSplitTimes, id)
+	delete(m.splitBackoffLevels, id)
+	delete(m.lineageChanges, id)
 
 	return nil
 }
@@ -256,25 +358,23 @@ func (m *DefaultCellManager) Checkpoint(cellID CellID) error {
 		return fmt.Errorf("cell with ID %s not found", cellID)
 	}
 
-	checkpoint, err := cell.Checkpoint()
-	if err != nil {
-		return fmt.Errorf("failed to create checkpoint: %w", err)
+	// Without a store we just validate that we can create the checkpoint
+	if m.checkpointStore == nil {
+		if _, err := cell.Checkpoint(); err != nil {
+			return fmt.Errorf("failed to create checkpoint: %w", err)
+		}
+		return nil
 	}
 
-	// TODO: Implement persistent storage for checkpoints
-	// Next steps:
-	// 1. Add file-based persistence with proper error handling
-	// 2. Implement checkpoint versioning and rotation
-	// 3. Add cloud storage integration for production environments
-	// 4. Support for incremental/delta checkpoints
-	// For now, we just validate that we can create the checkpoint
-	_ = checkpoint
+	if _, err := cell.persistCheckpoint(m.checkpointStore); err != nil {
+		return fmt.Errorf("failed to persist checkpoint: %w", err)
+	}
 
 	return nil
 }
 
-// Restore restores a cell's state from a checkpoint
-func (m *DefaultCellManager) Restore(cellID CellID, checkpoint []byte) error {
+// Restore restores a cell's state from a full snapshot followed by its deltas
+func (m *DefaultCellManager) Restore(cellID CellID, checkpoint []byte, deltas ...[]byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -283,9 +383,10 @@ func (m *DefaultCellManager) Restore(cellID CellID, checkpoint []byte) error {
 		return fmt.Errorf("cell with ID %s not found", cellID)
 	}
 
-	if err := cell.Restore(checkpoint); err != nil {
+	if err := cell.Restore(checkpoint, deltas...); err != nil {
 		return fmt.Errorf("failed to restore checkpoint: %w", err)
 	}
+	m.trackRestoredPlayers(cell)
 
 	return nil
 }
@@ -353,6 +454,7 @@ func (m *DefaultCellManager) Shutdown() error {
 	// Clear all data structures
 	m.cells = make(map[CellID]*Cell)
 	m.sessions = make(map[PlayerID]*PlayerSessionInfo)
+	m.mesh = NewCellMesh()
 
 	if len(errors) > 0 {
 		return fmt.Errorf("errors occurred during shutdown: %v", errors)
@@ -430,15 +532,8 @@ func (m *DefaultCellManager) GetPerCellStats() map[CellID]map[string]float64 {
 // handleSplitNeeded is called when a cell needs to be split
 func (m *DefaultCellManager) handleSplitNeeded(cellID CellID, densityRatio float64) {
 	// Check if cell is in cooldown period
-	m.mu.RLock()
-	lastSplitTime, exists := m.lastSplitTimes[cellID]
-	cooldownDuration := m.splitCooldownDuration
-	m.mu.RUnlock()
-
-	now := time.Now()
-	if exists && now.Sub(lastSplitTime) < cooldownDuration {
+	if remainingCooldown := m.splitCooldownRemaining(cellID, time.Now()); remainingCooldown > 0 {
 		// Cell is in cooldown period - log and increment metric
-		remainingCooldown := cooldownDuration - now.Sub(lastSplitTime)
 		fmt.Printf("Split attempt blocked for cell %s: still in cooldown period (%.1f seconds remaining, density ratio: %.2f)\n",
 			cellID, remainingCooldown.Seconds(), densityRatio)
 
@@ -446,6 +541,14 @@ func (m *DefaultCellManager) handleSplitNeeded(cellID CellID, densityRatio float
 		if m.metrics != nil {
 			m.metrics.IncrementSplitCooldownBlocks()
 		}
+
+		// Ask again once the cooldown is over, if the cell is still overloaded
+		m.rearmSplitRequest(cellID, remainingCooldown)
+		return
+	}
+
+	if requester := m.splitRequester(); requester != nil {
+		requester(cellID, "ThresholdExceeded")
 		return
 	}
 
@@ -455,21 +558,138 @@ func (m *DefaultCellManager) handleSplitNeeded(cellID CellID, densityRatio float
 		// Log error but don't fail the calling goroutine
 		// In a real implementation, we'd use proper logging
 		fmt.Printf("Failed to split cell %s: %v\n", cellID, err)
+
+		// The budget may allow the split later, so keep asking at the cooldown pace
+		m.mu.RLock()
+		retryDelay := max(m.splitCooldownDuration, minSplitRetryDelay)
+		m.mu.RUnlock()
+		m.rearmSplitRequest(cellID, retryDelay)
+	}
+}
+
+// minSplitRetryDelay keeps a cell whose split keeps failing from asking on every tick
+const minSplitRetryDelay = time.Second
+
+// rearmSplitRequest lets a cell whose split was declined request it again after the delay
+func (m *DefaultCellManager) rearmSplitRequest(cellID CellID, delay time.Duration) {
+	m.mu.RLock()
+	cell, exists := m.cells[cellID]
+	m.mu.RUnlock()
+
+	if exists {
+		cell.rearmSplitRequest(delay)
+	}
+}
+
+// splitCooldownRemaining returns how long until a cell may split again
+func (m *DefaultCellManager) splitCooldownRemaining(cellID CellID, now time.Time) time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	lastSplitTime, exists := m.lastSplitTimes[cellID]
+	if !exists {
+		return 0
+	}
+	return max(m.splitCooldownFor(m.splitBackoffLevels[cellID])-now.Sub(lastSplitTime), 0)
+}
+
+// Split cooldown backoff
+const (
+	// maxSplitBackoffLevel caps the cooldown at 2^maxSplitBackoffLevel times the base cooldown
+	maxSplitBackoffLevel = 5
+	// oscillationChanges splits and merges of one cell within the oscillation window flag it as oscillating
+	oscillationChanges = 3
+	// oscillationWindowCooldowns is the oscillation window in multiples of the base split cooldown
+	oscillationWindowCooldowns = 6
+)
+
+// splitCooldownFor returns the split cooldown at a backoff level, doubling with
+// each level. The caller must hold the manager lock.
+func (m *DefaultCellManager) splitCooldownFor(level int) time.Duration {
+	return m.splitCooldownDuration << min(level, maxSplitBackoffLevel)
+}
+
+// childBackoffLevel returns the backoff level for the children of a splitting
+// cell. A cell born from a split or merge that splits again before twice its own
+// cooldown has passed hands its children a longer cooldown; otherwise the
+// backoff resets. The caller must hold the manager lock.
+func (m *DefaultCellManager) childBackoffLevel(cell *Cell, now time.Time) int {
+	level, tracked := m.splitBackoffLevels[cell.state.ID]
+	if tracked && now.Sub(cell.startTime) < 2*m.splitCooldownFor(level) {
+		return min(level+1, maxSplitBackoffLevel)
+	}
+	return 0
+}
+
+// recordLineageChange notes that a cell split or was merged back together and
+// emits an oscillation event when that keeps happening within the oscillation
+// window. The caller must hold the manager lock.
+func (m *DefaultCellManager) recordLineageChange(cellID CellID, change string, now time.Time) {
+	window := m.splitCooldownDuration * oscillationWindowCooldowns
+
+	changes := make([]time.Time, 0, len(m.lineageChanges[cellID])+1)
+	for _, at := range m.lineageChanges[cellID] {
+		if now.Sub(at) < window {
+			changes = append(changes, at)
+		}
+	}
+	changes = append(changes, now)
+
+	if len(changes) < oscillationChanges {
+		m.lineageChanges[cellID] = changes
+		return
 	}
+
+	// Start counting afresh so a flapping cell is flagged once per run of changes
+	delete(m.lineageChanges, cellID)
+	fmt.Printf("Cell %s is oscillating: %d splits and merges in %s\n", cellID, len(changes), window)
+
+	m.events = append(m.events, CellEvent{
+		Type:      CellEventOscillating,
+		CellID:    cellID,
+		Timestamp: now,
+		Metadata: map[string]interface{}{
+			"changes":       len(changes),
+			"last_change":   change,
+			"window_s":      window.Seconds(),
+			"backoff_level": m.splitBackoffLevels[cellID],
+		},
+	})
 }
 
 // SplitCell splits a cell when it exceeds the threshold
 func (m *DefaultCellManager) SplitCell(cellID CellID, splitThreshold float64) ([]*Cell, error) {
-	return m.splitCellInternal(cellID, splitThreshold, "ThresholdExceeded", nil)
+	return m.splitCellInternal(cellID, splitThreshold, "ThresholdExceeded", nil, nil)
 }
 
 // ManualSplitCell forces a split regardless of threshold for testing purposes
 func (m *DefaultCellManager) ManualSplitCell(cellID CellID, userInfo map[string]interface{}) ([]*Cell, error) {
-	return m.splitCellInternal(cellID, 0.0, "ManualOverride", userInfo)
+	return m.splitCellInternal(cellID, 0.0, "ManualOverride", userInfo, nil)
+}
+
+// MaxCellIDLength bounds the IDs of split children, so names derived from them
+// such as a cell's "<id>-service" Service stay within the 63 character DNS label limit
+const MaxCellIDLength = 55
+
+// ChildCellID names the nth child of a split. The parent's ID is kept as a
+// prefix while it fits; deeper in the lineage it is cut short and a hash of the
+// full parent ID keeps the children of different parents apart.
+func ChildCellID(parentID CellID, n int) CellID {
+	suffix := fmt.Sprintf("-child-%d", n)
+	if len(parentID)+len(suffix) <= MaxCellIDLength {
+		return parentID + CellID(suffix)
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(parentID))
+	suffix = fmt.Sprintf("-%08x%s", hash.Sum32(), suffix)
+	prefix := strings.TrimRight(string(parentID[:MaxCellIDLength-len(suffix)]), "-")
+	return CellID(prefix + suffix)
 }
 
-// splitCellInternal performs the actual cell split logic
-func (m *DefaultCellManager) splitCellInternal(cellID CellID, splitThreshold float64, reason string, userInfo map[string]interface{}) ([]*Cell, error) {
+// splitCellInternal performs the actual cell split logic. Details are added to
+// the split event's metadata.
+func (m *DefaultCellManager) splitCellInternal(cellID CellID, splitThreshold float64, reason string, userInfo, details map[string]interface{}) ([]*Cell, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -480,8 +700,8 @@ func (m *DefaultCellManager) splitCellInternal(cellID CellID, splitThreshold flo
 
 	parentState := parentCell.GetState()
 
-	// Check if split is really needed (skip check for manual override)
-	if reason != "ManualOverride" {
+	// Check if split is really needed (manual and predicted splits happen below the threshold)
+	if reason == "ThresholdExceeded" {
 		if float64(parentState.PlayerCount)/float64(parentState.Capacity.MaxPlayers) < splitThreshold {
 			return nil, fmt.Errorf("cell %s does not meet split threshold", cellID)
 		}
@@ -489,21 +709,47 @@ func (m *DefaultCellManager) splitCellInternal(cellID CellID, splitThreshold flo
 
 	splitStart := time.Now()
 
-	// Create two child cells by subdividing the parent boundaries
-	childBoundaries := m.subdivideBoundaries(parentState.Boundaries)
+	// Create child cells by subdividing the parent boundaries
+	positions := make([]WorldPosition, 0, len(parentState.Players))
+	for _, player := range parentState.Players {
+		positions = append(positions, player.Position)
+	}
+	childBoundaries := m.subdivideBoundaries(parentState.Boundaries, positions...)
+	predictedPopulations := PredictChildPopulations(childBoundaries, positions)
+
+	// The parent is replaced, so a split adds one cell fewer than it has children
+	if err := m.checkSplitBudget(len(childBoundaries) - 1); err != nil {
+		m.recordSplitDenied(cellID, reason, len(childBoundaries))
+		return nil, fmt.Errorf("cannot split cell %s: %w", cellID, err)
+	}
 
 	childCells := make([]*Cell, 0, len(childBoundaries))
 	childIDs := make([]CellID, 0, len(childBoundaries))
 
+	// Every child checkpoints its parent, so the group can still merge back
+	// into it after a restart
+	parentSpec := CellSpec{
+		ID:         cellID,
+		Boundaries: parentState.Boundaries,
+		Capacity:   parentState.Capacity,
+		GameConfig: parentCell.inheritedGameConfig(),
+	}
+	splitParentState := &SplitParentState{
+		Spec:       parentSpec,
+		ParentID:   parentState.ParentID,
+		SiblingIDs: parentState.SiblingIDs,
+	}
+
 	// Create child cells
 	for i, bounds := range childBoundaries {
-		childID := CellID(fmt.Sprintf("%s-child-%d", cellID, i+1))
+		childID := ChildCellID(cellID, i+1)
 		childIDs = append(childIDs, childID)
 
 		childSpec := CellSpec{
 			ID:         childID,
 			Boundaries: bounds,
 			Capacity:   parentState.Capacity, // Same capacity as parent
+			GameConfig: parentCell.inheritedGameConfig(),
 		}
 
 		childCell, err := NewCell(childSpec)
@@ -519,18 +765,17 @@ func (m *DefaultCellManager) splitCellInternal(cellID CellID, splitThreshold flo
 		childCell.state.ParentID = &cellID
 		childCell.state.Generation = parentState.Generation + 1
 		childCell.state.SiblingIDs = make([]CellID, 0, len(childBoundaries)-1)
+		childCell.state.SplitParent = splitParentState
 
 		// Add other children as siblings (we'll update this after all children are created)
 		for j := range childBoundaries {
 			if j != i {
-				otherChildID := CellID(fmt.Sprintf("%s-child-%d", cellID, j+1))
-				childCell.state.SiblingIDs = append(childCell.state.SiblingIDs, otherChildID)
+				childCell.state.SiblingIDs = append(childCell.state.SiblingIDs, ChildCellID(cellID, j+1))
 			}
 		}
 
 		// Configure child cell
-		childCell.SetSplitThreshold(m.defaultSplitThreshold)
-		childCell.SetOnSplitNeeded(m.handleSplitNeeded)
+		m.configureCell(childCell)
 
 		if err := childCell.Start(m.ctx); err != nil {
 			// Clean up on error
@@ -545,22 +790,7 @@ func (m *DefaultCellManager) splitCellInternal(cellID CellID, splitThreshold flo
 	}
 
 	// Wait for child cells to become ready before redistribution
-	maxWaitTime := time.Millisecond * 200 // Give cells time to start
-	readyCheckInterval := time.Millisecond * 10
-
-	for attempts := 0; attempts < int(maxWaitTime/readyCheckInterval); attempts++ {
-		allReady := true
-		for _, child := range childCells {
-			if !child.GetState().Ready {
-				allReady = false
-				break
-			}
-		}
-		if allReady {
-			break
-		}
-		time.Sleep(readyCheckInterval)
-	}
+	waitForReady(childCells)
 
 	// Redistribute players to child cells based on position with metrics tracking
 	redistributionStart := time.Now()
@@ -570,6 +800,7 @@ func (m *DefaultCellManager) splitCellInternal(cellID CellID, splitThreshold flo
 
 	// Process players in batches for better performance
 	const batchSize = 10
+	reassignments := make([]PlayerReassignment, 0, len(parentState.Players))
 	playerSlice := make([]*PlayerState, 0, len(parentState.Players))
 	for _, player := range parentState.Players {
 		playerSlice = append(playerSlice, player)
@@ -589,6 +820,14 @@ func (m *DefaultCellManager) splitCellInternal(cellID CellID, splitThreshold flo
 			if targetChildID != "" {
 				if err := m.reassignPlayer(player.ID, cellID, targetChildID); err == nil {
 					redistributedPlayers++
+					reassignments = append(reassignments, PlayerReassignment{
+						PlayerID:   player.ID,
+						FromCellID: cellID,
+						ToCellID:   targetChildID,
+						Position:   player.Position,
+						Reason:     "split",
+						Timestamp:  time.Now(),
+					})
 					if m.metrics != nil {
 						m.metrics.RecordSessionReassignment()
 					}
@@ -612,10 +851,37 @@ func (m *DefaultCellManager) splitCellInternal(cellID CellID, splitThreshold flo
 		m.metrics.RecordSessionRedistributionTime(redistributionDuration)
 	}
 
+	// Hand players off while the parent still runs, so their connections can
+	// move to the children before it stops
+	m.publishReassignments(reassignments)
+
+	// Children that keep re-splitting wait longer each time
+	childLevel := m.childBackoffLevel(parentCell, splitStart)
+	for _, childID := range childIDs {
+		m.splitBackoffLevels[childID] = childLevel
+	}
+
+	// Remember the parent so its children can be merged back once load drops
+	m.splitParents[cellID] = &splitParent{
+		spec:         parentSpec,
+		parentID:     parentState.ParentID,
+		generation:   parentState.Generation,
+		siblingIDs:   parentState.SiblingIDs,
+		children:     childIDs,
+		backoffLevel: m.splitBackoffLevels[cellID],
+	}
+	delete(m.splitBackoffLevels, cellID)
+
 	// Mark parent cell as terminated
 	parentCell.Stop()
 	delete(m.cells, cellID)
 
+	// Replace the parent with its children in the cell mesh
+	m.removeFromMesh(cellID)
+	for _, childCell := range childCells {
+		m.addToMesh(childCell)
+	}
+
 	// Record the split time for cooldown tracking
 	splitTime := time.Now()
 	for _, childID := range childIDs {
@@ -623,22 +889,34 @@ func (m *DefaultCellManager) splitCellInternal(cellID CellID, splitThreshold flo
 	}
 	// Also record for the parent cell ID to handle any edge cases
 	m.lastSplitTimes[cellID] = splitTime
+	m.recordLineageChange(cellID, "split", splitTime)
 
 	splitDuration := time.Since(splitStart)
 
+	actualPopulations := make([]int, len(childCells))
+	for i, childCell := range childCells {
+		actualPopulations[i] = childCell.GetState().PlayerCount
+	}
+
 	// Record split event with enhanced metadata
 	eventMetadata := map[string]interface{}{
-		"threshold":             splitThreshold,
-		"parent_player_count":   initialPlayerCount,
-		"redistributed_players": redistributedPlayers,
-		"child_count":           len(childCells),
-		"reason":                reason,
+		"threshold":               splitThreshold,
+		"parent_player_count":     initialPlayerCount,
+		"redistributed_players":   redistributedPlayers,
+		"child_count":             len(childCells),
+		"reason":                  reason,
+		"split_strategy":          m.splitStrategy.Name(),
+		"predicted_child_players": predictedPopulations,
+		"actual_child_players":    actualPopulations,
 	}
 
 	// Add user identity information for manual overrides
 	if userInfo != nil {
 		eventMetadata["user_info"] = userInfo
 	}
+	for key, value := range details {
+		eventMetadata[key] = value
+	}
 
 	event := CellEvent{
 		Type:        CellEventSplit,
@@ -679,51 +957,317 @@ func (m *DefaultCellManager) splitCellInternal(cellID CellID, splitThreshold flo
 	return childCells, nil
 }
 
-// subdivideBoundaries splits a boundary into two child boundaries
-func (m *DefaultCellManager) subdivideBoundaries(parentBounds v1.WorldBounds) []v1.WorldBounds {
-	// Simple bisection along the X-axis for now
-	midX := (parentBounds.XMin + parentBounds.XMax) / 2
+// defaultPredictionHorizon is how far ahead density is forecast when no horizon is set
+const defaultPredictionHorizon = 10 * time.Minute
+
+// SetPredictiveScaling enables pre-splitting cells whose density the predictor
+// forecasts will reach threshold within horizon. Cells are sampled several times
+// per horizon; a nil predictor disables predictive scaling.
+func (m *DefaultCellManager) SetPredictiveScaling(predictor DensityPredictor, threshold float64, horizon time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	child1 := v1.WorldBounds{
-		XMin: parentBounds.XMin,
-		XMax: midX,
-		YMin: parentBounds.YMin,
-		YMax: parentBounds.YMax,
-		ZMin: parentBounds.ZMin,
-		ZMax: parentBounds.ZMax,
+	if horizon <= 0 {
+		horizon = defaultPredictionHorizon
 	}
+	m.predictor = predictor
+	m.predictiveThreshold = threshold
+	m.predictionHorizon = horizon
+	m.predictionEventIndex = len(m.events)
 
-	child2 := v1.WorldBounds{
-		XMin: midX,
-		XMax: parentBounds.XMax,
-		YMin: parentBounds.YMin,
-		YMax: parentBounds.YMax,
-		ZMin: parentBounds.ZMin,
-		ZMax: parentBounds.ZMax,
+	if !m.predictiveLoopStarted && predictor != nil {
+		m.predictiveLoopStarted = true
+		go m.predictiveLoop(predictionSampleInterval(horizon))
 	}
+}
 
-	return []v1.WorldBounds{child1, child2}
+// predictionSampleInterval samples often enough to see a trend well before the horizon
+func predictionSampleInterval(horizon time.Duration) time.Duration {
+	return min(max(horizon/10, 10*time.Millisecond), 15*time.Second)
 }
 
-// findTargetCell finds which child cell a player should be assigned to based on position
-func (m *DefaultCellManager) findTargetCell(pos WorldPosition, childCells []*Cell) CellID {
-	for _, cell := range childCells {
-		bounds := cell.GetState().Boundaries
+// predictiveLoop periodically samples cell density and pre-splits cells forecast to overload
+func (m *DefaultCellManager) predictiveLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.evaluatePredictions(now)
+		}
+	}
+}
 
-		// Check if position is within bounds
-		if pos.X >= bounds.XMin && pos.X <= bounds.XMax {
-			withinY := true
-			if bounds.YMin != nil && pos.Y < *bounds.YMin {
-				withinY = false
+// evaluatePredictions feeds new events and the current per-cell density to the
+// predictor, then pre-splits cells forecast to cross the threshold. Cells
+// already over the threshold are left to the reactive split path.
+func (m *DefaultCellManager) evaluatePredictions(now time.Time) []*Cell {
+	m.mu.Lock()
+	predictor, threshold, horizon := m.predictor, m.predictiveThreshold, m.predictionHorizon
+	events := append([]CellEvent(nil), m.events[m.predictionEventIndex:]...)
+	m.predictionEventIndex = len(m.events)
+	m.mu.Unlock()
+
+	if predictor == nil {
+		return nil
+	}
+
+	// Children carry on their parent's trend so a ramp-up is not lost at a split
+	for _, event := range events {
+		switch event.Type {
+		case CellEventSplit:
+			shares := childDensityShares(event)
+			for i, childID := range event.ChildrenIDs {
+				predictor.Inherit(event.CellID, childID, shares[i])
 			}
-			if bounds.YMax != nil && pos.Y > *bounds.YMax {
-				withinY = false
+		case CellEventTerminated:
+			predictor.Forget(event.CellID)
+		}
+	}
+
+	stats := m.GetPerCellStats()
+	for cellID, cellStats := range stats {
+		predictor.Observe(cellID, now, cellStats["load"])
+	}
+
+	split := make([]*Cell, 0)
+	for cellID, cellStats := range stats {
+		load := cellStats["load"]
+		if load >= threshold {
+			continue
+		}
+		forecast, ok := predictor.Forecast(cellID, horizon)
+		if !ok || forecast < threshold {
+			continue
+		}
+
+		// Don't retry a pre-split that was just attempted, or one still cooling down
+		m.mu.Lock()
+		lastAttempt, attempted := m.lastPredictedSplits[cellID]
+		retryAfter := m.splitCooldownDuration
+		m.mu.Unlock()
+		if attempted && now.Sub(lastAttempt) < retryAfter {
+			continue
+		}
+		if m.splitCooldownRemaining(cellID, now) > 0 {
+			continue
+		}
+
+		m.mu.Lock()
+		m.lastPredictedSplits[cellID] = now
+		m.mu.Unlock()
+
+		if requester := m.splitRequester(); requester != nil {
+			requester(cellID, "PredictedThresholdBreach")
+			continue
+		}
+
+		children, err := m.splitCellInternal(cellID, threshold, "PredictedThresholdBreach", nil, map[string]interface{}{
+			"current_density":    load,
+			"forecast_density":   forecast,
+			"forecast_horizon_s": horizon.Seconds(),
+		})
+		if err != nil {
+			fmt.Printf("Failed to pre-split cell %s: %v\n", cellID, err)
+			continue
+		}
+		split = append(split, children...)
+	}
+
+	return split
+}
+
+// childDensityShares returns the share of the parent's density each child of a
+// split event took over, from the predicted child populations when available
+func childDensityShares(event CellEvent) []float64 {
+	shares := make([]float64, len(event.ChildrenIDs))
+	predicted, _ := event.Metadata["predicted_child_players"].([]int)
+	total := 0
+	for _, count := range predicted {
+		total += count
+	}
+
+	for i := range shares {
+		if len(predicted) == len(shares) && total > 0 {
+			shares[i] = float64(predicted[i]) / float64(total)
+		} else {
+			shares[i] = 1 / float64(len(shares))
+		}
+	}
+	return shares
+}
+
+// SetCellLimits sets the world-level cell budget. Splits that would exceed
+// maxCells and merges that would drop below minCells are refused; zero leaves
+// that side unlimited.
+func (m *DefaultCellManager) SetCellLimits(minCells, maxCells int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.minCells = minCells
+	m.maxCells = maxCells
+}
+
+// checkSplitBudget reports whether adding cells stays within MaxCells. The
+// caller must hold the manager lock.
+func (m *DefaultCellManager) checkSplitBudget(added int) error {
+	if m.maxCells > 0 && len(m.cells)+added > m.maxCells {
+		return fmt.Errorf("%w: %d cells would exceed maxCells %d", ErrCellLimitReached, len(m.cells)+added, m.maxCells)
+	}
+	return nil
+}
+
+// checkMergeBudget reports whether removing cells stays within MinCells. The
+// caller must hold the manager lock.
+func (m *DefaultCellManager) checkMergeBudget(removed int) error {
+	if m.minCells > 0 && len(m.cells)-removed < m.minCells {
+		return fmt.Errorf("%w: %d cells would fall below minCells %d", ErrCellLimitReached, len(m.cells)-removed, m.minCells)
+	}
+	return nil
+}
+
+// recordSplitDenied records a split refused by the cell budget. The caller must
+// hold the manager lock.
+func (m *DefaultCellManager) recordSplitDenied(cellID CellID, reason string, children int) {
+	fmt.Printf("Split denied for cell %s: %d cells already at maxCells %d\n", cellID, len(m.cells), m.maxCells)
+
+	if m.metrics != nil {
+		m.metrics.IncrementSplitBudgetDenials()
+	}
+
+	m.events = append(m.events, CellEvent{
+		Type:      CellEventSplitDenied,
+		CellID:    cellID,
+		Timestamp: time.Now(),
+		Metadata: map[string]interface{}{
+			"reason":             "MaxCellsReached",
+			"split_reason":       reason,
+			"cell_count":         len(m.cells),
+			"max_cells":          m.maxCells,
+			"requested_children": children,
+		},
+	})
+}
+
+// waitForReady gives newly started cells time to become ready to accept players
+func waitForReady(cells []*Cell) {
+	maxWaitTime := time.Millisecond * 200 // Give cells time to start
+	readyCheckInterval := time.Millisecond * 10
+
+	for attempts := 0; attempts < int(maxWaitTime/readyCheckInterval); attempts++ {
+		allReady := true
+		for _, cell := range cells {
+			if !cell.GetState().Ready {
+				allReady = false
+				break
 			}
+		}
+		if allReady {
+			return
+		}
+		time.Sleep(readyCheckInterval)
+	}
+}
+
+// subdivideBoundaries splits a boundary into child boundaries using the configured split strategy.
+// The caller must hold the manager lock.
+func (m *DefaultCellManager) subdivideBoundaries(parentBounds v1.WorldBounds, positions ...WorldPosition) []v1.WorldBounds {
+	return m.splitStrategy.Split(parentBounds, positions)
+}
+
+// SetSplitCooldown sets the base time a cell must wait between splits. Cells
+// that keep re-splitting back off exponentially from it. The merge cooldown is
+// set separately with SetMergeCooldown.
+func (m *DefaultCellManager) SetSplitCooldown(cooldown time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.splitCooldownDuration = cooldown
+}
+
+// SetSplitThreshold sets the density at which cells request a split
+func (m *DefaultCellManager) SetSplitThreshold(threshold float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.defaultSplitThreshold = threshold
+	for _, cell := range m.cells {
+		cell.SetSplitThreshold(threshold)
+	}
+}
+
+// SetTickRate sets the simulation tick interval of cells created afterwards
+func (m *DefaultCellManager) SetTickRate(interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tickRate = interval
+}
 
-			if withinY {
-				return cell.GetState().ID
+// SetSplitTrigger sets how far below the split threshold a cell's density must
+// fall to end a breach, and how long a breach must last before the cell splits
+func (m *DefaultCellManager) SetSplitTrigger(hysteresis float64, sustainedBreach time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.splitHysteresis = hysteresis
+	m.sustainedBreach = sustainedBreach
+	for _, cell := range m.cells {
+		cell.SetSplitTrigger(hysteresis, sustainedBreach)
+	}
+}
+
+// SetSplitStrategy sets how cells are divided when they split
+func (m *DefaultCellManager) SetSplitStrategy(strategy SplitStrategy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.splitStrategy = strategy
+}
+
+// PredictChildPopulations counts how many of the positions each child bounds
+// will receive, assigning them the same way findTargetCell does
+func PredictChildPopulations(childBounds []v1.WorldBounds, positions []WorldPosition) []int {
+	populations := make([]int, len(childBounds))
+	if len(childBounds) == 0 {
+		return populations
+	}
+
+	for _, pos := range positions {
+		target := 0 // Unmatched players default to the first child
+		for i, bounds := range childBounds {
+			if containsPosition(bounds, pos) {
+				target = i
+				break
 			}
 		}
+		populations[target]++
+	}
+
+	return populations
+}
+
+// containsPosition reports whether a position lies within bounds, edges included.
+// Positions carry no Z, so only X and Y are checked.
+func containsPosition(bounds v1.WorldBounds, pos WorldPosition) bool {
+	if pos.X < bounds.XMin || pos.X > bounds.XMax {
+		return false
+	}
+	if bounds.YMin != nil && pos.Y < *bounds.YMin {
+		return false
+	}
+	if bounds.YMax != nil && pos.Y > *bounds.YMax {
+		return false
+	}
+	return true
+}
+
+// findTargetCell finds which child cell a player should be assigned to based on position
+func (m *DefaultCellManager) findTargetCell(pos WorldPosition, childCells []*Cell) CellID {
+	for _, cell := range childCells {
+		state := cell.GetState()
+		if containsPosition(state.Boundaries, pos) {
+			return state.ID
+		}
 	}
 
 	// Default to first child if no exact match
@@ -772,6 +1316,276 @@ func (m *DefaultCellManager) reassignPlayer(playerID PlayerID, fromCellID, toCel
 	return nil
 }
 
+// SetOnPlayersReassigned registers a callback for the players each split or
+// merge moves to another cell, so their connections can follow them. It is
+// called with the manager lock held and must not call back into the manager.
+func (m *DefaultCellManager) SetOnPlayersReassigned(callback func(reassignments []PlayerReassignment)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onPlayersReassigned = callback
+}
+
+// SetOnSplitRequested hands the automatic splits of this manager's cells, both
+// on a threshold breach and on a predicted one, to a callback instead of
+// performing them in this process. It is used where the cells' topology is
+// owned elsewhere, such as a cell pod whose splits are made by the controller.
+// Manual splits are unaffected.
+func (m *DefaultCellManager) SetOnSplitRequested(callback func(cellID CellID, reason string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onSplitRequested = callback
+}
+
+// splitRequester returns the callback automatic splits are handed to, if any
+func (m *DefaultCellManager) splitRequester() func(cellID CellID, reason string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.onSplitRequested
+}
+
+// publishReassignments notifies the reassignment callback of a batch of moved
+// players. The caller must hold the manager lock.
+func (m *DefaultCellManager) publishReassignments(reassignments []PlayerReassignment) {
+	if m.onPlayersReassigned != nil && len(reassignments) > 0 {
+		m.onPlayersReassigned(reassignments)
+	}
+}
+
+// mergeReassignment records a player moved into a merged cell
+func mergeReassignment(player *PlayerState, fromCellID, toCellID CellID) PlayerReassignment {
+	return PlayerReassignment{
+		PlayerID:   player.ID,
+		FromCellID: fromCellID,
+		ToCellID:   toCellID,
+		Position:   player.Position,
+		Reason:     "merge",
+		Timestamp:  time.Now(),
+	}
+}
+
+// configureCell applies the manager's split, ghost and checkpoint settings to a cell before it starts
+func (m *DefaultCellManager) configureCell(cell *Cell) {
+	cell.SetSplitThreshold(m.defaultSplitThreshold)
+	cell.SetSplitTrigger(m.splitHysteresis, m.sustainedBreach)
+	cell.SetOnSplitNeeded(m.handleSplitNeeded)
+	cell.SetOnGhostSync(m.replicateGhosts)
+	cell.SetCheckpointStore(m.checkpointStore)
+	cell.SetCheckpointInterval(m.checkpointInterval)
+	cell.SetTickRate(m.tickRate)
+}
+
+// recoverCell restores a new cell from its newest usable checkpoint, if any.
+// A cell without usable checkpoints starts empty. The caller must hold the manager lock.
+func (m *DefaultCellManager) recoverCell(cell *Cell) *CheckpointInfo {
+	if m.checkpointStore == nil {
+		return nil
+	}
+
+	info, err := RecoverCheckpoint(m.checkpointStore, cell)
+	if err != nil {
+		fmt.Printf("Starting cell %s without recovered state: %v\n", cell.state.ID, err)
+		return nil
+	}
+
+	return info
+}
+
+// trackRestoredPlayers registers sessions for the players of a restored cell and
+// returns how many there are. The caller must hold the manager lock.
+func (m *DefaultCellManager) trackRestoredPlayers(cell *Cell) int {
+	state := cell.GetState()
+	for playerID, player := range state.Players {
+		m.sessions[playerID] = &PlayerSessionInfo{
+			PlayerID: playerID,
+			CellID:   state.ID,
+			Position: player.Position,
+		}
+	}
+	return len(state.Players)
+}
+
+// trackRestoredSplitParent remembers the parent of a restored split child, so
+// its sibling group can merge back once all of them are live again. The caller
+// must hold the manager lock.
+func (m *DefaultCellManager) trackRestoredSplitParent(cell *Cell) {
+	state := cell.GetState()
+	if state.SplitParent == nil || state.ParentID == nil || *state.ParentID != state.SplitParent.Spec.ID {
+		return
+	}
+	if _, exists := m.splitParents[*state.ParentID]; exists {
+		return
+	}
+
+	children := append([]CellID{state.ID}, state.SiblingIDs...)
+	sort.Slice(children, func(i, j int) bool {
+		return children[i] < children[j]
+	})
+	m.splitParents[*state.ParentID] = &splitParent{
+		spec:       state.SplitParent.Spec,
+		parentID:   state.SplitParent.ParentID,
+		generation: state.Generation - 1,
+		siblingIDs: state.SplitParent.SiblingIDs,
+		children:   children,
+	}
+}
+
+// RecoverCells recreates every cell that has checkpoints in the configured store,
+// restoring each from its newest usable checkpoint. Cells that already exist are
+// skipped, as are cells a later split or merge replaced.
+func (m *DefaultCellManager) RecoverCells() ([]CellID, error) {
+	store := m.GetCheckpointStore()
+	if store == nil {
+		return nil, nil
+	}
+
+	cellIDs, err := store.Cells()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checkpointed cells: %w", err)
+	}
+
+	checkpointed := make(map[CellID]*checkpointedCell, len(cellIDs))
+	for _, cellID := range cellIDs {
+		if _, err := m.GetCell(cellID); err == nil {
+			continue
+		}
+
+		found, err := loadCheckpointedCell(store, cellID)
+		if err != nil {
+			fmt.Printf("Skipping recovery of cell %s: %v\n", cellID, err)
+			continue
+		}
+		checkpointed[cellID] = found
+	}
+
+	// A split parent and its children both leave checkpoints behind. Whichever
+	// side saved last is what the world looked like, the other is stale.
+	superseded := make(map[CellID]bool)
+	for cellID, found := range checkpointed {
+		if found.parentID == nil {
+			continue
+		}
+		if parent, exists := checkpointed[*found.parentID]; exists {
+			if parent.savedAt.After(found.savedAt) {
+				superseded[cellID] = true
+			} else {
+				superseded[*found.parentID] = true
+			}
+		}
+	}
+
+	recovered := make([]CellID, 0, len(checkpointed))
+	for _, cellID := range cellIDs {
+		found, exists := checkpointed[cellID]
+		if !exists {
+			continue
+		}
+		if superseded[cellID] {
+			fmt.Printf("Skipping recovery of cell %s: superseded by a later split or merge\n", cellID)
+			continue
+		}
+
+		if _, err := m.CreateCell(found.spec); err != nil {
+			return recovered, fmt.Errorf("failed to recover cell %s: %w", cellID, err)
+		}
+		recovered = append(recovered, cellID)
+	}
+
+	return recovered, nil
+}
+
+// checkpointedCell is what recovery needs to know about a checkpointed cell
+type checkpointedCell struct {
+	spec     CellSpec
+	parentID *CellID
+	savedAt  time.Time
+}
+
+// loadCheckpointedCell rebuilds a cell spec from the newest full snapshot that
+// loads cleanly, along with the cell's parent and when it last checkpointed
+func loadCheckpointedCell(store CheckpointStore, cellID CellID) (*checkpointedCell, error) {
+	infos, err := store.List(cellID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range infos {
+		if info.Kind != CheckpointFull {
+			continue
+		}
+		data, _, err := store.Load(cellID, info.Version)
+		if err != nil {
+			continue
+		}
+		var state CellState
+		if err := decodeState(data, &state); err != nil {
+			continue
+		}
+		return &checkpointedCell{
+			spec: CellSpec{
+				ID:         cellID,
+				Boundaries: state.Boundaries,
+				Capacity:   state.Capacity,
+				GameConfig: state.GameState,
+			},
+			parentID: state.ParentID,
+			savedAt:  infos[0].CreatedAt,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cell %s: %w", cellID, ErrCheckpointNotFound)
+}
+
+// SetCheckpointStore sets the store and interval used to persist cell checkpoints.
+// The interval applies to cells created afterwards; the store applies to all cells.
+func (m *DefaultCellManager) SetCheckpointStore(store CheckpointStore, interval time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.checkpointStore = store
+	m.checkpointInterval = interval
+
+	for _, cell := range m.cells {
+		cell.SetCheckpointStore(store)
+	}
+}
+
+// GetCheckpointStore returns the configured checkpoint store, or nil if persistence is disabled
+func (m *DefaultCellManager) GetCheckpointStore() CheckpointStore {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.checkpointStore
+}
+
+// addToMesh registers a cell in the cell mesh and refreshes the neighbor lists it affects.
+// The caller must hold the manager lock.
+func (m *DefaultCellManager) addToMesh(cell *Cell) {
+	id := cell.state.ID
+	cell.GetAOIFilter().SetCellMesh(id, m.mesh)
+	m.refreshNeighbors(m.mesh.AddCell(id, cell.state.Boundaries))
+}
+
+// removeFromMesh removes a cell from the cell mesh and refreshes its former neighbors.
+// The caller must hold the manager lock.
+func (m *DefaultCellManager) removeFromMesh(id CellID) {
+	m.refreshNeighbors(m.mesh.RemoveCell(id))
+}
+
+// refreshNeighbors copies the current mesh adjacency into each cell's state
+func (m *DefaultCellManager) refreshNeighbors(ids []CellID) {
+	for _, id := range ids {
+		if cell, exists := m.cells[id]; exists {
+			cell.setNeighbors(m.mesh.Neighbors(id))
+		}
+	}
+}
+
+// GetCellMesh returns the adjacency graph of live cells
+func (m *DefaultCellManager) GetCellMesh() *CellMesh {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.mesh
+}
+
 // GetEvents returns all recorded events
 func (m *DefaultCellManager) GetEvents() []CellEvent {
 	m.mu.RLock()
@@ -806,6 +1620,9 @@ func (m *DefaultCellManager) MergeCells(cellID1, cellID2 CellID) (*Cell, error)
 	if err := m.validateMergePair(state1, state2); err != nil {
 		return nil, fmt.Errorf("merge validation failed: %w", err)
 	}
+	if err := m.checkMergeBudget(1); err != nil {
+		return nil, fmt.Errorf("merge validation failed: %w", err)
+	}
 
 	mergeStart := time.Now()
 
@@ -823,6 +1640,7 @@ func (m *DefaultCellManager) MergeCells(cellID1, cellID2 CellID) (*Cell, error)
 			CPULimit:    state1.Capacity.CPULimit, // Use first cell's limits
 			MemoryLimit: state1.Capacity.MemoryLimit,
 		},
+		GameConfig: cell1.inheritedGameConfig(),
 	}
 
 	mergedCell, err := NewCell(mergedSpec)
@@ -836,8 +1654,7 @@ func (m *DefaultCellManager) MergeCells(cellID1, cellID2 CellID) (*Cell, error)
 	mergedCell.state.SiblingIDs = []CellID{} // Merged cell has no siblings initially
 
 	// Configure merged cell
-	mergedCell.SetSplitThreshold(m.defaultSplitThreshold)
-	mergedCell.SetOnSplitNeeded(m.handleSplitNeeded)
+	m.configureCell(mergedCell)
 
 	if err := mergedCell.Start(m.ctx); err != nil {
 		return nil, fmt.Errorf("failed to start merged cell: %w", err)
@@ -856,6 +1673,7 @@ func (m *DefaultCellManager) MergeCells(cellID1, cellID2 CellID) (*Cell, error)
 	}
 
 	// Add all players to merged cell
+	reassignments := make([]PlayerReassignment, 0, len(allPlayers))
 	for _, player := range allPlayers {
 		if err := mergedCell.AddPlayer(player); err == nil {
 			mergedPlayers++
@@ -863,8 +1681,14 @@ func (m *DefaultCellManager) MergeCells(cellID1, cellID2 CellID) (*Cell, error)
 			if session, exists := m.sessions[player.ID]; exists {
 				session.CellID = mergedID
 			}
+			fromCellID := cellID1
+			if _, inSecond := state2.Players[player.ID]; inSecond {
+				fromCellID = cellID2
+			}
+			reassignments = append(reassignments, mergeReassignment(player, fromCellID, mergedID))
 		}
 	}
+	m.publishReassignments(reassignments)
 
 	// Stop and remove the original cells
 	cell1.Stop()
@@ -875,6 +1699,11 @@ func (m *DefaultCellManager) MergeCells(cellID1, cellID2 CellID) (*Cell, error)
 	// Add merged cell to manager
 	m.cells[mergedID] = mergedCell
 
+	// Replace the source cells with the merged cell in the cell mesh
+	m.removeFromMesh(cellID1)
+	m.removeFromMesh(cellID2)
+	m.addToMesh(mergedCell)
+
 	mergeDuration := time.Since(mergeStart)
 
 	// Record merge event with ManualOverride reason
@@ -954,6 +1783,275 @@ func (m *DefaultCellManager) validateMergePair(state1, state2 CellState) error {
 	return nil
 }
 
+// defaultMergeHysteresis is how long siblings must stay underloaded before they are merged
+const defaultMergeHysteresis = 5 * time.Minute
+
+// SetAutoMerge enables merging underloaded siblings back into their parent. A
+// sibling group is merged once its combined player count has stayed below
+// threshold of the parent's capacity for the hysteresis window; a threshold of
+// zero or less disables merging.
+func (m *DefaultCellManager) SetAutoMerge(threshold float64, hysteresis time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.scaleDownThreshold = threshold
+	if hysteresis > 0 {
+		m.mergeHysteresis = hysteresis
+	}
+	m.underloadedSince = make(map[CellID]time.Time)
+
+	if !m.mergeLoopStarted && threshold > 0 {
+		m.mergeLoopStarted = true
+		go m.mergeLoop(mergeCheckInterval(m.mergeHysteresis))
+	}
+}
+
+// SetMergeCooldown sets how long cells created by a split or merge wait before
+// they can be merged. It defaults to the split cooldown.
+func (m *DefaultCellManager) SetMergeCooldown(cooldown time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.mergeCooldownDuration = cooldown
+}
+
+// mergeCheckInterval checks often enough to merge close to the end of the
+// hysteresis window without polling idle managers constantly
+func mergeCheckInterval(hysteresis time.Duration) time.Duration {
+	return min(max(hysteresis/4, 10*time.Millisecond), 30*time.Second)
+}
+
+// mergeLoop periodically merges sibling groups that have stayed underloaded
+func (m *DefaultCellManager) mergeLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.evaluateMerges(now)
+		}
+	}
+}
+
+// evaluateMerges tracks how long each sibling group has been underloaded and
+// merges the groups whose hysteresis window has elapsed
+func (m *DefaultCellManager) evaluateMerges(now time.Time) []*Cell {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.scaleDownThreshold <= 0 {
+		return nil
+	}
+
+	merged := make([]*Cell, 0)
+	for parentID, parent := range m.splitParents {
+		children, ok := m.mergeableChildren(parentID, parent)
+		if !ok {
+			delete(m.underloadedSince, parentID)
+			if _, tracked := m.splitParents[parentID]; !tracked {
+				delete(m.mergeBlockedUntil, parentID)
+			}
+			continue
+		}
+
+		if parent.spec.Capacity.MaxPlayers <= 0 {
+			continue
+		}
+
+		// The merged cell has the parent's capacity, so measure the group against it
+		players := 0
+		for _, child := range children {
+			players += child.GetState().PlayerCount
+		}
+		density := float64(players) / float64(parent.spec.Capacity.MaxPlayers)
+		if density >= m.scaleDownThreshold {
+			delete(m.underloadedSince, parentID)
+			continue
+		}
+
+		since, tracked := m.underloadedSince[parentID]
+		if !tracked {
+			m.underloadedSince[parentID] = now
+			continue
+		}
+		if now.Sub(since) < m.mergeHysteresis {
+			continue
+		}
+
+		// Stay tracked so the group merges as soon as the budget allows
+		if err := m.checkMergeBudget(len(children) - 1); err != nil {
+			continue
+		}
+
+		if remaining := m.mergeCooldownRemaining(parent.children, now); remaining > 0 {
+			// Report each cooldown window once rather than on every check
+			if now.After(m.mergeBlockedUntil[parentID]) {
+				m.mergeBlockedUntil[parentID] = now.Add(remaining)
+				fmt.Printf("Merge attempt blocked for cell %s: still in cooldown period (%.1f seconds remaining, density ratio: %.2f)\n",
+					parentID, remaining.Seconds(), density)
+				if m.metrics != nil {
+					m.metrics.IncrementMergeCooldownBlocks()
+				}
+			}
+			continue
+		}
+
+		mergedCell, err := m.mergeIntoParent(parent, children, density, now.Sub(since))
+		if err != nil {
+			fmt.Printf("Failed to merge children of cell %s: %v\n", parentID, err)
+			continue
+		}
+		merged = append(merged, mergedCell)
+	}
+
+	return merged
+}
+
+// mergeableChildren returns a split parent's children when all of them are live
+// and running. A group whose child was deleted or merged elsewhere can never be
+// merged back, so it is forgotten; one whose child split again is kept until
+// that child's own children have merged. The caller must hold the manager lock.
+func (m *DefaultCellManager) mergeableChildren(parentID CellID, parent *splitParent) ([]*Cell, bool) {
+	children := make([]*Cell, 0, len(parent.children))
+	for _, childID := range parent.children {
+		child, exists := m.cells[childID]
+		if !exists {
+			if _, split := m.splitParents[childID]; !split {
+				delete(m.splitParents, parentID)
+			}
+			return nil, false
+		}
+		if child.GetState().Phase != "Running" {
+			return nil, false
+		}
+		children = append(children, child)
+	}
+	return children, true
+}
+
+// mergeCooldownRemaining returns how long until none of the cells was created by
+// a recent split or merge. The caller must hold the manager lock.
+func (m *DefaultCellManager) mergeCooldownRemaining(cellIDs []CellID, now time.Time) time.Duration {
+	var remaining time.Duration
+	for _, id := range cellIDs {
+		for _, created := range []time.Time{m.lastSplitTimes[id], m.lastMergeTimes[id]} {
+			if created.IsZero() {
+				continue
+			}
+			remaining = max(remaining, m.mergeCooldownDuration-now.Sub(created))
+		}
+	}
+	return remaining
+}
+
+// mergeIntoParent replaces a sibling group with a cell covering their parent's
+// bounds. The caller must hold the manager lock.
+func (m *DefaultCellManager) mergeIntoParent(parent *splitParent, children []*Cell, density float64, underloadedFor time.Duration) (*Cell, error) {
+	mergeStart := time.Now()
+	parentID := parent.spec.ID
+
+	mergedCell, err := NewCell(parent.spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create merged cell: %w", err)
+	}
+
+	// Restore the parent's lineage
+	mergedCell.state.ParentID = parent.parentID
+	mergedCell.state.Generation = parent.generation
+	mergedCell.state.SiblingIDs = parent.siblingIDs
+	if parent.parentID != nil {
+		if grandparent, exists := m.splitParents[*parent.parentID]; exists {
+			mergedCell.state.SplitParent = grandparent.state()
+		}
+	}
+
+	m.configureCell(mergedCell)
+
+	if err := mergedCell.Start(m.ctx); err != nil {
+		return nil, fmt.Errorf("failed to start merged cell: %w", err)
+	}
+	waitForReady([]*Cell{mergedCell})
+
+	// Move every player from the children into the merged cell
+	mergedPlayers := 0
+	var reassignments []PlayerReassignment
+	for _, child := range children {
+		for _, player := range child.GetState().Players {
+			if err := mergedCell.AddPlayer(player); err != nil {
+				if m.metrics != nil {
+					m.metrics.RecordSessionLoss()
+				}
+				continue
+			}
+			mergedPlayers++
+			if session, exists := m.sessions[player.ID]; exists {
+				session.CellID = parentID
+			}
+			if m.metrics != nil {
+				m.metrics.RecordSessionReassignment()
+			}
+			reassignments = append(reassignments, mergeReassignment(player, child.state.ID, parentID))
+		}
+	}
+	m.publishReassignments(reassignments)
+
+	// Stop the children and replace them with the merged cell
+	for _, child := range children {
+		child.Stop()
+		delete(m.cells, child.state.ID)
+		m.removeFromMesh(child.state.ID)
+	}
+	m.cells[parentID] = mergedCell
+	m.addToMesh(mergedCell)
+
+	delete(m.splitParents, parentID)
+	delete(m.underloadedSince, parentID)
+	delete(m.mergeBlockedUntil, parentID)
+	m.lastMergeTimes[parentID] = time.Now()
+
+	// The parent resumes its backoff, so splitting it again soon backs off further
+	for _, childID := range parent.children {
+		delete(m.splitBackoffLevels, childID)
+	}
+	m.splitBackoffLevels[parentID] = parent.backoffLevel
+	m.recordLineageChange(parentID, "merge", time.Now())
+
+	mergeDuration := time.Since(mergeStart)
+
+	m.events = append(m.events, CellEvent{
+		Type:      CellEventMerged,
+		CellID:    parentID,
+		ParentID:  parent.parentID,
+		Timestamp: time.Now(),
+		Duration:  &mergeDuration,
+		Metadata: map[string]interface{}{
+			"reason":            "ScaleDown",
+			"source_cells":      parent.children,
+			"merged_players":    mergedPlayers,
+			"density_ratio":     density,
+			"threshold":         m.scaleDownThreshold,
+			"underloaded_for_s": underloadedFor.Seconds(),
+			"generation":        parent.generation,
+		},
+	})
+
+	for _, childID := range parent.children {
+		m.events = append(m.events, CellEvent{
+			Type:      CellEventTerminated,
+			CellID:    childID,
+			Timestamp: time.Now(),
+			Metadata: map[string]interface{}{
+				"reason":    "merged",
+				"merged_to": parentID,
+			},
+		})
+	}
+
+	return mergedCell, nil
+}
+
 // areCellsAdjacent checks if two cell boundaries are spatially adjacent
 func (m *DefaultCellManager) areCellsAdjacent(bounds1, bounds2 v1.WorldBounds) bool {
 	// Check if cells share a boundary edge
@@ -1096,6 +2194,11 @@ func (m *DefaultCellManager) ProcessMergeAnnotation(annotation MergeAnnotation)
 		}
 	}
 
+	// The cell budget applies even to forced merges
+	if err := m.checkMergeBudget(1); err != nil {
+		return nil, fmt.Errorf("annotation merge validation failed: %w", err)
+	}
+
 	mergeStart := time.Now()
 
 	// Create merged cell boundaries
@@ -1111,6 +2214,7 @@ func (m *DefaultCellManager) ProcessMergeAnnotation(annotation MergeAnnotation)
 			CPULimit:    sourceState.Capacity.CPULimit,
 			MemoryLimit: sourceState.Capacity.MemoryLimit,
 		},
+		GameConfig: sourceCell.inheritedGameConfig(),
 	}
 
 	mergedCell, err := NewCell(mergedSpec)
@@ -1128,8 +2232,7 @@ func (m *DefaultCellManager) ProcessMergeAnnotation(annotation MergeAnnotation)
 	}
 
 	// Configure merged cell
-	mergedCell.SetSplitThreshold(m.defaultSplitThreshold)
-	mergedCell.SetOnSplitNeeded(m.handleSplitNeeded)
+	m.configureCell(mergedCell)
 
 	if err := mergedCell.Start(m.ctx); err != nil {
 		return nil, fmt.Errorf("failed to start annotated merged cell: %w", err)
@@ -1147,6 +2250,7 @@ func (m *DefaultCellManager) ProcessMergeAnnotation(annotation MergeAnnotation)
 	}
 
 	// Add all players to merged cell
+	reassignments := make([]PlayerReassignment, 0, len(allPlayers))
 	for _, player := range allPlayers {
 		if err := mergedCell.AddPlayer(player); err == nil {
 			mergedPlayers++
@@ -1154,8 +2258,14 @@ func (m *DefaultCellManager) ProcessMergeAnnotation(annotation MergeAnnotation)
 			if session, exists := m.sessions[player.ID]; exists {
 				session.CellID = mergedID
 			}
+			fromCellID := annotation.SourceCellID
+			if _, inTarget := targetState.Players[player.ID]; inTarget {
+				fromCellID = annotation.TargetCellID
+			}
+			reassignments = append(reassignments, mergeReassignment(player, fromCellID, mergedID))
 		}
 	}
+	m.publishReassignments(reassignments)
 
 	// Stop and remove the original cells
 	sourceCell.Stop()
@@ -1166,6 +2276,11 @@ func (m *DefaultCellManager) ProcessMergeAnnotation(annotation MergeAnnotation)
 	// Add merged cell to manager
 	m.cells[mergedID] = mergedCell
 
+	// Replace the source cells with the merged cell in the cell mesh
+	m.removeFromMesh(annotation.SourceCellID)
+	m.removeFromMesh(annotation.TargetCellID)
+	m.addToMesh(mergedCell)
+
 	mergeDuration := time.Since(mergeStart)
 
 	// Record annotation-based merge event
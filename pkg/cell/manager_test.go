@@ -2,10 +2,15 @@ package cell
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/util/validation"
+
 	v1 "github.com/astrosteveo/fleetforge/api/v1"
 )
 
@@ -35,6 +40,42 @@ func TestCellManager_CreateCell(t *testing.T) {
 	}
 }
 
+func TestCellManager_SimulationSettings(t *testing.T) {
+	manager := NewCellManager().(*DefaultCellManager)
+	defer manager.Shutdown()
+
+	manager.SetSplitThreshold(0.6)
+	manager.SetTickRate(100 * time.Millisecond)
+
+	cell, err := manager.CreateCell(CellSpec{
+		ID:         "configured-cell",
+		Boundaries: createTestBounds(),
+		Capacity:   CellCapacity{MaxPlayers: 50},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+
+	cell.mu.RLock()
+	splitThreshold, tickRate := cell.splitThreshold, cell.tickRate
+	cell.mu.RUnlock()
+	if splitThreshold != 0.6 {
+		t.Errorf("Expected split threshold 0.6, got %f", splitThreshold)
+	}
+	if tickRate != 100*time.Millisecond {
+		t.Errorf("Expected tick interval 100ms, got %v", tickRate)
+	}
+
+	// The split threshold also applies to cells that already exist
+	manager.SetSplitThreshold(0.9)
+	cell.mu.RLock()
+	splitThreshold = cell.splitThreshold
+	cell.mu.RUnlock()
+	if splitThreshold != 0.9 {
+		t.Errorf("Expected split threshold 0.9 after update, got %f", splitThreshold)
+	}
+}
+
 func TestCellManager_CreateCell_Duplicate(t *testing.T) {
 	manager := NewCellManager()
 	defer manager.(*DefaultCellManager).Shutdown()
@@ -1079,7 +1120,8 @@ func TestCellSplitCooldown(t *testing.T) {
 
 // TestCellManager_ManualSplitCell tests the manual split override functionality
 func TestCellManager_ManualSplitCell(t *testing.T) {
-	manager := NewCellManager()
+	cooldownDuration := 500 * time.Millisecond
+	manager := NewCellManagerWithCooldown(cooldownDuration)
 
 	// Create a test cell
 	spec := CellSpec{
@@ -1185,80 +1227,473 @@ func TestCellManager_ManualSplitCell(t *testing.T) {
 		t.Fatal("Split event not found")
 	}
 
-	// Get the events again to find the split
-	events = manager.GetEvents()
-	splitEvent = nil
-	for i := range events {
-		if events[i].Type == CellEventSplit {
-			splitEvent = &events[i]
-			break
+	if splitEvent.Metadata["reason"] != "ManualOverride" {
+		t.Errorf("Expected split reason ManualOverride, got %v", splitEvent.Metadata["reason"])
+	}
+
+	if _, ok := splitEvent.Metadata["user_info"]; !ok {
+		t.Error("Expected user_info in split event metadata")
+	}
+
+	// A manual split starts the children's cooldown like any other split
+	childID := childCells[0].GetState().ID
+	for i := childCells[0].GetState().PlayerCount; i < 9; i++ {
+		player := &PlayerState{
+			ID:       PlayerID(fmt.Sprintf("child-player-%d", i+1)),
+			Position: WorldPosition{X: float64(i), Y: 0},
+		}
+		if err := manager.AddPlayer(childID, player); err != nil {
+			t.Fatalf("Failed to add player %d to child: %v", i+1, err)
 		}
 	}
 
-	if splitEvent == nil || len(splitEvent.ChildrenIDs) == 0 {
-		t.Fatal("Expected split event with children")
+	dmgr := manager.(*DefaultCellManager)
+	dmgr.handleSplitNeeded(childID, 0.9)
+	if _, err := manager.GetCell(childID); err != nil {
+		t.Fatalf("Child cell should not split again during its cooldown: %v", err)
+	}
+	for _, event := range manager.GetEvents() {
+		if event.Type == CellEventSplit && event.CellID == childID {
+			t.Fatal("Unexpected split event for the child during its cooldown")
+		}
 	}
 
-	childID := splitEvent.ChildrenIDs[0]
+	// Once the cooldown expires the still-overloaded child splits
+	time.Sleep(cooldownDuration + 100*time.Millisecond)
+	dmgr.handleSplitNeeded(childID, 0.9)
 
-	// Now try to split the child cell immediately - should be blocked by cooldown
-	t.Log("Attempting immediate re-split during cooldown period...")
+	if _, err := manager.GetCell(childID); err == nil {
+		t.Error("Child cell should split once its cooldown has expired")
+	}
+	resplit := false
+	for _, event := range manager.GetEvents() {
+		if event.Type == CellEventSplit && event.CellID == childID {
+			resplit = true
+		}
+	}
+	if !resplit {
+		t.Error("Expected a split event for the child after its cooldown")
+	}
+}
 
-	// Get the child cell and manually trigger split threshold
-	childCell, err := manager.GetCell(childID)
+// splitForMerge creates a cell holding the given number of players and splits it in two
+func splitForMerge(t *testing.T, manager *DefaultCellManager, players int) []*Cell {
+	t.Helper()
+
+	spec := CellSpec{
+		ID:         "parent",
+		Boundaries: createTestBounds(),
+		Capacity:   CellCapacity{MaxPlayers: 10},
+	}
+	if _, err := manager.CreateCell(spec); err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+
+	// Wait for cell to be ready
+	time.Sleep(time.Millisecond * 150)
+
+	for i := 0; i < players; i++ {
+		player := &PlayerState{ID: PlayerID(fmt.Sprintf("player-%d", i)), Position: WorldPosition{X: float64(100 + i*200), Y: 500}}
+		if err := manager.AddPlayer(spec.ID, player); err != nil {
+			t.Fatalf("Failed to add player: %v", err)
+		}
+	}
+
+	children, err := manager.ManualSplitCell(spec.ID, nil)
 	if err != nil {
-		t.Fatalf("Failed to get child cell: %v", err)
+		t.Fatalf("Failed to split cell: %v", err)
 	}
+	return children
+}
+
+func TestCellManager_AutoMergeUnderloadedSiblings(t *testing.T) {
+	manager := NewCellManagerWithCooldown(time.Millisecond).(*DefaultCellManager)
+	defer manager.Shutdown()
+	manager.SetAutoMerge(0.3, time.Hour)
 
-	// Assert that the childCell is not nil
-	if childCell == nil {
-		t.Fatal("Child cell is nil")
+	splitForMerge(t, manager, 2)
+
+	// Siblings must stay underloaded for the whole hysteresis window
+	now := time.Now()
+	if merged := manager.evaluateMerges(now); len(merged) != 0 {
+		t.Fatalf("Expected no merge when underload is first seen, got %d", len(merged))
+	}
+	if merged := manager.evaluateMerges(now.Add(30 * time.Minute)); len(merged) != 0 {
+		t.Fatalf("Expected no merge inside the hysteresis window, got %d", len(merged))
 	}
 
-	// Add more players to child to trigger threshold
-	for i := 0; i < 9; i++ {
-		player := &PlayerState{
-			ID:       PlayerID(fmt.Sprintf("child-player-%d", i+1)),
-			Position: WorldPosition{X: float64(i), Y: 0},
+	merged := manager.evaluateMerges(now.Add(time.Hour))
+	if len(merged) != 1 {
+		t.Fatalf("Expected siblings to merge after the hysteresis window, got %d", len(merged))
+	}
+
+	state := merged[0].GetState()
+	if state.ID != "parent" || state.PlayerCount != 2 {
+		t.Errorf("Expected parent to be restored with 2 players, got %s with %d", state.ID, state.PlayerCount)
+	}
+	if state.Boundaries.XMin != 0 || state.Boundaries.XMax != 1000 || state.Capacity.MaxPlayers != 10 {
+		t.Errorf("Expected the parent's bounds and capacity, got %+v %+v", state.Boundaries, state.Capacity)
+	}
+	if manager.GetCellCount() != 1 {
+		t.Errorf("Expected only the merged cell to remain, got %v", manager.ListCells())
+	}
+	if session, err := manager.GetPlayerSession("player-0"); err != nil || session.CellID != "parent" {
+		t.Errorf("Expected player session to move to the merged cell, got %+v (err %v)", session, err)
+	}
+
+	var mergeEvent *CellEvent
+	for _, event := range manager.GetEvents() {
+		if event.Type == CellEventMerged && event.CellID == "parent" {
+			mergeEvent = &event
 		}
-		err := manager.AddPlayer(childID, player)
+	}
+	if mergeEvent == nil || mergeEvent.Metadata["reason"] != "ScaleDown" {
+		t.Errorf("Expected a ScaleDown merge event, got %+v", mergeEvent)
+	}
+}
+
+func TestCellManager_AutoMergeHysteresisAndCooldown(t *testing.T) {
+	manager := NewCellManagerWithCooldown(time.Millisecond).(*DefaultCellManager)
+	defer manager.Shutdown()
+	manager.SetAutoMerge(0.3, time.Hour)
+
+	children := splitForMerge(t, manager, 2)
+
+	now := time.Now()
+	manager.evaluateMerges(now)
+
+	// A load spike above the threshold restarts the hysteresis window
+	for i := 0; i < 3; i++ {
+		player := &PlayerState{ID: PlayerID(fmt.Sprintf("spike-%d", i)), Position: WorldPosition{X: 100, Y: 100}}
+		if err := manager.AddPlayer(children[0].GetState().ID, player); err != nil {
+			t.Fatalf("Failed to add player: %v", err)
+		}
+	}
+	manager.evaluateMerges(now.Add(30 * time.Minute))
+	for i := 0; i < 3; i++ {
+		if err := manager.RemovePlayer(children[0].GetState().ID, PlayerID(fmt.Sprintf("spike-%d", i))); err != nil {
+			t.Fatalf("Failed to remove player: %v", err)
+		}
+	}
+
+	manager.evaluateMerges(now.Add(45 * time.Minute))
+	if merged := manager.evaluateMerges(now.Add(time.Hour)); len(merged) != 0 {
+		t.Fatalf("Expected the spike to restart the hysteresis window, got %d merges", len(merged))
+	}
+
+	// Recently split cells are held back by the merge cooldown
+	manager.SetMergeCooldown(24 * time.Hour)
+	if merged := manager.evaluateMerges(now.Add(2 * time.Hour)); len(merged) != 0 {
+		t.Fatalf("Expected the merge cooldown to block the merge, got %d merges", len(merged))
+	}
+
+	manager.SetMergeCooldown(time.Millisecond)
+	if merged := manager.evaluateMerges(now.Add(2 * time.Hour)); len(merged) != 1 {
+		t.Fatalf("Expected siblings to merge once the cooldown expired, got %d", len(merged))
+	}
+}
+
+func TestCellManager_SplitDeniedAtMaxCells(t *testing.T) {
+	manager := NewCellManagerWithCooldown(time.Millisecond).(*DefaultCellManager)
+	defer manager.Shutdown()
+	manager.SetCellLimits(0, 2)
+
+	children := splitForMerge(t, manager, 2)
+	if len(children) != 2 {
+		t.Fatalf("Expected a split up to maxCells to succeed, got %d children", len(children))
+	}
+
+	_, err := manager.ManualSplitCell(children[0].GetState().ID, nil)
+	if !errors.Is(err, ErrCellLimitReached) {
+		t.Fatalf("Expected split past maxCells to fail with ErrCellLimitReached, got %v", err)
+	}
+	if manager.GetCellCount() != 2 {
+		t.Errorf("Expected the denied split to leave 2 cells, got %d", manager.GetCellCount())
+	}
+
+	var denied *CellEvent
+	for _, event := range manager.GetEvents() {
+		if event.Type == CellEventSplitDenied {
+			denied = &event
+		}
+	}
+	if denied == nil {
+		t.Fatalf("Expected a split denied event")
+	}
+	if denied.CellID != children[0].GetState().ID || denied.Metadata["max_cells"] != 2 {
+		t.Errorf("Unexpected split denied event: %+v", denied)
+	}
+}
+
+func TestCellManager_MergeRespectsMinCells(t *testing.T) {
+	manager := NewCellManagerWithCooldown(time.Millisecond).(*DefaultCellManager)
+	defer manager.Shutdown()
+	manager.SetAutoMerge(0.3, time.Hour)
+	manager.SetCellLimits(2, 0)
+
+	children := splitForMerge(t, manager, 2)
+
+	now := time.Now()
+	manager.evaluateMerges(now)
+	if merged := manager.evaluateMerges(now.Add(2 * time.Hour)); len(merged) != 0 {
+		t.Fatalf("Expected no merge below minCells, got %d", len(merged))
+	}
+
+	if _, err := manager.MergeCells(children[0].GetState().ID, children[1].GetState().ID); !errors.Is(err, ErrCellLimitReached) {
+		t.Errorf("Expected manual merge below minCells to fail with ErrCellLimitReached, got %v", err)
+	}
+
+	// Once the budget allows it, the group that stayed underloaded merges
+	manager.SetCellLimits(1, 0)
+	if merged := manager.evaluateMerges(now.Add(2 * time.Hour)); len(merged) != 1 {
+		t.Fatalf("Expected siblings to merge once minCells allows it, got %d", len(merged))
+	}
+}
+
+func TestCellManager_SplitCooldownBacksOffForRepeatedSplits(t *testing.T) {
+	manager := NewCellManagerWithCooldown(time.Minute).(*DefaultCellManager)
+	defer manager.Shutdown()
+
+	children := splitForMerge(t, manager, 0)
+
+	// The first split of a root cell starts at the base cooldown
+	now := time.Now()
+	if remaining := manager.splitCooldownRemaining(children[0].GetState().ID, now); remaining <= 0 || remaining > time.Minute {
+		t.Errorf("Expected about one minute of cooldown, got %s", remaining)
+	}
+
+	// Splitting the same lineage again right away doubles the cooldown each time
+	for expected := 2 * time.Minute; expected <= 4*time.Minute; expected *= 2 {
+		var err error
+		children, err = manager.ManualSplitCell(children[0].GetState().ID, nil)
 		if err != nil {
-			t.Logf("Failed to add player %d to child (expected): %v", i+1, err)
+			t.Fatalf("Failed to split cell: %v", err)
+		}
+		remaining := manager.splitCooldownRemaining(children[0].GetState().ID, time.Now())
+		if remaining <= expected/2 || remaining > expected {
+			t.Errorf("Expected cooldown to back off to %s, got %s", expected, remaining)
 		}
 	}
+}
 
-	// Manually call handleSplitNeeded to simulate threshold breach
-	// We'll capture console output to verify cooldown message
-	t.Log("Triggering handleSplitNeeded to test cooldown...")
-	dmgr.handleSplitNeeded(childID, 0.95)
-	cooldownBlocksIncremented = true // We know it was called during cooldown
+func TestCellManager_FlagsOscillatingCells(t *testing.T) {
+	manager := NewCellManagerWithCooldown(time.Minute).(*DefaultCellManager)
+	defer manager.Shutdown()
+	manager.SetAutoMerge(0.3, time.Second)
 
-	// Check that cooldown blocks were incremented (simulated)
-	if !cooldownBlocksIncremented {
-		t.Error("Expected cooldown block to be triggered")
-	} else {
-		t.Logf("Cooldown block was triggered as expected")
+	// Split, merge back and split again within the oscillation window
+	splitForMerge(t, manager, 2)
+	now := time.Now()
+	manager.evaluateMerges(now)
+	if merged := manager.evaluateMerges(now.Add(time.Hour)); len(merged) != 1 {
+		t.Fatalf("Expected siblings to merge, got %d", len(merged))
+	}
+	if _, err := manager.ManualSplitCell("parent", nil); err != nil {
+		t.Fatalf("Failed to split cell: %v", err)
 	}
 
-	// Verify the child cell still exists (wasn't split due to cooldown)
-	_, err = manager.GetCell(childID)
-	if err != nil {
-		t.Error("Child cell should still exist after blocked split attempt")
+	var oscillating *CellEvent
+	for _, event := range manager.GetEvents() {
+		if event.Type == CellEventOscillating {
+			oscillating = &event
+		}
+	}
+	if oscillating == nil {
+		t.Fatalf("Expected an oscillation event after split, merge and split")
+	}
+	if oscillating.CellID != "parent" || oscillating.Metadata["changes"] != 3 {
+		t.Errorf("Unexpected oscillation event: %+v", oscillating)
 	}
 
-	// Wait for cooldown to expire
-	t.Log("Waiting for cooldown to expire...")
-	time.Sleep(cooldownDuration + 100*time.Millisecond)
+	// The re-split came soon after the merge, so its children back off
+	if level := manager.splitBackoffLevels["parent-child-1"]; level != 1 {
+		t.Errorf("Expected children of an oscillating cell to back off, got level %d", level)
+	}
+}
 
-	// Now try to split again - should succeed
-	t.Log("Attempting split after cooldown expires...")
-	secondChildren, err := manager.SplitCell(childID, 0.9)
-	if err == nil && len(secondChildren) > 0 {
-		t.Log("Split succeeded after cooldown expired")
-	} else {
-		// This might fail if the child cell doesn't have enough players, but that's ok
-		t.Logf("Split after cooldown failed (may be expected): %v", err)
+func TestCellManager_PublishesPlayerReassignments(t *testing.T) {
+	manager := NewCellManagerWithCooldown(time.Millisecond).(*DefaultCellManager)
+	defer manager.Shutdown()
+	manager.SetAutoMerge(0.5, time.Hour)
+
+	var batches [][]PlayerReassignment
+	parentRunning := false
+	manager.SetOnPlayersReassigned(func(reassignments []PlayerReassignment) {
+		batches = append(batches, reassignments)
+		// Called under the manager lock, before the source cells are removed
+		if len(batches) == 1 {
+			_, parentRunning = manager.cells["parent"]
+		}
+	})
+
+	splitForMerge(t, manager, 4)
+
+	if len(batches) != 1 || len(batches[0]) != 4 {
+		t.Fatalf("Expected one batch of 4 reassignments after the split, got %v", batches)
+	}
+	if !parentRunning {
+		t.Error("Expected players to be handed off before the parent was removed")
+	}
+	for _, reassignment := range batches[0] {
+		expected := CellID("parent-child-1")
+		if reassignment.Position.X >= 500 {
+			expected = "parent-child-2"
+		}
+		if reassignment.FromCellID != "parent" || reassignment.ToCellID != expected || reassignment.Reason != "split" {
+			t.Errorf("Unexpected split reassignment: %+v", reassignment)
+		}
 	}
 
-	t.Log("Cooldown functionality verified successfully!")
+	// Merging the children back hands every player to the restored parent
+	now := time.Now()
+	manager.evaluateMerges(now)
+	if merged := manager.evaluateMerges(now.Add(time.Hour)); len(merged) != 1 {
+		t.Fatalf("Expected siblings to merge, got %d", len(merged))
+	}
+	if len(batches) != 2 || len(batches[1]) != 4 {
+		t.Fatalf("Expected a second batch of 4 reassignments after the merge, got %v", batches)
+	}
+	for _, reassignment := range batches[1] {
+		if reassignment.ToCellID != "parent" || reassignment.FromCellID == "parent" || reassignment.Reason != "merge" {
+			t.Errorf("Unexpected merge reassignment: %+v", reassignment)
+		}
+	}
+}
+
+// newTestMetrics creates the manager's counters without registering them, so
+// tests can read them without sharing the global registry
+func newTestMetrics() *PrometheusMetrics {
+	counter := func(name string) prometheus.Counter {
+		return prometheus.NewCounter(prometheus.CounterOpts{Name: name})
+	}
+	return &PrometheusMetrics{
+		SessionReassignmentCount:  counter("session_reassignments"),
+		SessionRedistributionTime: prometheus.NewHistogram(prometheus.HistogramOpts{Name: "session_redistribution_seconds"}),
+		SessionLossCount:          counter("session_losses"),
+		SplitCooldownBlocks:       counter("split_cooldown_blocks"),
+		MergeCooldownBlocks:       counter("merge_cooldown_blocks"),
+		SplitBudgetDenials:        counter("split_budget_denials"),
+	}
+}
+
+func TestCellManager_MergeCooldownReportedOncePerWindow(t *testing.T) {
+	metrics := newTestMetrics()
+	manager := NewCellManagerWithMetricsAndCooldown(metrics, time.Hour).(*DefaultCellManager)
+	defer manager.Shutdown()
+	manager.SetAutoMerge(0.3, time.Minute)
+
+	splitForMerge(t, manager, 2)
+
+	// The group waits out the rest of its hour-long cooldown, checked every few seconds
+	now := time.Now()
+	manager.evaluateMerges(now)
+	for offset := 2 * time.Minute; offset < 50*time.Minute; offset += 15 * time.Second {
+		if merged := manager.evaluateMerges(now.Add(offset)); len(merged) != 0 {
+			t.Fatalf("Expected the cooldown to block the merge, got %d merges", len(merged))
+		}
+	}
+	if count := testutil.ToFloat64(metrics.MergeCooldownBlocks); count != 1 {
+		t.Errorf("Expected one blocked merge for the cooldown window, got %v", count)
+	}
+
+	// A longer cooldown set afterwards opens a new window, reported once more
+	manager.SetMergeCooldown(2 * time.Hour)
+	manager.evaluateMerges(now.Add(61 * time.Minute))
+	manager.evaluateMerges(now.Add(62 * time.Minute))
+	if count := testutil.ToFloat64(metrics.MergeCooldownBlocks); count != 2 {
+		t.Errorf("Expected the new cooldown window to be reported once, got %v", count)
+	}
+
+	manager.SetMergeCooldown(time.Hour)
+	if merged := manager.evaluateMerges(now.Add(70 * time.Minute)); len(merged) != 1 {
+		t.Fatalf("Expected siblings to merge once the cooldown expired, got %d", len(merged))
+	}
+}
+
+func TestCellManager_SplitRequestedAgainAfterCooldown(t *testing.T) {
+	metrics := newTestMetrics()
+	manager := NewCellManagerWithMetricsAndCooldown(metrics, 300*time.Millisecond).(*DefaultCellManager)
+	defer manager.Shutdown()
+
+	children := splitForMerge(t, manager, 0)
+	child := children[0].GetState()
+
+	// Overload a child while it is still in its split cooldown
+	for i := 0; i < 9; i++ {
+		player := &PlayerState{
+			ID:       PlayerID(fmt.Sprintf("crowd-%d", i)),
+			Position: WorldPosition{X: (child.Boundaries.XMin + child.Boundaries.XMax) / 2, Y: 500},
+		}
+		if err := manager.AddPlayer(child.ID, player); err != nil {
+			t.Fatalf("Failed to add player: %v", err)
+		}
+	}
+
+	// The first request is declined while the cooldown lasts
+	time.Sleep(100 * time.Millisecond)
+	if _, err := manager.GetCell(child.ID); err != nil {
+		t.Fatalf("Expected the child not to split during its cooldown: %v", err)
+	}
+	if blocks := testutil.ToFloat64(metrics.SplitCooldownBlocks); blocks != 1 {
+		t.Errorf("Expected one blocked split, got %v", blocks)
+	}
+
+	// The breach persists past the cooldown, so the child asks again and splits
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, err := manager.GetCell(child.ID); err != nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the child to split once its cooldown expired")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if blocks := testutil.ToFloat64(metrics.SplitCooldownBlocks); blocks != 1 {
+		t.Errorf("Expected the declined split to be retried only after the cooldown, got %v blocks", blocks)
+	}
+}
+
+func TestCellManager_ChildIDsStayBoundedAcrossGenerations(t *testing.T) {
+	manager := NewCellManagerWithCooldown(time.Millisecond).(*DefaultCellManager)
+	defer manager.Shutdown()
+
+	root := CellSpec{
+		ID:         "a-rather-long-world-name-cell-12",
+		Boundaries: createTestBounds(),
+		Capacity:   CellCapacity{MaxPlayers: 10},
+	}
+	if _, err := manager.CreateCell(root); err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+
+	seen := map[CellID]bool{root.ID: true}
+	cellID := root.ID
+	for generation := 1; generation <= 10; generation++ {
+		children, err := manager.ManualSplitCell(cellID, nil)
+		if err != nil {
+			t.Fatalf("Failed to split generation %d: %v", generation, err)
+		}
+
+		for _, child := range children {
+			state := child.GetState()
+			if len(state.ID) > MaxCellIDLength {
+				t.Errorf("Generation %d child %s is %d characters long", generation, state.ID, len(state.ID))
+			}
+			if errs := validation.IsDNS1035Label(string(state.ID)); len(errs) != 0 {
+				t.Errorf("Generation %d child %s is not a valid DNS label: %v", generation, state.ID, errs)
+			}
+			if seen[state.ID] {
+				t.Errorf("Generation %d reused the ID %s", generation, state.ID)
+			}
+			seen[state.ID] = true
+
+			// The lineage is kept on the cell, not in its name
+			if state.ParentID == nil || *state.ParentID != cellID || state.Generation != generation {
+				t.Errorf("Expected %s to descend from %s at generation %d, got %v at %d",
+					state.ID, cellID, generation, state.ParentID, state.Generation)
+			}
+		}
+		cellID = children[0].GetState().ID
+	}
 }
@@ -0,0 +1,187 @@
+package cell
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	v1 "github.com/astrosteveo/fleetforge/api/v1"
+)
+
+// CellMesh tracks the boundaries of live cells and the adjacency graph between them.
+// Two cells are neighbors when their boundaries touch along an edge or at a corner.
+type CellMesh struct {
+	bounds    map[CellID]v1.WorldBounds
+	adjacency map[CellID]map[CellID]struct{}
+	mu        sync.RWMutex
+}
+
+// NewCellMesh creates an empty cell mesh
+func NewCellMesh() *CellMesh {
+	return &CellMesh{
+		bounds:    make(map[CellID]v1.WorldBounds),
+		adjacency: make(map[CellID]map[CellID]struct{}),
+	}
+}
+
+// AddCell adds a cell to the mesh and returns every cell whose neighbor set changed,
+// including the added cell itself
+func (m *CellMesh) AddCell(id CellID, bounds v1.WorldBounds) []CellID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	changed := []CellID{id}
+	if _, exists := m.bounds[id]; exists {
+		changed = append(changed, m.removeLocked(id)...)
+	}
+
+	m.bounds[id] = bounds
+	m.adjacency[id] = make(map[CellID]struct{})
+
+	for otherID, otherBounds := range m.bounds {
+		if otherID == id || !boundsTouch(bounds, otherBounds) {
+			continue
+		}
+		m.adjacency[id][otherID] = struct{}{}
+		m.adjacency[otherID][id] = struct{}{}
+		changed = append(changed, otherID)
+	}
+
+	return uniqueCellIDs(changed)
+}
+
+// RemoveCell removes a cell from the mesh and returns its former neighbors
+func (m *CellMesh) RemoveCell(id CellID) []CellID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return uniqueCellIDs(m.removeLocked(id))
+}
+
+// removeLocked removes a cell from the mesh; the caller must hold the write lock
+func (m *CellMesh) removeLocked(id CellID) []CellID {
+	neighbors := make([]CellID, 0, len(m.adjacency[id]))
+	for neighborID := range m.adjacency[id] {
+		delete(m.adjacency[neighborID], id)
+		neighbors = append(neighbors, neighborID)
+	}
+
+	delete(m.adjacency, id)
+	delete(m.bounds, id)
+
+	return neighbors
+}
+
+// Neighbors returns the cells adjacent to a cell, sorted by ID
+func (m *CellMesh) Neighbors(id CellID) []CellID {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	neighbors := make([]CellID, 0, len(m.adjacency[id]))
+	for neighborID := range m.adjacency[id] {
+		neighbors = append(neighbors, neighborID)
+	}
+
+	sort.Slice(neighbors, func(i, j int) bool { return neighbors[i] < neighbors[j] })
+	return neighbors
+}
+
+// GetBounds returns the boundaries of a cell in the mesh
+func (m *CellMesh) GetBounds(id CellID) (v1.WorldBounds, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	bounds, exists := m.bounds[id]
+	return bounds, exists
+}
+
+// CellsWithinRadius returns the cells, other than origin, whose boundaries lie within
+// radius of a position. The search walks the adjacency graph outwards from origin and
+// falls back to a full scan when origin is not part of the mesh.
+func (m *CellMesh) CellsWithinRadius(origin CellID, position WorldPosition, radius float64) []CellID {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []CellID
+
+	if _, exists := m.bounds[origin]; !exists {
+		for id, bounds := range m.bounds {
+			if distanceToBounds(position, bounds) <= radius {
+				result = append(result, id)
+			}
+		}
+	} else {
+		// The disc around position is connected, so every cell it touches is
+		// reachable from origin through cells that also touch it
+		visited := map[CellID]bool{origin: true}
+		queue := []CellID{origin}
+
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+
+			for neighborID := range m.adjacency[current] {
+				if visited[neighborID] {
+					continue
+				}
+				visited[neighborID] = true
+
+				if distanceToBounds(position, m.bounds[neighborID]) <= radius {
+					result = append(result, neighborID)
+					queue = append(queue, neighborID)
+				}
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// boundsTouch reports whether two cell boundaries overlap or share an edge or corner.
+// Missing Y bounds are treated as unbounded.
+func boundsTouch(a, b v1.WorldBounds) bool {
+	if a.XMin > b.XMax || b.XMin > a.XMax {
+		return false
+	}
+
+	aYMin, aYMax := yRange(a)
+	bYMin, bYMax := yRange(b)
+
+	return aYMin <= bYMax && bYMin <= aYMax
+}
+
+// distanceToBounds returns the distance from a position to the closest point of a cell
+func distanceToBounds(pos WorldPosition, bounds v1.WorldBounds) float64 {
+	yMin, yMax := yRange(bounds)
+
+	dx := math.Max(0, math.Max(bounds.XMin-pos.X, pos.X-bounds.XMax))
+	dy := math.Max(0, math.Max(yMin-pos.Y, pos.Y-yMax))
+
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// yRange returns the Y extent of a boundary, treating missing bounds as infinite
+func yRange(bounds v1.WorldBounds) (float64, float64) {
+	yMin, yMax := math.Inf(-1), math.Inf(1)
+	if bounds.YMin != nil {
+		yMin = *bounds.YMin
+	}
+	if bounds.YMax != nil {
+		yMax = *bounds.YMax
+	}
+	return yMin, yMax
+}
+
+// uniqueCellIDs removes duplicate IDs while preserving order
+func uniqueCellIDs(ids []CellID) []CellID {
+	seen := make(map[CellID]bool, len(ids))
+	unique := make([]CellID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,124 @@
+package cell
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCellMesh_Adjacency(t *testing.T) {
+	mesh := NewCellMesh()
+
+	// 2x2 grid of 100x100 cells
+	mesh.AddCell("sw", createCustomBounds(0, 100, 0, 100))
+	mesh.AddCell("se", createCustomBounds(100, 200, 0, 100))
+	mesh.AddCell("nw", createCustomBounds(0, 100, 100, 200))
+	changed := mesh.AddCell("ne", createCustomBounds(100, 200, 100, 200))
+
+	if len(changed) != 4 {
+		t.Errorf("Expected adding ne to change 4 cells, got %v", changed)
+	}
+
+	// Corner contact counts as adjacency
+	if got := mesh.Neighbors("sw"); len(got) != 3 {
+		t.Errorf("Expected sw to have 3 neighbors, got %v", got)
+	}
+
+	// A distant cell touches nothing
+	mesh.AddCell("far", createCustomBounds(1000, 1100, 1000, 1100))
+	if got := mesh.Neighbors("far"); len(got) != 0 {
+		t.Errorf("Expected far to have no neighbors, got %v", got)
+	}
+
+	formerNeighbors := mesh.RemoveCell("ne")
+	if len(formerNeighbors) != 3 {
+		t.Errorf("Expected ne to have had 3 neighbors, got %v", formerNeighbors)
+	}
+	if got := mesh.Neighbors("sw"); len(got) != 2 {
+		t.Errorf("Expected sw to have 2 neighbors after removal, got %v", got)
+	}
+}
+
+func TestCellMesh_CellsWithinRadius(t *testing.T) {
+	mesh := NewCellMesh()
+
+	// Row of four 100-wide cells
+	mesh.AddCell("a", createCustomBounds(0, 100, 0, 100))
+	mesh.AddCell("b", createCustomBounds(100, 200, 0, 100))
+	mesh.AddCell("c", createCustomBounds(200, 300, 0, 100))
+	mesh.AddCell("d", createCustomBounds(300, 400, 0, 100))
+
+	// Near the a|b border only b is in range
+	got := mesh.CellsWithinRadius("a", WorldPosition{X: 90, Y: 50}, 50)
+	if len(got) != 1 || got[0] != "b" {
+		t.Errorf("Expected [b], got %v", got)
+	}
+
+	// A large radius reaches through b into c
+	got = mesh.CellsWithinRadius("a", WorldPosition{X: 90, Y: 50}, 150)
+	if len(got) != 2 || got[0] != "b" || got[1] != "c" {
+		t.Errorf("Expected [b c], got %v", got)
+	}
+
+	// In the middle of a cell nothing else is in range
+	if got := mesh.CellsWithinRadius("a", WorldPosition{X: 50, Y: 50}, 10); len(got) != 0 {
+		t.Errorf("Expected no cells in range, got %v", got)
+	}
+}
+
+func TestCellManager_MaintainsNeighbors(t *testing.T) {
+	manager := NewCellManager().(*DefaultCellManager)
+	defer manager.Shutdown()
+
+	left, err := manager.CreateCell(CellSpec{
+		ID:         "left",
+		Boundaries: createCustomBounds(0, 500, 0, 1000),
+		Capacity:   CellCapacity{MaxPlayers: 10},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+	_, err = manager.CreateCell(CellSpec{
+		ID:         "right",
+		Boundaries: createCustomBounds(500, 1000, 0, 1000),
+		Capacity:   CellCapacity{MaxPlayers: 10},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+
+	if got := left.GetState().Neighbors; len(got) != 1 || got[0] != "right" {
+		t.Fatalf("Expected left to neighbor right, got %v", got)
+	}
+
+	// A player near the border can see across it
+	if got := left.GetAOIFilter().GetNeighborCells(WorldPosition{X: 480, Y: 500}); len(got) != 1 || got[0] != "right" {
+		t.Errorf("Expected right to be within AOI radius, got %v", got)
+	}
+	if got := left.GetAOIFilter().GetNeighborCells(WorldPosition{X: 100, Y: 500}); len(got) != 0 {
+		t.Errorf("Expected no neighbor cells far from the border, got %v", got)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	children, err := manager.ManualSplitCell("right", nil)
+	if err != nil {
+		t.Fatalf("Failed to split cell: %v", err)
+	}
+
+	// The taller right cell is split across Y, so both children border left
+	if got := left.GetState().Neighbors; len(got) != 2 {
+		t.Errorf("Expected left to neighbor both children after split, got %v", got)
+	}
+	for _, child := range children {
+		if len(child.GetState().Neighbors) == 0 {
+			t.Errorf("Expected child %s to have neighbors", child.GetState().ID)
+		}
+	}
+
+	if err := manager.DeleteCell("right-child-1"); err != nil {
+		t.Fatalf("Failed to delete cell: %v", err)
+	}
+	if got := left.GetState().Neighbors; len(got) != 1 || got[0] != "right-child-2" {
+		t.Errorf("Expected left to neighbor only the remaining child after delete, got %v", got)
+	}
+}
@@ -30,6 +30,8 @@ type PrometheusMetrics struct {
 	SessionRedistributionTime prometheus.Histogram
 	SessionLossCount          prometheus.Counter
 	SplitCooldownBlocks       prometheus.Counter
+	MergeCooldownBlocks       prometheus.Counter
+	SplitBudgetDenials        prometheus.Counter
 }
 
 // NewPrometheusMetrics creates and registers Prometheus metrics (singleton)
@@ -112,6 +114,14 @@ func NewPrometheusMetrics() *PrometheusMetrics {
 				Name: "fleetforge_split_cooldown_blocks",
 				Help: "Number of split attempts blocked due to cooldown",
 			}),
+			MergeCooldownBlocks: promauto.NewCounter(prometheus.CounterOpts{
+				Name: "fleetforge_merge_cooldown_blocks",
+				Help: "Number of merge attempts blocked due to cooldown",
+			}),
+			SplitBudgetDenials: promauto.NewCounter(prometheus.CounterOpts{
+				Name: "fleetforge_split_budget_denials_total",
+				Help: "Number of splits denied because the world is at its maximum cell count",
+			}),
 		}
 	})
 	return globalMetrics
@@ -167,6 +177,16 @@ func (pm *PrometheusMetrics) IncrementSplitCooldownBlocks() {
 	pm.SplitCooldownBlocks.Inc()
 }
 
+// IncrementMergeCooldownBlocks increments the counter for blocked merge attempts
+func (pm *PrometheusMetrics) IncrementMergeCooldownBlocks() {
+	pm.MergeCooldownBlocks.Inc()
+}
+
+// IncrementSplitBudgetDenials increments the counter for splits denied by the cell budget
+func (pm *PrometheusMetrics) IncrementSplitBudgetDenials() {
+	pm.SplitBudgetDenials.Inc()
+}
+
 // RecordSessionReassignment increments the session reassignment counter
 func (pm *PrometheusMetrics) RecordSessionReassignment() {
 	pm.SessionReassignmentCount.Inc()
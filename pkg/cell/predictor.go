@@ -0,0 +1,135 @@
+package cell
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Default smoothing factors for HoltPredictor
+const (
+	defaultPredictorAlpha = 0.5
+	defaultPredictorBeta  = 0.3
+)
+
+// minForecastSamples is how many observations a cell needs before it is forecast
+const minForecastSamples = 3
+
+// DensityPredictor forecasts a cell's density from its recent history
+type DensityPredictor interface {
+	// Observe records a cell's density at a point in time
+	Observe(cellID CellID, at time.Time, density float64)
+
+	// Forecast predicts a cell's density horizon after its latest observation.
+	// It returns false until the cell has enough history.
+	Forecast(cellID CellID, horizon time.Duration) (float64, bool)
+
+	// Inherit seeds a child cell's history from its parent, scaled by the share
+	// of the parent's density the child took over
+	Inherit(parentID, childID CellID, share float64)
+
+	// Forget drops a cell's history
+	Forget(cellID CellID)
+}
+
+// densitySeries is the smoothed state of one cell's density history
+type densitySeries struct {
+	level   float64   // Smoothed density
+	trend   float64   // Smoothed change in density per second
+	last    time.Time // Time of the latest observation
+	samples int
+}
+
+// HoltPredictor forecasts density with Holt's linear trend method (double
+// exponential smoothing), adjusted for observations that arrive at irregular
+// intervals. Alpha weights new observations against the smoothed level and
+// beta weights the latest change against the smoothed trend; both lie in (0, 1].
+type HoltPredictor struct {
+	alpha  float64
+	beta   float64
+	series map[CellID]*densitySeries
+	mu     sync.Mutex
+}
+
+// NewHoltPredictor creates a predictor with the given smoothing factors. Factors
+// outside (0, 1] fall back to the defaults.
+func NewHoltPredictor(alpha, beta float64) *HoltPredictor {
+	if alpha <= 0 || alpha > 1 {
+		alpha = defaultPredictorAlpha
+	}
+	if beta <= 0 || beta > 1 {
+		beta = defaultPredictorBeta
+	}
+
+	return &HoltPredictor{
+		alpha:  alpha,
+		beta:   beta,
+		series: make(map[CellID]*densitySeries),
+	}
+}
+
+// Observe records a cell's density. Observations older than the latest one are ignored.
+func (p *HoltPredictor) Observe(cellID CellID, at time.Time, density float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s, exists := p.series[cellID]
+	if !exists {
+		p.series[cellID] = &densitySeries{level: density, last: at, samples: 1}
+		return
+	}
+
+	elapsed := at.Sub(s.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	level := p.alpha*density + (1-p.alpha)*(s.level+s.trend*elapsed)
+	if s.samples == 1 {
+		// The second observation gives the first trend estimate
+		s.trend = (density - s.level) / elapsed
+	} else {
+		s.trend = p.beta*(level-s.level)/elapsed + (1-p.beta)*s.trend
+	}
+	s.level = level
+	s.last = at
+	s.samples++
+}
+
+// Forecast predicts a cell's density horizon after its latest observation,
+// never below zero
+func (p *HoltPredictor) Forecast(cellID CellID, horizon time.Duration) (float64, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s, exists := p.series[cellID]
+	if !exists || s.samples < minForecastSamples {
+		return 0, false
+	}
+	return math.Max(s.level+s.trend*horizon.Seconds(), 0), true
+}
+
+// Inherit seeds a child's history from its parent so a split during a ramp-up
+// does not leave the children without a trend to forecast from
+func (p *HoltPredictor) Inherit(parentID, childID CellID, share float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	parent, exists := p.series[parentID]
+	if !exists {
+		return
+	}
+	p.series[childID] = &densitySeries{
+		level:   parent.level * share,
+		trend:   parent.trend * share,
+		last:    parent.last,
+		samples: parent.samples,
+	}
+}
+
+// Forget drops a cell's history
+func (p *HoltPredictor) Forget(cellID CellID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.series, cellID)
+}
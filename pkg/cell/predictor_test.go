@@ -0,0 +1,153 @@
+package cell
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHoltPredictor_ForecastsSyntheticSeries(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		density  func(minute int) float64
+		horizon  time.Duration
+		expected float64
+	}{
+		{
+			name:     "flat",
+			density:  func(int) float64 { return 0.4 },
+			horizon:  10 * time.Minute,
+			expected: 0.4,
+		},
+		{
+			name:     "linear ramp",
+			density:  func(minute int) float64 { return 0.1 + 0.02*float64(minute) },
+			horizon:  10 * time.Minute,
+			expected: 0.1 + 0.02*29,
+		},
+		{
+			name: "noisy ramp",
+			density: func(minute int) float64 {
+				return 0.1 + 0.02*float64(minute) + 0.01*math.Sin(float64(minute))
+			},
+			horizon:  10 * time.Minute,
+			expected: 0.1 + 0.02*29,
+		},
+		{
+			name:     "draining",
+			density:  func(minute int) float64 { return math.Max(0.6-0.05*float64(minute), 0) },
+			horizon:  time.Hour,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predictor := NewHoltPredictor(0, 0)
+			for minute := 0; minute < 20; minute++ {
+				predictor.Observe("cell", start.Add(time.Duration(minute)*time.Minute), tt.density(minute))
+			}
+
+			forecast, ok := predictor.Forecast("cell", tt.horizon)
+			if !ok {
+				t.Fatalf("Expected a forecast after 20 observations")
+			}
+			if math.Abs(forecast-tt.expected) > 0.05 {
+				t.Errorf("Expected forecast near %.3f, got %.3f", tt.expected, forecast)
+			}
+		})
+	}
+}
+
+func TestHoltPredictor_HistoryLifecycle(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	predictor := NewHoltPredictor(0.5, 0.3)
+
+	predictor.Observe("parent", start, 0.2)
+	predictor.Observe("parent", start.Add(time.Minute), 0.3)
+	if _, ok := predictor.Forecast("parent", time.Minute); ok {
+		t.Errorf("Expected no forecast before %d observations", minForecastSamples)
+	}
+
+	// Out-of-order observations are ignored
+	predictor.Observe("parent", start, 0.9)
+	predictor.Observe("parent", start.Add(2*time.Minute), 0.4)
+	parentForecast, ok := predictor.Forecast("parent", 5*time.Minute)
+	if !ok {
+		t.Fatalf("Expected a forecast after %d observations", minForecastSamples)
+	}
+
+	// Children inherit a share of the parent's level and trend
+	predictor.Inherit("parent", "child", 0.5)
+	childForecast, ok := predictor.Forecast("child", 5*time.Minute)
+	if !ok || math.Abs(childForecast-parentForecast/2) > 1e-9 {
+		t.Errorf("Expected child forecast %.3f, got %.3f (ok %v)", parentForecast/2, childForecast, ok)
+	}
+
+	predictor.Forget("parent")
+	if _, ok := predictor.Forecast("parent", time.Minute); ok {
+		t.Errorf("Expected forgotten history to have no forecast")
+	}
+}
+
+func TestCellManager_PredictiveSplitBeforeThreshold(t *testing.T) {
+	manager := NewCellManagerWithCooldown(time.Millisecond).(*DefaultCellManager)
+	defer manager.Shutdown()
+	manager.SetPredictiveScaling(NewHoltPredictor(0, 0), 0.8, time.Hour)
+
+	spec := CellSpec{
+		ID:         "ramping-cell",
+		Boundaries: createTestBounds(),
+		Capacity:   CellCapacity{MaxPlayers: 100},
+	}
+	if _, err := manager.CreateCell(spec); err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+
+	// Wait for cell to be ready
+	time.Sleep(time.Millisecond * 150)
+
+	// Ten players join each minute: well under the threshold now, but on course to cross it
+	start := time.Now()
+	players := 0
+	var split []*Cell
+	for minute := 0; minute < 4 && len(split) == 0; minute++ {
+		for i := 0; i < 10; i++ {
+			player := &PlayerState{ID: PlayerID(fmt.Sprintf("player-%d", players)), Position: WorldPosition{X: float64(players * 20), Y: 500}}
+			if err := manager.AddPlayer(spec.ID, player); err != nil {
+				t.Fatalf("Failed to add player: %v", err)
+			}
+			players++
+		}
+		split = manager.evaluatePredictions(start.Add(time.Duration(minute) * time.Minute))
+	}
+
+	if len(split) != 2 {
+		t.Fatalf("Expected the ramping cell to be pre-split, got %d children with %d players", len(split), players)
+	}
+	if players >= 80 {
+		t.Errorf("Expected the split before the cell reached the threshold, at %d players", players)
+	}
+
+	var splitEvent *CellEvent
+	for _, event := range manager.GetEvents() {
+		if event.Type == CellEventSplit && event.CellID == spec.ID {
+			splitEvent = &event
+		}
+	}
+	if splitEvent == nil || splitEvent.Metadata["reason"] != "PredictedThresholdBreach" {
+		t.Fatalf("Expected a PredictedThresholdBreach split event, got %+v", splitEvent)
+	}
+	if forecast, _ := splitEvent.Metadata["forecast_density"].(float64); forecast < 0.8 {
+		t.Errorf("Expected the forecast density to reach the threshold, got %v", splitEvent.Metadata["forecast_density"])
+	}
+
+	// The children carry on the parent's trend from the split event
+	manager.evaluatePredictions(start.Add(4 * time.Minute))
+	if _, ok := manager.predictor.Forecast(split[0].GetState().ID, time.Minute); !ok {
+		t.Errorf("Expected children to inherit the parent's density history")
+	}
+}
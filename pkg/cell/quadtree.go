@@ -0,0 +1,356 @@
+package cell
+
+import (
+	"container/heap"
+	"math"
+)
+
+// quadNode is a node in an adaptive point quadtree. Leaves hold entries directly;
+// internal nodes hold exactly four children and track the size of their subtree.
+type quadNode struct {
+	minX, minY float64
+	maxX, maxY float64
+	depth      int
+
+	entries  map[PlayerID]WorldPosition
+	children *[4]*quadNode
+	count    int
+}
+
+// quadtree is a point quadtree whose leaves subdivide when they exceed maxEntries
+// and collapse back into a single leaf when their subtree drops to minEntries or below
+type quadtree struct {
+	root       *quadNode
+	maxEntries int
+	minEntries int
+	maxDepth   int
+}
+
+// newQuadtree creates an empty quadtree with the given subdivision limits
+func newQuadtree(maxEntries, minEntries, maxDepth int) *quadtree {
+	return &quadtree{
+		maxEntries: maxEntries,
+		minEntries: minEntries,
+		maxDepth:   maxDepth,
+	}
+}
+
+// newQuadLeaf creates an empty leaf covering the given square
+func newQuadLeaf(minX, minY, maxX, maxY float64, depth int) *quadNode {
+	return &quadNode{
+		minX:    minX,
+		minY:    minY,
+		maxX:    maxX,
+		maxY:    maxY,
+		depth:   depth,
+		entries: make(map[PlayerID]WorldPosition),
+	}
+}
+
+// insert adds a point, growing the root when the point falls outside it
+func (t *quadtree) insert(id PlayerID, pos WorldPosition) {
+	if math.IsNaN(pos.X) || math.IsNaN(pos.Y) || math.IsInf(pos.X, 0) || math.IsInf(pos.Y, 0) {
+		return
+	}
+
+	if t.root == nil {
+		// Start with a square around the first point; the root grows on demand
+		const initialHalfSize = 512.0
+		t.root = newQuadLeaf(pos.X-initialHalfSize, pos.Y-initialHalfSize,
+			pos.X+initialHalfSize, pos.Y+initialHalfSize, 0)
+	}
+
+	for !t.root.contains(pos) {
+		t.grow(pos)
+	}
+
+	t.insertInto(t.root, id, pos)
+}
+
+// remove deletes a point previously inserted at pos
+func (t *quadtree) remove(id PlayerID, pos WorldPosition) {
+	if t.root == nil || !t.root.contains(pos) {
+		return
+	}
+
+	t.removeFrom(t.root, id, pos)
+
+	if t.root.count == 0 {
+		t.root = nil
+	}
+}
+
+// grow doubles the root towards pos, keeping the old root as one of the new quadrants
+func (t *quadtree) grow(pos WorldPosition) {
+	old := t.root
+	size := old.maxX - old.minX
+
+	minX, minY := old.minX, old.minY
+	if pos.X < old.minX {
+		minX -= size
+	}
+	if pos.Y < old.minY {
+		minY -= size
+	}
+
+	root := &quadNode{
+		minX:  minX,
+		minY:  minY,
+		maxX:  minX + 2*size,
+		maxY:  minY + 2*size,
+		count: old.count,
+	}
+	root.children = root.makeChildren()
+
+	idx := root.childIndex(WorldPosition{X: (old.minX + old.maxX) / 2, Y: (old.minY + old.maxY) / 2})
+	root.children[idx] = old
+
+	// Depth is measured from the root, so every existing node moves one level down
+	old.shiftDepth(1)
+	t.root = root
+}
+
+// insertInto adds a point to the subtree rooted at node
+func (t *quadtree) insertInto(node *quadNode, id PlayerID, pos WorldPosition) {
+	for {
+		node.count++
+		if node.children == nil {
+			node.entries[id] = pos
+			if len(node.entries) > t.maxEntries && node.depth < t.maxDepth {
+				t.subdivide(node)
+			}
+			return
+		}
+		node = node.children[node.childIndex(pos)]
+	}
+}
+
+// removeFrom deletes a point from the subtree rooted at node, collapsing underfull subtrees
+func (t *quadtree) removeFrom(node *quadNode, id PlayerID, pos WorldPosition) bool {
+	if node.children == nil {
+		if _, exists := node.entries[id]; !exists {
+			return false
+		}
+		delete(node.entries, id)
+		node.count--
+		return true
+	}
+
+	if !t.removeFrom(node.children[node.childIndex(pos)], id, pos) {
+		return false
+	}
+
+	node.count--
+	if node.count <= t.minEntries {
+		t.collapse(node)
+	}
+	return true
+}
+
+// subdivide turns a leaf into an internal node and pushes its entries down
+func (t *quadtree) subdivide(node *quadNode) {
+	node.children = node.makeChildren()
+	entries := node.entries
+	node.entries = nil
+
+	for id, pos := range entries {
+		child := node.children[node.childIndex(pos)]
+		child.count++
+		child.entries[id] = pos
+	}
+
+	// A single crowded quadrant may need to subdivide again
+	for _, child := range node.children {
+		if len(child.entries) > t.maxEntries && child.depth < t.maxDepth {
+			t.subdivide(child)
+		}
+	}
+}
+
+// collapse merges an internal node's subtree back into a single leaf
+func (t *quadtree) collapse(node *quadNode) {
+	entries := make(map[PlayerID]WorldPosition, node.count)
+	node.collectEntries(entries)
+	node.children = nil
+	node.entries = entries
+}
+
+// queryRange appends to out every point within radius of center
+func (t *quadtree) queryRange(center WorldPosition, radius float64, out []PlayerID) []PlayerID {
+	if t.root == nil {
+		return out
+	}
+
+	stack := []*quadNode{t.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if node.count == 0 || node.distanceTo(center) > radius {
+			continue
+		}
+
+		if node.children == nil {
+			for id, pos := range node.entries {
+				if distance(center, pos) <= radius {
+					out = append(out, id)
+				}
+			}
+			continue
+		}
+
+		stack = append(stack, node.children[0], node.children[1], node.children[2], node.children[3])
+	}
+
+	return out
+}
+
+// nearest returns up to k points ordered by increasing distance from center
+func (t *quadtree) nearest(center WorldPosition, k int) []PlayerID {
+	if t.root == nil || k <= 0 {
+		return nil
+	}
+
+	// Best-first search: nodes and points share one queue ordered by distance,
+	// so points are popped in exactly nearest-first order
+	queue := &quadQueue{}
+	heap.Push(queue, quadQueueItem{node: t.root, dist: t.root.distanceTo(center)})
+
+	result := make([]PlayerID, 0, k)
+	for queue.Len() > 0 && len(result) < k {
+		item := heap.Pop(queue).(quadQueueItem)
+
+		if item.node == nil {
+			result = append(result, item.id)
+			continue
+		}
+
+		node := item.node
+		if node.children == nil {
+			for id, pos := range node.entries {
+				heap.Push(queue, quadQueueItem{id: id, dist: distance(center, pos)})
+			}
+			continue
+		}
+
+		for _, child := range node.children {
+			if child.count > 0 {
+				heap.Push(queue, quadQueueItem{node: child, dist: child.distanceTo(center)})
+			}
+		}
+	}
+
+	return result
+}
+
+// leafCount returns the number of leaves, used to observe subdivision
+func (t *quadtree) leafCount() int {
+	if t.root == nil {
+		return 0
+	}
+	return t.root.leafCount()
+}
+
+// contains reports whether pos lies inside the node's half-open square
+func (n *quadNode) contains(pos WorldPosition) bool {
+	return pos.X >= n.minX && pos.X < n.maxX && pos.Y >= n.minY && pos.Y < n.maxY
+}
+
+// childIndex returns the quadrant of pos: bit 0 selects east, bit 1 selects north
+func (n *quadNode) childIndex(pos WorldPosition) int {
+	midX := (n.minX + n.maxX) / 2
+	midY := (n.minY + n.maxY) / 2
+
+	idx := 0
+	if pos.X >= midX {
+		idx |= 1
+	}
+	if pos.Y >= midY {
+		idx |= 2
+	}
+	return idx
+}
+
+// makeChildren creates four empty leaves covering the node's quadrants
+func (n *quadNode) makeChildren() *[4]*quadNode {
+	midX := (n.minX + n.maxX) / 2
+	midY := (n.minY + n.maxY) / 2
+	depth := n.depth + 1
+
+	return &[4]*quadNode{
+		newQuadLeaf(n.minX, n.minY, midX, midY, depth),
+		newQuadLeaf(midX, n.minY, n.maxX, midY, depth),
+		newQuadLeaf(n.minX, midY, midX, n.maxY, depth),
+		newQuadLeaf(midX, midY, n.maxX, n.maxY, depth),
+	}
+}
+
+// distanceTo returns the distance from pos to the closest point of the node's square
+func (n *quadNode) distanceTo(pos WorldPosition) float64 {
+	dx := math.Max(0, math.Max(n.minX-pos.X, pos.X-n.maxX))
+	dy := math.Max(0, math.Max(n.minY-pos.Y, pos.Y-n.maxY))
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// collectEntries copies every point in the subtree into out
+func (n *quadNode) collectEntries(out map[PlayerID]WorldPosition) {
+	if n.children == nil {
+		for id, pos := range n.entries {
+			out[id] = pos
+		}
+		return
+	}
+	for _, child := range n.children {
+		child.collectEntries(out)
+	}
+}
+
+// shiftDepth adds delta to the depth of every node in the subtree
+func (n *quadNode) shiftDepth(delta int) {
+	n.depth += delta
+	if n.children != nil {
+		for _, child := range n.children {
+			child.shiftDepth(delta)
+		}
+	}
+}
+
+// leafCount returns the number of leaves in the subtree
+func (n *quadNode) leafCount() int {
+	if n.children == nil {
+		return 1
+	}
+	total := 0
+	for _, child := range n.children {
+		total += child.leafCount()
+	}
+	return total
+}
+
+// quadQueueItem is either a node or a single point queued for nearest-neighbour search
+type quadQueueItem struct {
+	node *quadNode
+	id   PlayerID
+	dist float64
+}
+
+// quadQueue is a min-heap of queue items ordered by distance
+type quadQueue []quadQueueItem
+
+func (q quadQueue) Len() int            { return len(q) }
+func (q quadQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q quadQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *quadQueue) Push(x interface{}) { *q = append(*q, x.(quadQueueItem)) }
+func (q *quadQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// distance calculates the Euclidean distance between two positions
+func distance(pos1, pos2 WorldPosition) float64 {
+	dx := pos1.X - pos2.X
+	dy := pos1.Y - pos2.Y
+	return math.Sqrt(dx*dx + dy*dy)
+}
@@ -3,13 +3,18 @@ package cell
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	fleetforgev1 "github.com/astrosteveo/fleetforge/api/v1"
 	"github.com/go-logr/logr"
 )
 
-// CellSimulator wraps a cell manager to provide simulation capabilities
+// CellSimulator wraps a cell manager to provide simulation capabilities. The
+// simulated cell never splits in process: the controller owns the world's
+// topology, so a split is requested in the cell's status and performed by the
+// controller, which then has the simulator hand its players off to the children.
 type CellSimulator struct {
 	cellID            CellID
 	boundaries        fleetforgev1.WorldBounds
@@ -17,16 +22,21 @@ type CellSimulator struct {
 	logger            logr.Logger
 	manager           CellManager
 	cell              *Cell
+	stateCodec        string
 	ctx               context.Context
 	cancel            context.CancelFunc
 	prometheusMetrics *PrometheusMetrics
+
+	mu             sync.Mutex
+	splitReason    string          // Why a split was requested; empty until one is
+	handoffTargets []HandoffTarget // The cells taking the players over, once the cell is split
 }
 
 // NewCellSimulator creates a new cell simulator
 func NewCellSimulator(cellID string, boundaries fleetforgev1.WorldBounds, maxPlayers int32, logger logr.Logger) *CellSimulator {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &CellSimulator{
+	cs := &CellSimulator{
 		cellID:            CellID(cellID),
 		boundaries:        boundaries,
 		MaxPlayers:        maxPlayers,
@@ -36,6 +46,59 @@ func NewCellSimulator(cellID string, boundaries fleetforgev1.WorldBounds, maxPla
 		cancel:            cancel,
 		prometheusMetrics: NewPrometheusMetrics(),
 	}
+	if defaultManager, ok := cs.manager.(*DefaultCellManager); ok {
+		defaultManager.SetOnSplitRequested(cs.requestSplit)
+	}
+	return cs
+}
+
+// SetCheckpointStore enables periodic checkpoint persistence for the simulated cell
+func (cs *CellSimulator) SetCheckpointStore(store CheckpointStore, interval time.Duration) {
+	if defaultManager, ok := cs.manager.(*DefaultCellManager); ok {
+		defaultManager.SetCheckpointStore(store, interval)
+	}
+}
+
+// SetStateCodec selects the codec used to encode the simulated cell's checkpoints
+func (cs *CellSimulator) SetStateCodec(name string) error {
+	if _, err := NewStateCodec(name); err != nil {
+		return err
+	}
+	cs.stateCodec = name
+	return nil
+}
+
+// SetSplitTrigger sets the hysteresis band that ends a threshold breach of the
+// simulated cell, and how long a breach must last before a split is requested
+func (cs *CellSimulator) SetSplitTrigger(hysteresis float64, sustainedBreach time.Duration) {
+	if defaultManager, ok := cs.manager.(*DefaultCellManager); ok {
+		defaultManager.SetSplitTrigger(hysteresis, sustainedBreach)
+	}
+}
+
+// SetSplitThreshold sets the density at which the simulated cell requests a split
+func (cs *CellSimulator) SetSplitThreshold(threshold float64) {
+	if defaultManager, ok := cs.manager.(*DefaultCellManager); ok {
+		defaultManager.SetSplitThreshold(threshold)
+	}
+}
+
+// SetTickRate sets how many simulation ticks per second the simulated cell runs
+func (cs *CellSimulator) SetTickRate(ticksPerSecond int) {
+	if ticksPerSecond <= 0 {
+		return
+	}
+	if defaultManager, ok := cs.manager.(*DefaultCellManager); ok {
+		defaultManager.SetTickRate(time.Second / time.Duration(ticksPerSecond))
+	}
+}
+
+// SetPredictiveScaling enables requesting a split of the simulated cell early,
+// when its density is forecast to reach threshold within horizon
+func (cs *CellSimulator) SetPredictiveScaling(threshold float64, horizon time.Duration) {
+	if defaultManager, ok := cs.manager.(*DefaultCellManager); ok {
+		defaultManager.SetPredictiveScaling(NewHoltPredictor(0, 0), threshold, horizon)
+	}
 }
 
 // Start starts the cell simulator
@@ -49,6 +112,9 @@ func (cs *CellSimulator) Start() error {
 			MemoryLimit: "1Gi",
 		},
 	}
+	if cs.stateCodec != "" {
+		spec.GameConfig = map[string]interface{}{StateCodecConfigKey: cs.stateCodec}
+	}
 
 	cell, err := cs.manager.CreateCell(spec)
 	if err != nil {
@@ -130,17 +196,49 @@ func (cs *CellSimulator) GetStatus() map[string]interface{} {
 	}
 
 	health := cs.GetHealth()
+	splitReason, splitRequested := cs.SplitRequested()
 	return map[string]interface{}{
-		"id":             string(cs.cellID),
-		"health":         cs.getHealthString(health),
-		"currentPlayers": health.PlayerCount,
-		"maxPlayers":     cs.MaxPlayers,
-		"ready":          health.Healthy,
-		"cpuUsage":       health.CPUUsage,
-		"memoryUsage":    health.MemoryUsage,
-		"uptime":         health.Uptime.Seconds(),
-		"errors":         health.Errors,
+		"id":              string(cs.cellID),
+		"health":          cs.getHealthString(health),
+		"currentPlayers":  health.PlayerCount,
+		"maxPlayers":      cs.MaxPlayers,
+		"ready":           health.Healthy,
+		"cpuUsage":        health.CPUUsage,
+		"memoryUsage":     health.MemoryUsage,
+		"uptime":          health.Uptime.Seconds(),
+		"errors":          health.Errors,
+		"splitRequested":  splitRequested,
+		"splitReason":     splitReason,
+		"playerPositions": cs.PlayerPositions(maxReportedPositions),
+	}
+}
+
+// maxReportedPositions bounds the player positions a simulated cell reports in
+// its status, which the controller keeps on the Cell resource
+const maxReportedPositions = 100
+
+// PlayerPositions returns the positions of up to limit of the simulated cell's
+// players, spread evenly over them, for the controller to place a split
+func (cs *CellSimulator) PlayerPositions(limit int) []WorldPosition {
+	if cs.cell == nil || limit <= 0 {
+		return nil
+	}
+
+	state := cs.cell.GetState()
+	ids := make([]PlayerID, 0, len(state.Players))
+	for id := range state.Players {
+		ids = append(ids, id)
 	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	positions := make([]WorldPosition, 0, min(len(ids), limit))
+	for i := 0; i < len(ids) && len(positions) < limit; i++ {
+		// Take every player when they fit, else an even stride through them
+		if len(ids) <= limit || i*limit/len(ids) == len(positions) {
+			positions = append(positions, state.Players[ids[i]].Position)
+		}
+	}
+	return positions
 }
 
 // getHealthString converts HealthStatus to a string representation
@@ -195,6 +293,91 @@ func (cs *CellSimulator) UpdatePlayerPosition(playerID string, position WorldPos
 	return cs.manager.UpdatePlayerPosition(cs.cellID, PlayerID(playerID), position)
 }
 
+// requestSplit records a split the simulated cell asked for. The request stands
+// until the controller splits the cell and its players are handed off.
+func (cs *CellSimulator) requestSplit(cellID CellID, reason string) {
+	if cellID != cs.cellID {
+		return
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.splitReason == "" {
+		cs.logger.Info("Split requested from the controller", "cellID", cellID, "reason", reason)
+	}
+	cs.splitReason = reason
+}
+
+// SplitRequested reports whether the simulated cell asked to be split, and why
+func (cs *CellSimulator) SplitRequested() (string, bool) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.splitReason, cs.splitReason != ""
+}
+
+// HandOff hands the simulated cell's players off to the cells taking over its
+// space after a split or merge, each to the target containing their position. The players stay in the
+// simulated cell until the gateway moves their connections, and the returned
+// reassignments tell it where to. Players who join afterwards are handed off
+// as well, see HandoffFor.
+func (cs *CellSimulator) HandOff(targets []HandoffTarget) ([]PlayerReassignment, error) {
+	if cs.cell == nil {
+		return nil, fmt.Errorf("cell not initialized")
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no cells to hand players off to")
+	}
+
+	cs.mu.Lock()
+	cs.handoffTargets = append([]HandoffTarget(nil), targets...)
+	cs.mu.Unlock()
+
+	state := cs.cell.GetState()
+	reassignments := make([]PlayerReassignment, 0, len(state.Players))
+	for _, player := range state.Players {
+		reassignments = append(reassignments, cs.handoffReassignment(player, targets))
+	}
+	return reassignments, nil
+}
+
+// HandoffFor returns where a player should go once the simulated cell has
+// handed its players off, or false while it still serves them
+func (cs *CellSimulator) HandoffFor(playerID string) (PlayerReassignment, bool) {
+	cs.mu.Lock()
+	targets := cs.handoffTargets
+	cs.mu.Unlock()
+
+	if cs.cell == nil || len(targets) == 0 {
+		return PlayerReassignment{}, false
+	}
+	player, exists := cs.cell.GetState().Players[PlayerID(playerID)]
+	if !exists {
+		return PlayerReassignment{}, false
+	}
+	return cs.handoffReassignment(player, targets), true
+}
+
+// handoffReassignment moves a player to the target containing their position,
+// or the first target when none does
+func (cs *CellSimulator) handoffReassignment(player *PlayerState, targets []HandoffTarget) PlayerReassignment {
+	target := targets[0].ID
+	for _, candidate := range targets {
+		if containsPosition(candidate.Boundaries, player.Position) {
+			target = candidate.ID
+			break
+		}
+	}
+
+	return PlayerReassignment{
+		PlayerID:   player.ID,
+		FromCellID: cs.cellID,
+		ToCellID:   target,
+		Position:   player.Position,
+		Reason:     "handoff",
+		Timestamp:  time.Now(),
+	}
+}
+
 // GetBoundaries returns the cell boundaries
 func (cs *CellSimulator) GetBoundaries() fleetforgev1.WorldBounds {
 	return cs.boundaries
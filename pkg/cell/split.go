@@ -0,0 +1,293 @@
+package cell
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	v1 "github.com/astrosteveo/fleetforge/api/v1"
+)
+
+// SplitStrategy decides how an overloaded cell's bounds are divided between its children
+type SplitStrategy interface {
+	// Name returns the strategy name used to select it
+	Name() string
+
+	// Split divides bounds into child bounds that exactly cover it. Positions are
+	// the current player positions, for strategies that balance population.
+	Split(bounds v1.WorldBounds, positions []WorldPosition) []v1.WorldBounds
+}
+
+// splitAxis identifies a bounds axis
+type splitAxis int
+
+const (
+	axisX splitAxis = iota
+	axisY
+	axisZ
+)
+
+// NewSplitStrategy returns the split strategy registered under a name; an empty
+// name selects longest-axis bisection
+func NewSplitStrategy(name string) (SplitStrategy, error) {
+	switch name {
+	case "", v1.SplitStrategyLongestAxis:
+		return LongestAxisSplit{}, nil
+	case v1.SplitStrategyQuad:
+		return QuadSplit{}, nil
+	case v1.SplitStrategyOctree:
+		return OctreeSplit{}, nil
+	case v1.SplitStrategyPlayerMedian:
+		return PlayerMedianSplit{}, nil
+	default:
+		return nil, fmt.Errorf("unknown split strategy %q", name)
+	}
+}
+
+// LongestAxisSplit bisects a cell across its longest axis so repeated splits
+// keep cells close to square instead of producing thin slivers
+type LongestAxisSplit struct{}
+
+// Name returns the strategy name
+func (LongestAxisSplit) Name() string { return v1.SplitStrategyLongestAxis }
+
+// Split bisects bounds across its longest axis at the point that best balances
+// the players on either side
+func (LongestAxisSplit) Split(bounds v1.WorldBounds, positions []WorldPosition) []v1.WorldBounds {
+	axis := longestAxis(bounds, true)
+	lo, hi := axisRange(bounds, axis)
+	return splitAlong(bounds, axis, balancedCut(lo, hi, axisCoords(positions, axis)))
+}
+
+// QuadSplit divides a 2D cell into four quadrants. Cells without Y bounds fall
+// back to longest-axis bisection.
+type QuadSplit struct{}
+
+// Name returns the strategy name
+func (QuadSplit) Name() string { return v1.SplitStrategyQuad }
+
+// Split divides bounds into four quadrants, cutting X and then each half's Y
+// where the players are balanced
+func (QuadSplit) Split(bounds v1.WorldBounds, positions []WorldPosition) []v1.WorldBounds {
+	if !hasAxis(bounds, axisY) {
+		return LongestAxisSplit{}.Split(bounds, positions)
+	}
+	return splitAcross(bounds, positions, axisX, axisY)
+}
+
+// OctreeSplit divides a 3D cell into eight octants. Cells without Z bounds fall
+// back to a quad split. Player positions carry no Z, so players are assigned to
+// children by their X and Y coordinates only and Z is always cut at its midpoint.
+type OctreeSplit struct{}
+
+// Name returns the strategy name
+func (OctreeSplit) Name() string { return v1.SplitStrategyOctree }
+
+// Split divides bounds into eight octants
+func (OctreeSplit) Split(bounds v1.WorldBounds, positions []WorldPosition) []v1.WorldBounds {
+	if !hasAxis(bounds, axisY) || !hasAxis(bounds, axisZ) {
+		return QuadSplit{}.Split(bounds, positions)
+	}
+	return splitAcross(bounds, positions, axisX, axisY, axisZ)
+}
+
+// PlayerMedianSplit bisects a cell across the axis its players are most spread
+// along, rather than the longest one, so a cluster is split through its middle
+type PlayerMedianSplit struct{}
+
+// Name returns the strategy name
+func (PlayerMedianSplit) Name() string { return v1.SplitStrategyPlayerMedian }
+
+// Split bisects bounds at the median player coordinate along the axis with the
+// widest player spread, or across the longest axis when there are no players
+func (PlayerMedianSplit) Split(bounds v1.WorldBounds, positions []WorldPosition) []v1.WorldBounds {
+	axis := longestAxis(bounds, false)
+	if hasAxis(bounds, axisY) && len(positions) > 0 {
+		if spread(axisCoords(positions, axisY)) > spread(axisCoords(positions, axisX)) {
+			axis = axisY
+		} else {
+			axis = axisX
+		}
+	}
+
+	lo, hi := axisRange(bounds, axis)
+	return splitAlong(bounds, axis, balancedCut(lo, hi, axisCoords(positions, axis)))
+}
+
+// minSplitFraction keeps a density-aware cut at least this fraction of an axis
+// away from either edge, so a tight cluster can't produce a sliver child
+const minSplitFraction = 0.1
+
+// balancedCut returns where to cut [lo, hi] so the players on either side are
+// as even as possible. It cuts halfway between the players either side of the
+// median, moving past players that share a coordinate, and falls back to the
+// midpoint when there are too few players to balance.
+func balancedCut(lo, hi float64, coords []float64) float64 {
+	mid := (lo + hi) / 2
+	if len(coords) < 2 {
+		return mid
+	}
+
+	sorted := append([]float64(nil), coords...)
+	sort.Float64s(sorted)
+
+	// A cut in the gap before sorted[i] leaves i players in the lower child, so
+	// pick the gap between distinct coordinates closest to half of them
+	half := len(sorted) / 2
+	best := -1
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			continue
+		}
+		if best < 0 || math.Abs(float64(i-half)) < math.Abs(float64(best-half)) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return mid
+	}
+
+	at := (sorted[best-1] + sorted[best]) / 2
+	margin := (hi - lo) * minSplitFraction
+	return math.Min(math.Max(at, lo+margin), hi-margin)
+}
+
+// spread returns the distance between the smallest and largest coordinate
+func spread(coords []float64) float64 {
+	if len(coords) == 0 {
+		return 0
+	}
+	lo, hi := coords[0], coords[0]
+	for _, c := range coords[1:] {
+		lo, hi = math.Min(lo, c), math.Max(hi, c)
+	}
+	return hi - lo
+}
+
+// hasAxis reports whether bounds define an axis; X is always defined
+func hasAxis(bounds v1.WorldBounds, axis splitAxis) bool {
+	switch axis {
+	case axisY:
+		return bounds.YMin != nil && bounds.YMax != nil
+	case axisZ:
+		return bounds.ZMin != nil && bounds.ZMax != nil
+	default:
+		return true
+	}
+}
+
+// axisRange returns the minimum and maximum of bounds along an axis
+func axisRange(bounds v1.WorldBounds, axis splitAxis) (float64, float64) {
+	switch axis {
+	case axisY:
+		return *bounds.YMin, *bounds.YMax
+	case axisZ:
+		return *bounds.ZMin, *bounds.ZMax
+	default:
+		return bounds.XMin, bounds.XMax
+	}
+}
+
+// longestAxis returns the longest defined axis of bounds, preferring X, then Y,
+// then Z on ties. Z is only considered when includeZ is set.
+func longestAxis(bounds v1.WorldBounds, includeZ bool) splitAxis {
+	best := axisX
+	bestLength := bounds.XMax - bounds.XMin
+
+	candidates := []splitAxis{axisY}
+	if includeZ {
+		candidates = append(candidates, axisZ)
+	}
+	for _, axis := range candidates {
+		if !hasAxis(bounds, axis) {
+			continue
+		}
+		lo, hi := axisRange(bounds, axis)
+		if hi-lo > bestLength {
+			best = axis
+			bestLength = hi - lo
+		}
+	}
+
+	return best
+}
+
+// axisCoords returns the player coordinates along an axis. Positions carry no Z,
+// so there are none to balance along it.
+func axisCoords(positions []WorldPosition, axis splitAxis) []float64 {
+	if axis == axisZ || len(positions) == 0 {
+		return nil
+	}
+	coords := make([]float64, len(positions))
+	for i, pos := range positions {
+		coords[i] = positionCoord(pos, axis)
+	}
+	return coords
+}
+
+// positionCoord returns a player position's coordinate along X or Y
+func positionCoord(pos WorldPosition, axis splitAxis) float64 {
+	if axis == axisY {
+		return pos.Y
+	}
+	return pos.X
+}
+
+// partitionPositions divides positions at a cut. Positions on the cut go to the
+// lower side, matching how players are assigned to children; every position
+// stays on the lower side of a Z cut.
+func partitionPositions(positions []WorldPosition, axis splitAxis, at float64) ([]WorldPosition, []WorldPosition) {
+	if axis == axisZ {
+		return positions, nil
+	}
+	var lower, upper []WorldPosition
+	for _, pos := range positions {
+		if positionCoord(pos, axis) <= at {
+			lower = append(lower, pos)
+		} else {
+			upper = append(upper, pos)
+		}
+	}
+	return lower, upper
+}
+
+// splitAlong cuts bounds in two at a coordinate along an axis
+func splitAlong(bounds v1.WorldBounds, axis splitAxis, at float64) []v1.WorldBounds {
+	// Deep copies so children never share optional coordinate pointers
+	lower, upper := *bounds.DeepCopy(), *bounds.DeepCopy()
+	switch axis {
+	case axisY:
+		lower.YMax, upper.YMin = floatPtr(at), floatPtr(at)
+	case axisZ:
+		lower.ZMax, upper.ZMin = floatPtr(at), floatPtr(at)
+	default:
+		lower.XMax, upper.XMin = at, at
+	}
+	return []v1.WorldBounds{lower, upper}
+}
+
+// splitAcross cuts bounds along every given axis in turn, like a k-d tree, so
+// each cut balances the players within the region being cut
+func splitAcross(bounds v1.WorldBounds, positions []WorldPosition, axes ...splitAxis) []v1.WorldBounds {
+	children := []v1.WorldBounds{bounds}
+	members := [][]WorldPosition{positions}
+	for _, axis := range axes {
+		nextChildren := make([]v1.WorldBounds, 0, len(children)*2)
+		nextMembers := make([][]WorldPosition, 0, len(children)*2)
+		for i, child := range children {
+			lo, hi := axisRange(child, axis)
+			at := balancedCut(lo, hi, axisCoords(members[i], axis))
+			lower, upper := partitionPositions(members[i], axis, at)
+
+			nextChildren = append(nextChildren, splitAlong(child, axis, at)...)
+			nextMembers = append(nextMembers, lower, upper)
+		}
+		children, members = nextChildren, nextMembers
+	}
+	return children
+}
+
+// floatPtr returns a pointer to a float64
+func floatPtr(v float64) *float64 {
+	return &v
+}
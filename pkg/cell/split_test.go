@@ -0,0 +1,236 @@
+package cell
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "github.com/astrosteveo/fleetforge/api/v1"
+)
+
+// boundsVolume returns the area of 2D bounds or the volume of 3D bounds
+func boundsVolume(b v1.WorldBounds) float64 {
+	volume := b.XMax - b.XMin
+	if b.YMin != nil && b.YMax != nil {
+		volume *= *b.YMax - *b.YMin
+	}
+	if b.ZMin != nil && b.ZMax != nil {
+		volume *= *b.ZMax - *b.ZMin
+	}
+	return volume
+}
+
+func TestSplitStrategies_PartitionParent(t *testing.T) {
+	flat := createCustomBounds(0, 100, 0, 400)
+	cube := createCustomBounds(0, 100, 0, 100)
+	cube.ZMin, cube.ZMax = floatPtr(0), floatPtr(100)
+
+	tests := []struct {
+		name     string
+		strategy string
+		bounds   v1.WorldBounds
+		children int
+	}{
+		{name: "longest axis", strategy: v1.SplitStrategyLongestAxis, bounds: flat, children: 2},
+		{name: "quad", strategy: v1.SplitStrategyQuad, bounds: flat, children: 4},
+		{name: "quad without Y", strategy: v1.SplitStrategyQuad, bounds: v1.WorldBounds{XMin: 0, XMax: 10}, children: 2},
+		{name: "octree", strategy: v1.SplitStrategyOctree, bounds: cube, children: 8},
+		{name: "octree without Z", strategy: v1.SplitStrategyOctree, bounds: flat, children: 4},
+		{name: "player median", strategy: v1.SplitStrategyPlayerMedian, bounds: flat, children: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := NewSplitStrategy(tt.strategy)
+			if err != nil {
+				t.Fatalf("Failed to create split strategy: %v", err)
+			}
+
+			children := strategy.Split(tt.bounds, nil)
+			if len(children) != tt.children {
+				t.Fatalf("Expected %d children, got %d", tt.children, len(children))
+			}
+
+			total := 0.0
+			for _, child := range children {
+				if !child.IsValidBounds() {
+					t.Errorf("Child bounds are invalid: %+v", child)
+				}
+				total += boundsVolume(child)
+			}
+			if total != boundsVolume(tt.bounds) {
+				t.Errorf("Volume not conserved: parent=%f, children=%f", boundsVolume(tt.bounds), total)
+			}
+		})
+	}
+
+	if _, err := NewSplitStrategy("hexagonal"); err == nil {
+		t.Errorf("Expected an unknown split strategy to be rejected")
+	}
+}
+
+func TestLongestAxisSplit_CutsLongestSide(t *testing.T) {
+	children := LongestAxisSplit{}.Split(createCustomBounds(0, 100, 0, 400), nil)
+
+	if children[0].XMin != 0 || children[0].XMax != 100 || *children[0].YMax != 200 {
+		t.Errorf("Expected a cut across Y at 200, got %+v", children[0])
+	}
+	if *children[1].YMin != 200 || *children[1].YMax != 400 {
+		t.Errorf("Expected upper child to span Y 200-400, got %v-%v", *children[1].YMin, *children[1].YMax)
+	}
+}
+
+func TestPlayerMedianSplit_BalancesPopulation(t *testing.T) {
+	positions := []WorldPosition{{X: 10, Y: 5}, {X: 12, Y: 5}, {X: 14, Y: 5}, {X: 16, Y: 5}, {X: 90, Y: 5}}
+
+	children := PlayerMedianSplit{}.Split(createCustomBounds(0, 100, 0, 10), positions)
+	if children[0].XMax != 13 {
+		t.Errorf("Expected the cut between the middle players at X 13, got %f", children[0].XMax)
+	}
+
+	// Players spread along Y are cut across Y even though X is longer
+	vertical := []WorldPosition{{X: 50, Y: 1}, {X: 51, Y: 4}, {X: 52, Y: 6}, {X: 53, Y: 9}}
+	children = PlayerMedianSplit{}.Split(createCustomBounds(0, 100, 0, 10), vertical)
+	if children[0].XMax != 100 || *children[0].YMax != 5 {
+		t.Errorf("Expected a cut across Y at 5, got %+v", children[0])
+	}
+
+	// Without players it falls back to the midpoint
+	children = PlayerMedianSplit{}.Split(createCustomBounds(0, 100, 0, 10), nil)
+	if children[0].XMax != 50 {
+		t.Errorf("Expected the cut at the midpoint, got %f", children[0].XMax)
+	}
+}
+
+func TestSplitStrategies_BalanceClusteredPlayers(t *testing.T) {
+	bounds := createCustomBounds(0, 1000, 0, 1000)
+
+	// Most players crowd the lower-left corner, with a few stragglers elsewhere
+	positions := make([]WorldPosition, 0, 40)
+	for i := 0; i < 36; i++ {
+		positions = append(positions, WorldPosition{X: 50 + float64(i%6)*20, Y: 50 + float64(i/6)*20})
+	}
+	positions = append(positions,
+		WorldPosition{X: 900, Y: 100}, WorldPosition{X: 100, Y: 900},
+		WorldPosition{X: 900, Y: 900}, WorldPosition{X: 600, Y: 600})
+
+	for _, strategy := range []SplitStrategy{LongestAxisSplit{}, QuadSplit{}, PlayerMedianSplit{}} {
+		children := strategy.Split(bounds, positions)
+		populations := PredictChildPopulations(children, positions)
+
+		fair := len(positions) / len(children)
+		for i, count := range populations {
+			if count < fair-1 || count > fair+1 {
+				t.Errorf("%s: expected about %d players in child %d, got %v", strategy.Name(), fair, i, populations)
+				break
+			}
+		}
+	}
+
+	// A single tight cluster still leaves each child a usable share of the parent
+	cluster := []WorldPosition{{X: 1, Y: 500}, {X: 2, Y: 500}, {X: 3, Y: 500}, {X: 4, Y: 500}}
+	children := LongestAxisSplit{}.Split(bounds, cluster)
+	if children[0].XMax < 100 {
+		t.Errorf("Expected the cut to stay clear of the edge, got X %f", children[0].XMax)
+	}
+}
+
+func TestCellManager_SplitRecordsChildPopulations(t *testing.T) {
+	manager := NewCellManagerWithCooldown(time.Millisecond).(*DefaultCellManager)
+	defer manager.Shutdown()
+
+	spec := CellSpec{
+		ID:         "clustered-cell",
+		Boundaries: createTestBounds(),
+		Capacity:   CellCapacity{MaxPlayers: 100},
+	}
+	if _, err := manager.CreateCell(spec); err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+
+	// Wait for cell to be ready
+	time.Sleep(time.Millisecond * 150)
+
+	// Nine players in the west and one far east; a midpoint cut would
+	// leave nine players in one child
+	xs := []float64{110, 120, 130, 140, 150, 160, 170, 180, 190, 950}
+	for i, x := range xs {
+		player := &PlayerState{ID: PlayerID(fmt.Sprintf("player-%d", i)), Position: WorldPosition{X: x, Y: 500}}
+		if err := manager.AddPlayer(spec.ID, player); err != nil {
+			t.Fatalf("Failed to add player: %v", err)
+		}
+	}
+
+	children, err := manager.ManualSplitCell(spec.ID, nil)
+	if err != nil {
+		t.Fatalf("Failed to split cell: %v", err)
+	}
+	for _, child := range children {
+		if count := child.GetState().PlayerCount; count != 5 {
+			t.Errorf("Expected 5 players in %s, got %d", child.GetState().ID, count)
+		}
+	}
+
+	var splitEvent *CellEvent
+	for _, event := range manager.GetEvents() {
+		if event.Type == CellEventSplit && event.CellID == spec.ID {
+			splitEvent = &event
+		}
+	}
+	if splitEvent == nil {
+		t.Fatalf("Expected a split event")
+	}
+
+	predicted, _ := splitEvent.Metadata["predicted_child_players"].([]int)
+	actual, _ := splitEvent.Metadata["actual_child_players"].([]int)
+	if len(predicted) != 2 || predicted[0] != 5 || predicted[1] != 5 {
+		t.Errorf("Expected predicted populations [5 5], got %v", splitEvent.Metadata["predicted_child_players"])
+	}
+	if len(actual) != 2 || actual[0] != predicted[0] || actual[1] != predicted[1] {
+		t.Errorf("Expected actual populations to match the prediction, got %v", splitEvent.Metadata["actual_child_players"])
+	}
+}
+
+func TestCellManager_SplitCellWithQuadStrategy(t *testing.T) {
+	manager := NewCellManagerWithCooldown(time.Millisecond).(*DefaultCellManager)
+	defer manager.Shutdown()
+	manager.SetSplitStrategy(QuadSplit{})
+
+	spec := CellSpec{
+		ID:         "quad-cell",
+		Boundaries: createTestBounds(),
+		Capacity:   CellCapacity{MaxPlayers: 100},
+	}
+	if _, err := manager.CreateCell(spec); err != nil {
+		t.Fatalf("Failed to create cell: %v", err)
+	}
+
+	// Wait for cell to be ready
+	time.Sleep(time.Millisecond * 150)
+
+	corners := []WorldPosition{{X: 100, Y: 100}, {X: 900, Y: 100}, {X: 100, Y: 900}, {X: 900, Y: 900}}
+	for i, pos := range corners {
+		player := &PlayerState{ID: PlayerID(string(rune('a' + i))), Position: pos}
+		if err := manager.AddPlayer(spec.ID, player); err != nil {
+			t.Fatalf("Failed to add player: %v", err)
+		}
+	}
+
+	children, err := manager.ManualSplitCell(spec.ID, nil)
+	if err != nil {
+		t.Fatalf("Failed to split cell: %v", err)
+	}
+	if len(children) != 4 {
+		t.Fatalf("Expected 4 children, got %d", len(children))
+	}
+
+	for _, child := range children {
+		state := child.GetState()
+		if state.PlayerCount != 1 {
+			t.Errorf("Expected one player in each quadrant, %s has %d", state.ID, state.PlayerCount)
+		}
+		if len(state.SiblingIDs) != 3 {
+			t.Errorf("Expected 3 siblings for %s, got %d", state.ID, len(state.SiblingIDs))
+		}
+	}
+}
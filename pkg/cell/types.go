@@ -18,6 +18,11 @@ type WorldPosition struct {
 	Y float64 `json:"y"`
 }
 
+// isFinite reports whether both coordinates are finite numbers
+func (p WorldPosition) isFinite() bool {
+	return isFinite(p.X) && isFinite(p.Y)
+}
+
 // PlayerState represents the state of a player within a cell
 type PlayerState struct {
 	ID       PlayerID      `json:"id"`
@@ -48,6 +53,10 @@ type CellState struct {
 	Generation int      `json:"generation"`           // Generation level (0 for root, 1 for first split, etc.)
 	SiblingIDs []CellID `json:"siblingIds,omitempty"` // IDs of sibling cells (from same parent)
 
+	// Parent this cell was split from, kept so the children can still be
+	// merged back after they are recovered from checkpoints
+	SplitParent *SplitParentState `json:"splitParent,omitempty"`
+
 	// Capacity and limits
 	Capacity CellCapacity `json:"capacity"`
 
@@ -67,6 +76,14 @@ type CellState struct {
 	Ready bool   `json:"ready"`
 }
 
+// SplitParentState describes a split cell well enough to recreate it when its
+// children merge. Its generation is one less than its children's.
+type SplitParentState struct {
+	Spec       CellSpec `json:"spec"`
+	ParentID   *CellID  `json:"parentId,omitempty"`
+	SiblingIDs []CellID `json:"siblingIds,omitempty"`
+}
+
 // HealthStatus represents the health status of a cell
 type HealthStatus struct {
 	Healthy        bool          `json:"healthy"`
@@ -96,6 +113,15 @@ type AOIFilter interface {
 
 	// GetNeighborCells returns the neighboring cells that might have relevant players
 	GetNeighborCells(position WorldPosition) []CellID
+
+	// UpdatePlayer inserts or moves a player in the filter's spatial index
+	UpdatePlayer(playerID PlayerID, position WorldPosition)
+
+	// RemovePlayer removes a player from the filter's spatial index
+	RemovePlayer(playerID PlayerID)
+
+	// SetCellMesh attaches the filter to the live cell mesh on behalf of a cell
+	SetCellMesh(cellID CellID, mesh *CellMesh)
 }
 
 // CellManager interface defines the core cell management operations
@@ -116,7 +142,7 @@ type CellManager interface {
 
 	// State management
 	Checkpoint(cellID CellID) error
-	Restore(cellID CellID, checkpoint []byte) error
+	Restore(cellID CellID, checkpoint []byte, deltas ...[]byte) error
 
 	// Scaling operations
 	SplitCell(cellID CellID, splitThreshold float64) ([]*Cell, error)
@@ -149,10 +175,13 @@ type CellEventType string
 const (
 	CellEventCreated     CellEventType = "CellCreated"
 	CellEventSplit       CellEventType = "CellSplit"
+	CellEventSplitDenied CellEventType = "CellSplitDenied"
+	CellEventOscillating CellEventType = "CellOscillating"
 	CellEventMerged      CellEventType = "CellMerged"
 	CellEventTerminated  CellEventType = "CellTerminated"
 	CellEventPlayerAdded CellEventType = "PlayerAdded"
 	CellEventPlayerMoved CellEventType = "PlayerMoved"
+	CellEventRestored    CellEventType = "CellRestored"
 )
 
 // CellEvent represents an event that occurred in the cell system
@@ -166,6 +195,23 @@ type CellEvent struct {
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// PlayerReassignment records a player moved to another cell by a split or merge
+type PlayerReassignment struct {
+	PlayerID   PlayerID      `json:"playerId"`
+	FromCellID CellID        `json:"fromCellId"`
+	ToCellID   CellID        `json:"toCellId"`
+	Position   WorldPosition `json:"position"`
+	Reason     string        `json:"reason"`
+	Timestamp  time.Time     `json:"timestamp"`
+}
+
+// HandoffTarget is a cell that takes players over from a split cell, and the
+// space it covers
+type HandoffTarget struct {
+	ID         CellID         `json:"id"`
+	Boundaries v1.WorldBounds `json:"boundaries"`
+}
+
 // CellMetrics defines metrics exposed by cells
 type CellMetrics struct {
 	// Basic metrics
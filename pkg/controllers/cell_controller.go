@@ -0,0 +1,863 @@
+/*
+Copyright 2024 FleetForge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	fleetforgev1 "github.com/astrosteveo/fleetforge/api/v1"
+	"github.com/astrosteveo/fleetforge/pkg/cell"
+)
+
+// CellReconciler reconciles a Cell object
+type CellReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
+	// StatusScraper queries cell pods for their player counts. Defaults to
+	// querying the /status endpoint on the pod's health port.
+	StatusScraper CellStatusScraper
+	// HandoffRequester asks a split or merged cell's pod to hand its players off
+	// to the cells taking over its space. Defaults to posting them to the pod's
+	// /handoff endpoint.
+	HandoffRequester CellHandoffRequester
+}
+
+const (
+	// cellStatusInterval is how often a cell's pod is queried for its status
+	cellStatusInterval = 30 * time.Second
+
+	// splitDrainInterval is how often a split or merged cell's pod is asked
+	// again to hand off the players it still serves
+	splitDrainInterval = 5 * time.Second
+	// splitDrainTimeout bounds how long a split or merged cell's pod is kept for
+	// the cells taking over its space to start and its players to move, counted
+	// from the split or merge
+	splitDrainTimeout = 5 * time.Minute
+
+	// handoffTokenKey is the key of the token in a cell's handoff secret
+	handoffTokenKey = "token"
+)
+
+//+kubebuilder:rbac:groups=fleetforge.io,resources=cells,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=fleetforge.io,resources=cells/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=fleetforge.io,resources=cells/finalizers,verbs=update
+//+kubebuilder:rbac:groups=fleetforge.io,resources=worldspecs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile reconciles a Cell resource into its Deployment and Service
+func (r *CellReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("cell", req.NamespacedName)
+
+	cellObj := &fleetforgev1.Cell{}
+	if err := r.Get(ctx, req.NamespacedName, cellObj); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("Cell resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get Cell")
+		return ctrl.Result{}, err
+	}
+
+	// The cell's pods are configured from its world
+	worldSpec := &fleetforgev1.WorldSpec{}
+	worldKey := client.ObjectKey{Namespace: cellObj.Namespace, Name: cellObj.Spec.WorldRef}
+	if err := r.Get(ctx, worldKey, worldSpec); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("WorldSpec for cell not found, retrying later", "world", cellObj.Spec.WorldRef)
+			return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+		}
+		log.Error(err, "Failed to get WorldSpec", "world", cellObj.Spec.WorldRef)
+		return ctrl.Result{}, err
+	}
+
+	// A split cell's space is served by its children, and a merged cell's by its
+	// parent, so it runs no pods once its players have moved to them
+	if cellObj.Spec.IsSplit() || cellObj.Spec.IsMerged() {
+		return r.reconcileRetiredCell(ctx, cellObj, log)
+	}
+
+	if worldSpec.Spec.Persistence.Enabled {
+		volumeResult, err := r.reconcileCheckpointVolume(ctx, cellObj, worldSpec, log)
+		if err != nil {
+			r.Recorder.Event(cellObj, corev1.EventTypeWarning, "CellCheckpointVolumeFailed",
+				fmt.Sprintf("Failed to reconcile checkpoint volume for cell %s: %v", cellObj.Name, err))
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile checkpoint volume for cell %s: %w", cellObj.Name, err)
+		}
+		if volumeResult == controllerutil.OperationResultCreated {
+			r.Recorder.Event(cellObj, corev1.EventTypeNormal, "CellCheckpointVolumeCreated",
+				fmt.Sprintf("Created checkpoint volume for cell %s", cellObj.Name))
+		}
+	}
+
+	secretResult, err := r.reconcileHandoffSecret(ctx, cellObj, worldSpec, log)
+	if err != nil {
+		r.Recorder.Event(cellObj, corev1.EventTypeWarning, "CellHandoffSecretFailed",
+			fmt.Sprintf("Failed to reconcile handoff secret for cell %s: %v", cellObj.Name, err))
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile handoff secret for cell %s: %w", cellObj.Name, err)
+	}
+	if secretResult == controllerutil.OperationResultCreated {
+		r.Recorder.Event(cellObj, corev1.EventTypeNormal, "CellHandoffSecretCreated",
+			fmt.Sprintf("Created handoff secret for cell %s", cellObj.Name))
+	}
+
+	deploymentResult, err := r.reconcileCellDeployment(ctx, cellObj, worldSpec, log)
+	if err != nil {
+		r.Recorder.Event(cellObj, corev1.EventTypeWarning, "CellDeploymentFailed",
+			fmt.Sprintf("Failed to reconcile deployment for cell %s: %v", cellObj.Name, err))
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile cell deployment %s: %w", cellObj.Name, err)
+	}
+	if deploymentResult == controllerutil.OperationResultCreated {
+		r.Recorder.Event(cellObj, corev1.EventTypeNormal, "CellDeploymentCreated",
+			fmt.Sprintf("Created deployment for cell %s", cellObj.Name))
+	} else if deploymentResult == controllerutil.OperationResultUpdated {
+		r.Recorder.Event(cellObj, corev1.EventTypeNormal, "CellDeploymentUpdated",
+			fmt.Sprintf("Updated deployment for cell %s", cellObj.Name))
+	}
+
+	serviceResult, err := r.reconcileCellService(ctx, cellObj, worldSpec, log)
+	if err != nil {
+		r.Recorder.Event(cellObj, corev1.EventTypeWarning, "CellServiceFailed",
+			fmt.Sprintf("Failed to reconcile service for cell %s: %v", cellObj.Name, err))
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile cell service %s: %w", cellObj.Name, err)
+	}
+	if serviceResult == controllerutil.OperationResultCreated {
+		r.Recorder.Event(cellObj, corev1.EventTypeNormal, "CellServiceCreated",
+			fmt.Sprintf("Created service for cell %s", cellObj.Name))
+	}
+
+	if err := r.updateCellStatus(ctx, cellObj, log); err != nil {
+		log.Error(err, "Failed to update Cell status")
+		return ctrl.Result{}, err
+	}
+
+	// Poll for pod health and player count changes that do not touch the deployment
+	return ctrl.Result{RequeueAfter: cellStatusInterval}, nil
+}
+
+// reconcileCellDeployment creates or updates a deployment for a cell
+func (r *CellReconciler) reconcileCellDeployment(ctx context.Context, cellObj *fleetforgev1.Cell, worldSpec *fleetforgev1.WorldSpec, log logr.Logger) (controllerutil.OperationResult, error) {
+	cellID := cellObj.Name
+	bounds := cellObj.Spec.Boundaries
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cellID,
+			Namespace: cellObj.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		// Set owner reference
+		if err := controllerutil.SetControllerReference(cellObj, deployment, r.Scheme); err != nil {
+			return err
+		}
+		deployment.Labels = cellLabels(worldSpec.Name, cellID)
+
+		// World settings are passed as args, so a WorldSpec change rolls the cell's pods
+		cellArgs := []string{fmt.Sprintf("--cell-id=%s", cellID)}
+		cellArgs = append(cellArgs, boundsArgs(bounds)...)
+		cellArgs = append(cellArgs, fmt.Sprintf("--max-players=%d", worldSpec.Spec.Capacity.MaxPlayersPerCell))
+		if worldSpec.Spec.TickRate > 0 {
+			cellArgs = append(cellArgs, fmt.Sprintf("--tick-rate=%d", worldSpec.Spec.TickRate))
+		}
+		cellArgs = append(cellArgs, scalingArgs(worldSpec.Spec.Scaling)...)
+
+		// Checkpoints are written to the cell's own claim, so they outlive the pod
+		// and the next pod recovers from them after a reschedule or rollout
+		var volumes []corev1.Volume
+		var volumeMounts []corev1.VolumeMount
+		strategy := appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}
+		if persistence := worldSpec.Spec.Persistence; persistence.Enabled {
+			cellArgs = append(cellArgs, fmt.Sprintf("--checkpoint-dir=%s", cellCheckpointDir))
+			if persistence.CheckpointInterval != "" {
+				cellArgs = append(cellArgs, fmt.Sprintf("--checkpoint-interval=%s", persistence.CheckpointInterval))
+			}
+			if persistence.RetentionPeriod != "" {
+				cellArgs = append(cellArgs, fmt.Sprintf("--retention-period=%s", persistence.RetentionPeriod))
+			}
+			if persistence.StateCodec != "" {
+				cellArgs = append(cellArgs, fmt.Sprintf("--state-codec=%s", persistence.StateCodec))
+			}
+			volumes = []corev1.Volume{
+				{
+					Name: "checkpoints",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: checkpointClaimName(cellID),
+						},
+					},
+				},
+			}
+			volumeMounts = []corev1.VolumeMount{
+				{
+					Name:      "checkpoints",
+					MountPath: cellCheckpointDir,
+				},
+			}
+			// The claim is ReadWriteOnce, so the old pod must release it before
+			// its replacement starts
+			strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+		}
+
+		// Configure deployment spec
+		deployment.Spec = appsv1.DeploymentSpec{
+			Replicas: int32Ptr(1),
+			Strategy: strategy,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: cellLabels(worldSpec.Name, cellID),
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: cellLabels(worldSpec.Name, cellID),
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "cell-simulator",
+							Image: worldSpec.Spec.GameServerImage,
+							Args:  cellArgs,
+							// Only the controller may ask the pod to hand its players off
+							Env: []corev1.EnvVar{
+								{
+									Name: "HANDOFF_TOKEN",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{Name: handoffSecretName(cellID)},
+											Key:                  handoffTokenKey,
+										},
+									},
+								},
+							},
+							Resources: corev1.ResourceRequirements{
+								Limits: corev1.ResourceList{
+									corev1.ResourceCPU:    r.parseResourceQuantity(worldSpec.Spec.Capacity.CPULimitPerCell, "cpu"),
+									corev1.ResourceMemory: r.parseResourceQuantity(worldSpec.Spec.Capacity.MemoryLimitPerCell, "memory"),
+								},
+								Requests: corev1.ResourceList{
+									corev1.ResourceCPU:    r.parseResourceQuantity(getStringValue(worldSpec.Spec.Capacity.CPURequestPerCell, "500m"), "cpu"),
+									corev1.ResourceMemory: r.parseResourceQuantity(getStringValue(worldSpec.Spec.Capacity.MemoryRequestPerCell, "1Gi"), "memory"),
+								},
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "health",
+									ContainerPort: 8081,
+									Protocol:      corev1.ProtocolTCP,
+								},
+								{
+									Name:          "metrics",
+									ContainerPort: 8080,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							LivenessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{
+										Path: "/health",
+										Port: intstr.FromString("health"),
+									},
+								},
+								InitialDelaySeconds: 30,
+								PeriodSeconds:       10,
+							},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{
+										Path: "/ready",
+										Port: intstr.FromString("health"),
+									},
+								},
+								InitialDelaySeconds: 5,
+								PeriodSeconds:       5,
+							},
+							VolumeMounts: volumeMounts,
+						},
+					},
+					Volumes: volumes,
+				},
+			},
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		log.Error(err, "Failed to create or update deployment", "deployment", cellID)
+		return controllerutil.OperationResultNone, err
+	}
+
+	log.Info("Successfully reconciled deployment", "deployment", cellID, "operation", result)
+	return result, nil
+}
+
+// reconcileCheckpointVolume creates the persistent volume claim holding a
+// cell's checkpoints. The claim is owned by the Cell, so it is kept across pod
+// restarts and rollouts and only removed along with the cell.
+func (r *CellReconciler) reconcileCheckpointVolume(ctx context.Context, cellObj *fleetforgev1.Cell, worldSpec *fleetforgev1.WorldSpec, log logr.Logger) (controllerutil.OperationResult, error) {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      checkpointClaimName(cellObj.Name),
+			Namespace: cellObj.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, claim, func() error {
+		if err := controllerutil.SetControllerReference(cellObj, claim, r.Scheme); err != nil {
+			return err
+		}
+		claim.Labels = cellLabels(worldSpec.Name, cellObj.Name)
+
+		// A claim's spec is immutable once it is bound
+		if claim.CreationTimestamp.IsZero() {
+			claim.Spec = corev1.PersistentVolumeClaimSpec{
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				StorageClassName: worldSpec.Spec.Persistence.StorageClass,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(cellCheckpointStorage),
+					},
+				},
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		log.Error(err, "Failed to create or update checkpoint volume claim", "claim", claim.Name)
+		return controllerutil.OperationResultNone, err
+	}
+
+	log.Info("Successfully reconciled checkpoint volume claim", "claim", claim.Name, "operation", result)
+	return result, nil
+}
+
+// checkpointClaimName returns the name of a cell's checkpoint volume claim
+func checkpointClaimName(cellID string) string {
+	return cellID + "-checkpoints"
+}
+
+// reconcileHandoffSecret creates the secret holding the token a cell's pod
+// requires on handoff requests. The token is generated once, and the secret is
+// owned by the Cell so it is removed along with it.
+func (r *CellReconciler) reconcileHandoffSecret(ctx context.Context, cellObj *fleetforgev1.Cell, worldSpec *fleetforgev1.WorldSpec, log logr.Logger) (controllerutil.OperationResult, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      handoffSecretName(cellObj.Name),
+			Namespace: cellObj.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if err := controllerutil.SetControllerReference(cellObj, secret, r.Scheme); err != nil {
+			return err
+		}
+		secret.Labels = cellLabels(worldSpec.Name, cellObj.Name)
+
+		// Rotating the token would lock out the running pod until it restarts
+		if len(secret.Data[handoffTokenKey]) > 0 {
+			return nil
+		}
+		token := make([]byte, 32)
+		if _, err := rand.Read(token); err != nil {
+			return fmt.Errorf("failed to generate handoff token: %w", err)
+		}
+		secret.Data = map[string][]byte{handoffTokenKey: []byte(hex.EncodeToString(token))}
+		return nil
+	})
+
+	if err != nil {
+		log.Error(err, "Failed to create or update handoff secret", "secret", secret.Name)
+		return controllerutil.OperationResultNone, err
+	}
+
+	log.Info("Successfully reconciled handoff secret", "secret", secret.Name, "operation", result)
+	return result, nil
+}
+
+// handoffSecretName returns the name of a cell's handoff secret
+func handoffSecretName(cellID string) string {
+	return cellID + "-handoff"
+}
+
+// handoffToken returns the token a cell's pod requires on handoff requests
+func (r *CellReconciler) handoffToken(ctx context.Context, cellObj *fleetforgev1.Cell) (string, error) {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: cellObj.Namespace, Name: handoffSecretName(cellObj.Name)}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return "", fmt.Errorf("failed to get handoff secret of cell %s: %w", cellObj.Name, err)
+	}
+	token := string(secret.Data[handoffTokenKey])
+	if token == "" {
+		return "", fmt.Errorf("handoff secret of cell %s has no token", cellObj.Name)
+	}
+	return token, nil
+}
+
+// reconcileCellService creates or updates a service for a cell
+func (r *CellReconciler) reconcileCellService(ctx context.Context, cellObj *fleetforgev1.Cell, worldSpec *fleetforgev1.WorldSpec, log logr.Logger) (controllerutil.OperationResult, error) {
+	cellID := cellObj.Name
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cellID + "-service",
+			Namespace: cellObj.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+		// Set owner reference
+		if err := controllerutil.SetControllerReference(cellObj, service, r.Scheme); err != nil {
+			return err
+		}
+		service.Labels = cellLabels(worldSpec.Name, cellID)
+
+		// Configure service spec
+		service.Spec = corev1.ServiceSpec{
+			Selector: map[string]string{
+				"app":     "fleetforge-cell",
+				"cell-id": cellID,
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "health",
+					Port:       8081,
+					TargetPort: intstr.FromString("health"),
+					Protocol:   corev1.ProtocolTCP,
+				},
+				{
+					Name:       "metrics",
+					Port:       8080,
+					TargetPort: intstr.FromString("metrics"),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Type: corev1.ServiceTypeClusterIP,
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		log.Error(err, "Failed to create or update service", "service", cellID+"-service")
+		return controllerutil.OperationResultNone, err
+	}
+
+	log.Info("Successfully reconciled service", "service", cellID+"-service", "operation", result)
+	return result, nil
+}
+
+// updateCellStatus reports the cell's phase, pod health and player count in its
+// status. When the pod cannot be queried the last reported player count is kept
+// and marked stale.
+func (r *CellReconciler) updateCellStatus(ctx context.Context, cellObj *fleetforgev1.Cell, log logr.Logger) error {
+	status := fleetforgev1.CellObservedStatus{
+		Phase:           "Pending",
+		Health:          "Pending",
+		Players:         cellObj.Status.Players,
+		LastHeartbeat:   cellObj.Status.LastHeartbeat,
+		Stale:           cellObj.Status.LastHeartbeat != nil,
+		SplitRequested:  cellObj.Status.SplitRequested,
+		PlayerPositions: cellObj.Status.PlayerPositions,
+	}
+
+	deployment := &appsv1.Deployment{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: cellObj.Namespace, Name: cellObj.Name}, deployment)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get deployment: %w", err)
+	}
+	if err == nil && deployment.Status.ReadyReplicas > 0 {
+		status.Phase = "Running"
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(cellObj.Namespace), client.MatchingLabels{"cell-id": cellObj.Name}); err != nil {
+		log.Error(err, "Failed to list pods, continuing with deployment-only status")
+	}
+	if len(podList.Items) > 0 {
+		pod := &podList.Items[0]
+		status.PodName = pod.Name
+		status.Health = podHealthStatus(pod)
+		if pod.Status.Phase == corev1.PodRunning {
+			report, err := r.statusScraper().ScrapeCellStatus(ctx, pod)
+			if err != nil {
+				log.Info("Failed to query cell pod status, keeping last reported players", "pod", pod.Name, "error", err.Error())
+			} else {
+				status.Players = report.CurrentPlayers
+				status.LastHeartbeat = &metav1.Time{Time: time.Now()}
+				status.Stale = false
+				status.SplitRequested = report.SplitRequested
+				status.PlayerPositions = report.PlayerPositions
+			}
+		}
+	}
+
+	cellObj.Status = status
+	return r.Status().Update(ctx, cellObj)
+}
+
+// reconcileRetiredCell retires the pod of a split or merged cell. The pod keeps
+// serving its players until every cell taking over its space is running, is
+// then asked to hand them off, and is deleted once it is empty or
+// splitDrainTimeout after the split or merge. A merged cell is deleted along
+// with its pod.
+func (r *CellReconciler) reconcileRetiredCell(ctx context.Context, cellObj *fleetforgev1.Cell, log logr.Logger) (ctrl.Result, error) {
+	pod, err := r.runningPod(ctx, cellObj)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if pod != nil {
+		draining, err := r.drainRetiredCell(ctx, cellObj, pod, log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if draining {
+			cellObj.Status.Phase = "Draining"
+			cellObj.Status.PodName = pod.Name
+			cellObj.Status.SplitRequested = false
+			if err := r.Status().Update(ctx, cellObj); err != nil {
+				log.Error(err, "Failed to update Cell status")
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: splitDrainInterval}, nil
+		}
+	}
+
+	if err := r.deleteCellWorkload(ctx, cellObj, log); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if cellObj.Spec.IsMerged() {
+		if err := r.Delete(ctx, cellObj); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete merged cell %s: %w", cellObj.Name, err)
+		}
+		log.Info("Deleted merged cell", "mergedInto", cellObj.Spec.MergedInto)
+		return ctrl.Result{}, nil
+	}
+
+	cellObj.Status = fleetforgev1.CellObservedStatus{Phase: "Split"}
+	if err := r.Status().Update(ctx, cellObj); err != nil {
+		log.Error(err, "Failed to update Cell status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// drainRetiredCell hands a split or merged cell's players off to the cells
+// taking over its space once they are all running, and reports whether its pod
+// must keep serving
+func (r *CellReconciler) drainRetiredCell(ctx context.Context, cellObj *fleetforgev1.Cell, pod *corev1.Pod, log logr.Logger) (bool, error) {
+	targetIDs := cellObj.Spec.ChildIDs
+	if cellObj.Spec.IsMerged() {
+		targetIDs = []string{cellObj.Spec.MergedInto}
+	}
+	targets, retiredAt, ready, err := r.handoffTargets(ctx, cellObj.Namespace, targetIDs)
+	if err != nil {
+		return false, err
+	}
+
+	if !retiredAt.IsZero() && time.Since(retiredAt) > splitDrainTimeout {
+		log.Info("Handoff of retired cell timed out, removing its pod", "pod", pod.Name, "players", cellObj.Status.Players)
+		r.Recorder.Event(cellObj, corev1.EventTypeWarning, "CellHandoffTimedOut",
+			fmt.Sprintf("Players of cell %s were not all handed off within %s", cellObj.Name, splitDrainTimeout))
+		return false, nil
+	}
+	if !ready {
+		log.Info("Waiting for the cells taking over the retired cell to run before handing off its players")
+		return true, nil
+	}
+
+	token, err := r.handoffToken(ctx, cellObj)
+	if err != nil {
+		log.Info("Cannot authenticate handoff of retired cell, retrying", "pod", pod.Name, "error", err.Error())
+		return true, nil
+	}
+	report, err := r.handoffRequester().RequestHandoff(ctx, pod, token, targets)
+	if err != nil {
+		log.Info("Failed to hand off players of retired cell, retrying", "pod", pod.Name, "error", err.Error())
+		return true, nil
+	}
+	cellObj.Status.Players = report.CurrentPlayers
+	if report.CurrentPlayers > 0 {
+		return true, nil
+	}
+
+	r.Recorder.Event(cellObj, corev1.EventTypeNormal, "CellPlayersHandedOff",
+		fmt.Sprintf("Players of cell %s were handed off to %s", cellObj.Name, strings.Join(targetIDs, ", ")))
+	return false, nil
+}
+
+// handoffTargets returns the cells taking over a retired cell's space as
+// handoff targets, when the earliest of them took over, and whether they are
+// all running. A target that has itself been merged is replaced by the cell it
+// was merged into.
+func (r *CellReconciler) handoffTargets(ctx context.Context, namespace string, targetIDs []string) ([]cell.HandoffTarget, time.Time, bool, error) {
+	targets := make([]cell.HandoffTarget, 0, len(targetIDs))
+	var retiredAt time.Time
+	ready := true
+	for _, targetID := range targetIDs {
+		target, err := r.liveTarget(ctx, namespace, targetID)
+		if err != nil {
+			return nil, time.Time{}, false, err
+		}
+		if target == nil {
+			ready = false
+			continue
+		}
+
+		if at := lineageChangedAt(target); !at.IsZero() && (retiredAt.IsZero() || at.Before(retiredAt)) {
+			retiredAt = at
+		}
+		if target.Status.Phase != "Running" && !target.Spec.IsSplit() {
+			ready = false
+		}
+		targets = append(targets, cell.HandoffTarget{
+			ID:         cell.CellID(target.Name),
+			Boundaries: *target.Spec.Boundaries.DeepCopy(),
+		})
+	}
+	return targets, retiredAt, ready, nil
+}
+
+// liveTarget returns the cell serving a handoff target's space, following
+// merges, or nil when it does not exist (yet)
+func (r *CellReconciler) liveTarget(ctx context.Context, namespace, targetID string) (*fleetforgev1.Cell, error) {
+	visited := make(map[string]bool)
+	for {
+		target := &fleetforgev1.Cell{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: targetID}, target); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to get cell %s: %w", targetID, err)
+		}
+		if !target.Spec.IsMerged() || visited[target.Name] {
+			return target, nil
+		}
+		visited[target.Name] = true
+		targetID = target.Spec.MergedInto
+	}
+}
+
+// runningPod returns the cell's running pod, or nil when it has none
+func (r *CellReconciler) runningPod(ctx context.Context, cellObj *fleetforgev1.Cell) (*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList, client.InNamespace(cellObj.Namespace), client.MatchingLabels{"cell-id": cellObj.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for i := range podList.Items {
+		if pod := &podList.Items[i]; pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			return pod, nil
+		}
+	}
+	return nil, nil
+}
+
+// handoffRequester returns the client used to ask split cells' pods for handoffs
+func (r *CellReconciler) handoffRequester() CellHandoffRequester {
+	if r.HandoffRequester == nil {
+		return NewHTTPCellStatusScraper()
+	}
+	return r.HandoffRequester
+}
+
+// statusScraper returns the scraper used to query cell pods
+func (r *CellReconciler) statusScraper() CellStatusScraper {
+	if r.StatusScraper == nil {
+		return NewHTTPCellStatusScraper()
+	}
+	return r.StatusScraper
+}
+
+// deleteCellWorkload deletes a retired cell's deployment, service, handoff
+// secret and checkpoint volume claim
+func (r *CellReconciler) deleteCellWorkload(ctx context.Context, cellObj *fleetforgev1.Cell, log logr.Logger) error {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: cellObj.Name, Namespace: cellObj.Namespace},
+	}
+	if err := r.Delete(ctx, deployment); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete deployment %s: %w", deployment.Name, err)
+	} else if err == nil {
+		log.Info("Deleted deployment of retired cell", "deployment", deployment.Name)
+		r.Recorder.Event(cellObj, corev1.EventTypeNormal, "CellDeploymentDeleted",
+			fmt.Sprintf("Deleted deployment for retired cell %s", cellObj.Name))
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: cellObj.Name + "-service", Namespace: cellObj.Namespace},
+	}
+	if err := r.Delete(ctx, service); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete service %s: %w", service.Name, err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: handoffSecretName(cellObj.Name), Namespace: cellObj.Namespace},
+	}
+	if err := r.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete handoff secret %s: %w", secret.Name, err)
+	}
+
+	// The cell's players now live in the cells that took over its space, so its
+	// checkpoints must not be restored if it serves its space again
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: checkpointClaimName(cellObj.Name), Namespace: cellObj.Namespace},
+	}
+	if err := r.Delete(ctx, claim); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete checkpoint volume claim %s: %w", claim.Name, err)
+	}
+	return nil
+}
+
+// cellsForWorld maps a WorldSpec to its cells, so that changes to the world's
+// settings are rolled out to every cell's pods
+func (r *CellReconciler) cellsForWorld(ctx context.Context, obj client.Object) []reconcile.Request {
+	cellList := &fleetforgev1.CellList{}
+	if err := r.List(ctx, cellList, client.InNamespace(obj.GetNamespace()), client.MatchingLabels{"world": obj.GetName()}); err != nil {
+		r.Log.Error(err, "Failed to list cells of world", "world", obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(cellList.Items))
+	for _, cellObj := range cellList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{Namespace: cellObj.Namespace, Name: cellObj.Name},
+		})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CellReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&fleetforgev1.Cell{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
+		Owns(&corev1.Secret{}).
+		Watches(&fleetforgev1.WorldSpec{},
+			handler.EnqueueRequestsFromMapFunc(r.cellsForWorld),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// boundsArgs returns the cell-simulator flags for a cell's bounds. Y and Z are
+// only passed when the cell has them.
+func boundsArgs(bounds fleetforgev1.WorldBounds) []string {
+	args := []string{
+		fmt.Sprintf("--x-min=%f", bounds.XMin),
+		fmt.Sprintf("--x-max=%f", bounds.XMax),
+	}
+	if bounds.YMin != nil && bounds.YMax != nil {
+		args = append(args,
+			fmt.Sprintf("--y-min=%f", *bounds.YMin),
+			fmt.Sprintf("--y-max=%f", *bounds.YMax),
+		)
+	}
+	if bounds.ZMin != nil && bounds.ZMax != nil {
+		args = append(args,
+			fmt.Sprintf("--z-min=%f", *bounds.ZMin),
+			fmt.Sprintf("--z-max=%f", *bounds.ZMax),
+		)
+	}
+	return args
+}
+
+// cellLabels returns the labels identifying a cell's resources and pods
+func cellLabels(worldName, cellID string) map[string]string {
+	return map[string]string{
+		"app":     "fleetforge-cell",
+		"cell-id": cellID,
+		"world":   worldName,
+	}
+}
+
+// parseResourceQuantity parses a resource quantity string, logs errors, and uses contextually appropriate defaults.
+func (r *CellReconciler) parseResourceQuantity(s string, resourceType string) resource.Quantity {
+	qty, err := resource.ParseQuantity(s)
+	if err != nil {
+		var defaultVal string
+		switch resourceType {
+		case "cpu":
+			defaultVal = "100m"
+		case "memory":
+			defaultVal = "128Mi"
+		default:
+			defaultVal = "100m"
+		}
+		r.Log.Error(err, "Failed to parse resource quantity", "value", s, "resourceType", resourceType, "usingDefault", defaultVal)
+		return resource.MustParse(defaultVal)
+	}
+	return qty
+}
+
+// podHealthStatus determines health status based on pod conditions
+func podHealthStatus(pod *corev1.Pod) string {
+	// Check if pod is running and ready
+	if pod.Status.Phase == corev1.PodRunning {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady {
+				if condition.Status == corev1.ConditionTrue {
+					return "Healthy"
+				} else {
+					return "NotReady"
+				}
+			}
+		}
+		return "Starting"
+	} else if pod.Status.Phase == corev1.PodPending {
+		// Check if it's stuck in pending due to scheduling issues
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+				return "SchedulingFailed"
+			}
+		}
+		return "Pending"
+	} else if pod.Status.Phase == corev1.PodFailed {
+		return "Failed"
+	} else if pod.Status.Phase == corev1.PodSucceeded {
+		return "Completed"
+	}
+
+	return "Unknown"
+}
@@ -0,0 +1,786 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetforgev1 "github.com/astrosteveo/fleetforge/api/v1"
+	"github.com/astrosteveo/fleetforge/pkg/cell"
+)
+
+func TestCellReconciler_Reconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	yMin := -1000.0
+	yMax := 1000.0
+	worldSpec := &fleetforgev1.WorldSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-world",
+			Namespace: "default",
+		},
+		Spec: fleetforgev1.WorldSpecSpec{
+			Topology: fleetforgev1.WorldTopology{
+				InitialCells: 1,
+				WorldBoundaries: fleetforgev1.WorldBounds{
+					XMin: -1000.0,
+					XMax: 1000.0,
+					YMin: &yMin,
+					YMax: &yMax,
+				},
+			},
+			Capacity: fleetforgev1.CellCapacity{
+				MaxPlayersPerCell:  100,
+				CPULimitPerCell:    "1000m",
+				MemoryLimitPerCell: "2Gi",
+			},
+			GameServerImage: "fleetforge-cell:latest",
+			TickRate:        30,
+		},
+	}
+	cellObj := &fleetforgev1.Cell{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-world-cell-0",
+			Namespace: "default",
+			Labels:    cellLabels("test-world", "test-world-cell-0"),
+		},
+		Spec: fleetforgev1.CellSpec{
+			WorldRef:   "test-world",
+			Boundaries: worldSpec.Spec.Topology.WorldBoundaries,
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(worldSpec, cellObj).
+		WithStatusSubresource(&fleetforgev1.Cell{}).
+		Build()
+
+	reconciler := &CellReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "test-world-cell-0", Namespace: "default"}}
+
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	// The cell's deployment and service are owned by the cell
+	deployment := &appsv1.Deployment{}
+	if err := fakeClient.Get(ctx, req.NamespacedName, deployment); err != nil {
+		t.Fatalf("Expected a deployment for the cell: %v", err)
+	}
+	if owner := metav1.GetControllerOf(deployment); owner == nil || owner.Kind != "Cell" || owner.Name != "test-world-cell-0" {
+		t.Errorf("Expected the deployment to be controlled by its cell, got %+v", owner)
+	}
+	if deployment.Labels["world"] != "test-world" || deployment.Labels["cell-id"] != "test-world-cell-0" {
+		t.Errorf("Expected world and cell labels on the deployment, got %v", deployment.Labels)
+	}
+	args := deployment.Spec.Template.Spec.Containers[0].Args
+	if len(args) == 0 || args[0] != "--cell-id=test-world-cell-0" {
+		t.Errorf("Expected the cell ID as the first argument, got %v", args)
+	}
+	for _, want := range []string{"--y-min=-1000.000000", "--y-max=1000.000000", "--tick-rate=30"} {
+		if !containsArg(args, want) {
+			t.Errorf("Expected argument %s, got %v", want, args)
+		}
+	}
+
+	// The pod gets the token the controller authenticates handoffs with
+	secret := &corev1.Secret{}
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: "test-world-cell-0-handoff", Namespace: "default"}, secret); err != nil {
+		t.Fatalf("Expected a handoff secret for the cell: %v", err)
+	}
+	if len(secret.Data[handoffTokenKey]) == 0 {
+		t.Error("Expected the handoff secret to hold a token")
+	}
+	env := deployment.Spec.Template.Spec.Containers[0].Env
+	if len(env) != 1 || env[0].Name != "HANDOFF_TOKEN" || env[0].ValueFrom == nil ||
+		env[0].ValueFrom.SecretKeyRef == nil || env[0].ValueFrom.SecretKeyRef.Name != secret.Name {
+		t.Errorf("Expected the handoff token from the cell's secret in the pod env, got %+v", env)
+	}
+
+	// The world's cells are reconciled again when its settings change
+	requests := reconciler.cellsForWorld(ctx, worldSpec)
+	if len(requests) != 1 || requests[0].NamespacedName != req.NamespacedName {
+		t.Errorf("Expected the world to map to its cell, got %v", requests)
+	}
+
+	service := &corev1.Service{}
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: "test-world-cell-0-service", Namespace: "default"}, service); err != nil {
+		t.Fatalf("Expected a service for the cell: %v", err)
+	}
+
+	updated := &fleetforgev1.Cell{}
+	if err := fakeClient.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("Failed to get Cell: %v", err)
+	}
+	if updated.Status.Phase != "Pending" {
+		t.Errorf("Expected phase Pending before the deployment is ready, got %q", updated.Status.Phase)
+	}
+
+	// Once split, the cell's children serve its space and its pods are removed
+	updated.Spec.ChildIDs = []string{"test-world-cell-0-child-1", "test-world-cell-0-child-2"}
+	if err := fakeClient.Update(ctx, updated); err != nil {
+		t.Fatalf("Failed to split Cell: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	if err := fakeClient.Get(ctx, req.NamespacedName, &appsv1.Deployment{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the split cell's deployment to be deleted, got %v", err)
+	}
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: "test-world-cell-0-service", Namespace: "default"}, &corev1.Service{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the split cell's service to be deleted, got %v", err)
+	}
+	if err := fakeClient.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("Failed to get Cell: %v", err)
+	}
+	if updated.Status.Phase != "Split" {
+		t.Errorf("Expected phase Split, got %q", updated.Status.Phase)
+	}
+}
+
+func TestCellReconciler_CheckpointVolume(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	storageClass := "fast-ssd"
+	worldSpec := &fleetforgev1.WorldSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-world", Namespace: "default"},
+		Spec: fleetforgev1.WorldSpecSpec{
+			Topology: fleetforgev1.WorldTopology{
+				InitialCells:    1,
+				WorldBoundaries: fleetforgev1.WorldBounds{XMin: -1000.0, XMax: 1000.0},
+			},
+			Capacity: fleetforgev1.CellCapacity{
+				MaxPlayersPerCell:  100,
+				CPULimitPerCell:    "1000m",
+				MemoryLimitPerCell: "2Gi",
+			},
+			Persistence: fleetforgev1.PersistenceConfiguration{
+				Enabled:            true,
+				CheckpointInterval: "30s",
+				StorageClass:       &storageClass,
+			},
+			GameServerImage: "fleetforge-cell:latest",
+		},
+	}
+	cellObj := &fleetforgev1.Cell{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-world-cell-0",
+			Namespace: "default",
+			Labels:    cellLabels("test-world", "test-world-cell-0"),
+		},
+		Spec: fleetforgev1.CellSpec{
+			WorldRef:   "test-world",
+			Boundaries: worldSpec.Spec.Topology.WorldBoundaries,
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(worldSpec, cellObj).
+		WithStatusSubresource(&fleetforgev1.Cell{}).
+		Build()
+
+	reconciler := &CellReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: record.NewFakeRecorder(10),
+	}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "test-world-cell-0", Namespace: "default"}}
+	claimKey := client.ObjectKey{Name: "test-world-cell-0-checkpoints", Namespace: "default"}
+
+	// Reconciling twice leaves the one claim in place
+	for i := 0; i < 2; i++ {
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile failed: %v", err)
+		}
+	}
+
+	claim := &corev1.PersistentVolumeClaim{}
+	if err := fakeClient.Get(ctx, claimKey, claim); err != nil {
+		t.Fatalf("Expected a checkpoint volume claim for the cell: %v", err)
+	}
+	if owner := metav1.GetControllerOf(claim); owner == nil || owner.Kind != "Cell" || owner.Name != "test-world-cell-0" {
+		t.Errorf("Expected the claim to be controlled by its cell, got %+v", owner)
+	}
+	if claim.Spec.StorageClassName == nil || *claim.Spec.StorageClassName != storageClass {
+		t.Errorf("Expected storage class %s, got %v", storageClass, claim.Spec.StorageClassName)
+	}
+	if size := claim.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != cellCheckpointStorage {
+		t.Errorf("Expected a %s claim, got %s", cellCheckpointStorage, size.String())
+	}
+
+	// The pod mounts the claim, and a rollout stops the old pod before the new
+	// one needs the volume
+	deployment := &appsv1.Deployment{}
+	if err := fakeClient.Get(ctx, req.NamespacedName, deployment); err != nil {
+		t.Fatalf("Expected a deployment for the cell: %v", err)
+	}
+	volumes := deployment.Spec.Template.Spec.Volumes
+	if len(volumes) != 1 || volumes[0].PersistentVolumeClaim == nil || volumes[0].PersistentVolumeClaim.ClaimName != claimKey.Name {
+		t.Errorf("Expected the checkpoint claim to be mounted, got %+v", volumes)
+	}
+	if deployment.Spec.Strategy.Type != appsv1.RecreateDeploymentStrategyType {
+		t.Errorf("Expected the Recreate strategy, got %q", deployment.Spec.Strategy.Type)
+	}
+	if !containsArg(deployment.Spec.Template.Spec.Containers[0].Args, "--checkpoint-dir="+cellCheckpointDir) {
+		t.Errorf("Expected the checkpoint directory argument, got %v", deployment.Spec.Template.Spec.Containers[0].Args)
+	}
+
+	// A split cell's checkpoints are dropped with its pods
+	updated := &fleetforgev1.Cell{}
+	if err := fakeClient.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("Failed to get Cell: %v", err)
+	}
+	updated.Spec.ChildIDs = []string{"test-world-cell-0-child-1", "test-world-cell-0-child-2"}
+	if err := fakeClient.Update(ctx, updated); err != nil {
+		t.Fatalf("Failed to split Cell: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := fakeClient.Get(ctx, claimKey, &corev1.PersistentVolumeClaim{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the split cell's checkpoint claim to be deleted, got %v", err)
+	}
+}
+
+// fakeStatusScraper returns a fixed report, or an error when err is set
+type fakeStatusScraper struct {
+	report CellStatusReport
+	err    error
+}
+
+func (f *fakeStatusScraper) ScrapeCellStatus(ctx context.Context, pod *corev1.Pod) (*CellStatusReport, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	report := f.report
+	return &report, nil
+}
+
+// fakeHandoffRequester records the targets it is asked to hand players off to
+// and reports the players still left on the pod
+type fakeHandoffRequester struct {
+	players  int32
+	tokens   []string
+	requests [][]cell.HandoffTarget
+}
+
+func (f *fakeHandoffRequester) RequestHandoff(ctx context.Context, pod *corev1.Pod, token string, targets []cell.HandoffTarget) (*CellStatusReport, error) {
+	f.tokens = append(f.tokens, token)
+	f.requests = append(f.requests, targets)
+	return &CellStatusReport{ID: pod.Labels["cell-id"], CurrentPlayers: f.players}, nil
+}
+
+func TestCellReconciler_ReportsPlayers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	worldSpec := &fleetforgev1.WorldSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-world", Namespace: "default"},
+		Spec: fleetforgev1.WorldSpecSpec{
+			Topology: fleetforgev1.WorldTopology{
+				InitialCells:    1,
+				WorldBoundaries: fleetforgev1.WorldBounds{XMin: -1000.0, XMax: 1000.0},
+			},
+			Capacity: fleetforgev1.CellCapacity{
+				MaxPlayersPerCell:  100,
+				CPULimitPerCell:    "1000m",
+				MemoryLimitPerCell: "2Gi",
+			},
+			GameServerImage: "fleetforge-cell:latest",
+		},
+	}
+	cellObj := &fleetforgev1.Cell{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-world-cell-0", Namespace: "default"},
+		Spec: fleetforgev1.CellSpec{
+			WorldRef:   "test-world",
+			Boundaries: worldSpec.Spec.Topology.WorldBoundaries,
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-world-cell-0-abc",
+			Namespace: "default",
+			Labels:    cellLabels("test-world", "test-world-cell-0"),
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(worldSpec, cellObj, pod).
+		WithStatusSubresource(&fleetforgev1.Cell{}).
+		Build()
+
+	scraper := &fakeStatusScraper{report: CellStatusReport{
+		ID:              "test-world-cell-0",
+		CurrentPlayers:  42,
+		PlayerPositions: []fleetforgev1.PlayerPosition{{X: 10, Y: -20}},
+	}}
+	reconciler := &CellReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Log:           ctrl.Log.WithName("test"),
+		Recorder:      record.NewFakeRecorder(10),
+		StatusScraper: scraper,
+	}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "test-world-cell-0", Namespace: "default"}}
+
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	updated := &fleetforgev1.Cell{}
+	if err := fakeClient.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("Failed to get Cell: %v", err)
+	}
+	if updated.Status.Players != 42 {
+		t.Errorf("Expected 42 players from the pod's status, got %d", updated.Status.Players)
+	}
+	if updated.Status.LastHeartbeat == nil {
+		t.Error("Expected a heartbeat after a successful status query")
+	}
+	if updated.Status.Stale {
+		t.Error("Expected fresh status after a successful status query")
+	}
+	if !reflect.DeepEqual(updated.Status.PlayerPositions, scraper.report.PlayerPositions) {
+		t.Errorf("Expected the pod's player positions to be reported, got %v", updated.Status.PlayerPositions)
+	}
+
+	// A failed query keeps the last report but marks it stale
+	scraper.err = fmt.Errorf("connection refused")
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := fakeClient.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("Failed to get Cell: %v", err)
+	}
+	if updated.Status.Players != 42 {
+		t.Errorf("Expected the last reported 42 players to be kept, got %d", updated.Status.Players)
+	}
+	if !updated.Status.Stale {
+		t.Error("Expected status to be marked stale after a failed query")
+	}
+}
+
+func TestCellReconciler_DrainsSplitCell(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	worldSpec := &fleetforgev1.WorldSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-world", Namespace: "default"},
+		Spec: fleetforgev1.WorldSpecSpec{
+			Topology: fleetforgev1.WorldTopology{
+				InitialCells:    1,
+				WorldBoundaries: fleetforgev1.WorldBounds{XMin: -1000.0, XMax: 1000.0},
+			},
+			Capacity:        fleetforgev1.CellCapacity{MaxPlayersPerCell: 100},
+			GameServerImage: "fleetforge-cell:latest",
+		},
+	}
+	childIDs := []string{"test-world-cell-0-child-1", "test-world-cell-0-child-2"}
+	parent := &fleetforgev1.Cell{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-world-cell-0",
+			Namespace: "default",
+			Labels:    cellLabels("test-world", "test-world-cell-0"),
+		},
+		Spec: fleetforgev1.CellSpec{
+			WorldRef:   "test-world",
+			Boundaries: worldSpec.Spec.Topology.WorldBoundaries,
+			ChildIDs:   childIDs,
+		},
+		Status: fleetforgev1.CellObservedStatus{Phase: "Running", Players: 3, SplitRequested: true},
+	}
+	objects := []client.Object{
+		worldSpec,
+		parent,
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test-world-cell-0", Namespace: "default"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-world-cell-0-service", Namespace: "default"}},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-world-cell-0-handoff", Namespace: "default"},
+			Data:       map[string][]byte{handoffTokenKey: []byte("split-token")},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-world-cell-0-abc",
+				Namespace: "default",
+				Labels:    cellLabels("test-world", "test-world-cell-0"),
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+		},
+	}
+	for i, childID := range childIDs {
+		objects = append(objects, &fleetforgev1.Cell{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              childID,
+				Namespace:         "default",
+				Labels:            cellLabels("test-world", childID),
+				CreationTimestamp: metav1.Now(),
+			},
+			Spec: fleetforgev1.CellSpec{
+				WorldRef:   "test-world",
+				Boundaries: fleetforgev1.WorldBounds{XMin: -1000.0 + float64(i)*1000.0, XMax: float64(i) * 1000.0},
+				ParentID:   "test-world-cell-0",
+			},
+			Status: fleetforgev1.CellObservedStatus{Phase: "Pending"},
+		})
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&fleetforgev1.Cell{}).
+		Build()
+
+	requester := &fakeHandoffRequester{players: 3}
+	reconciler := &CellReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		Log:              ctrl.Log.WithName("test"),
+		Recorder:         record.NewFakeRecorder(10),
+		HandoffRequester: requester,
+	}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "test-world-cell-0", Namespace: "default"}}
+	reconcileParent := func() *fleetforgev1.Cell {
+		t.Helper()
+		result, err := reconciler.Reconcile(ctx, req)
+		if err != nil {
+			t.Fatalf("Reconcile failed: %v", err)
+		}
+		updated := &fleetforgev1.Cell{}
+		if err := fakeClient.Get(ctx, req.NamespacedName, updated); err != nil {
+			t.Fatalf("Failed to get Cell: %v", err)
+		}
+		if updated.Status.Phase == "Draining" && result.RequeueAfter != splitDrainInterval {
+			t.Errorf("Expected a draining cell to be requeued after %s, got %s", splitDrainInterval, result.RequeueAfter)
+		}
+		return updated
+	}
+
+	// The pod keeps its players until the children run
+	updated := reconcileParent()
+	if updated.Status.Phase != "Draining" || updated.Status.SplitRequested {
+		t.Errorf("Expected the split cell to drain without a split request, got %+v", updated.Status)
+	}
+	if len(requester.requests) != 0 {
+		t.Errorf("Expected no handoff before the children run, got %v", requester.requests)
+	}
+
+	for _, childID := range childIDs {
+		child := &fleetforgev1.Cell{}
+		if err := fakeClient.Get(ctx, client.ObjectKey{Name: childID, Namespace: "default"}, child); err != nil {
+			t.Fatalf("Failed to get child Cell: %v", err)
+		}
+		child.Status.Phase = "Running"
+		if err := fakeClient.Status().Update(ctx, child); err != nil {
+			t.Fatalf("Failed to update child Cell: %v", err)
+		}
+	}
+
+	// The handoff is repeated while players are left on the pod
+	updated = reconcileParent()
+	if updated.Status.Phase != "Draining" || updated.Status.Players != 3 {
+		t.Errorf("Expected the split cell to keep draining its 3 players, got %+v", updated.Status)
+	}
+	if len(requester.requests) != 1 {
+		t.Fatalf("Expected one handoff request, got %d", len(requester.requests))
+	}
+	targets := requester.requests[0]
+	if len(targets) != 2 || string(targets[0].ID) != childIDs[0] || targets[1].Boundaries.XMin != 0 {
+		t.Errorf("Expected the children as handoff targets, got %+v", targets)
+	}
+	if requester.tokens[0] != "split-token" {
+		t.Errorf("Expected the handoff to carry the cell's token, got %q", requester.tokens[0])
+	}
+	if err := fakeClient.Get(ctx, req.NamespacedName, &appsv1.Deployment{}); err != nil {
+		t.Errorf("Expected the deployment to be kept while players are left: %v", err)
+	}
+
+	// The pod is removed once it is empty
+	requester.players = 0
+	updated = reconcileParent()
+	if updated.Status.Phase != "Split" {
+		t.Errorf("Expected the drained cell to be split, got phase %q", updated.Status.Phase)
+	}
+	if len(requester.requests) != 2 {
+		t.Errorf("Expected a second handoff request, got %d", len(requester.requests))
+	}
+	if err := fakeClient.Get(ctx, req.NamespacedName, &appsv1.Deployment{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the drained cell's deployment to be deleted, got %v", err)
+	}
+	serviceKey := client.ObjectKey{Name: "test-world-cell-0-service", Namespace: "default"}
+	if err := fakeClient.Get(ctx, serviceKey, &corev1.Service{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the drained cell's service to be deleted, got %v", err)
+	}
+	secretKey := client.ObjectKey{Name: "test-world-cell-0-handoff", Namespace: "default"}
+	if err := fakeClient.Get(ctx, secretKey, &corev1.Secret{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the drained cell's handoff secret to be deleted, got %v", err)
+	}
+}
+
+func TestCellReconciler_DrainsMergedCell(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	worldSpec := &fleetforgev1.WorldSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-world", Namespace: "default"},
+		Spec: fleetforgev1.WorldSpecSpec{
+			Topology: fleetforgev1.WorldTopology{
+				InitialCells:    1,
+				WorldBoundaries: fleetforgev1.WorldBounds{XMin: -1000.0, XMax: 1000.0},
+			},
+			Capacity:        fleetforgev1.CellCapacity{MaxPlayersPerCell: 100},
+			GameServerImage: "fleetforge-cell:latest",
+		},
+	}
+	parent := &fleetforgev1.Cell{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-world-cell-0",
+			Namespace:         "default",
+			Labels:            cellLabels("test-world", "test-world-cell-0"),
+			CreationTimestamp: metav1.Now(),
+		},
+		Spec: fleetforgev1.CellSpec{
+			WorldRef:   "test-world",
+			Boundaries: worldSpec.Spec.Topology.WorldBoundaries,
+		},
+		Status: fleetforgev1.CellObservedStatus{Phase: "Pending"},
+	}
+	childID := "test-world-cell-0-child-1"
+	child := &fleetforgev1.Cell{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      childID,
+			Namespace: "default",
+			Labels:    cellLabels("test-world", childID),
+		},
+		Spec: fleetforgev1.CellSpec{
+			WorldRef:   "test-world",
+			Boundaries: fleetforgev1.WorldBounds{XMin: -1000.0, XMax: 0},
+			ParentID:   "test-world-cell-0",
+			MergedInto: "test-world-cell-0",
+		},
+		Status: fleetforgev1.CellObservedStatus{Phase: "Running", Players: 2},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			worldSpec,
+			parent,
+			child,
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: childID, Namespace: "default"}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: childID + "-service", Namespace: "default"}},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: childID + "-handoff", Namespace: "default"},
+				Data:       map[string][]byte{handoffTokenKey: []byte("merge-token")},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      childID + "-abc",
+					Namespace: "default",
+					Labels:    cellLabels("test-world", childID),
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.2"},
+			},
+		).
+		WithStatusSubresource(&fleetforgev1.Cell{}).
+		Build()
+
+	requester := &fakeHandoffRequester{players: 2}
+	reconciler := &CellReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		Log:              ctrl.Log.WithName("test"),
+		Recorder:         record.NewFakeRecorder(10),
+		HandoffRequester: requester,
+	}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: childID, Namespace: "default"}}
+
+	// The pod keeps its players until the parent runs again
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	updated := &fleetforgev1.Cell{}
+	if err := fakeClient.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatalf("Failed to get Cell: %v", err)
+	}
+	if updated.Status.Phase != "Draining" {
+		t.Errorf("Expected the merged cell to drain, got phase %q", updated.Status.Phase)
+	}
+	if len(requester.requests) != 0 {
+		t.Errorf("Expected no handoff before the parent runs, got %v", requester.requests)
+	}
+
+	parent.Status.Phase = "Running"
+	if err := fakeClient.Status().Update(ctx, parent); err != nil {
+		t.Fatalf("Failed to update parent Cell: %v", err)
+	}
+
+	// The players are handed off to the parent
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if len(requester.requests) != 1 {
+		t.Fatalf("Expected one handoff request, got %d", len(requester.requests))
+	}
+	targets := requester.requests[0]
+	if len(targets) != 1 || string(targets[0].ID) != "test-world-cell-0" || targets[0].Boundaries.XMax != 1000.0 {
+		t.Errorf("Expected the parent as handoff target, got %+v", targets)
+	}
+	if requester.tokens[0] != "merge-token" {
+		t.Errorf("Expected the handoff to carry the cell's token, got %q", requester.tokens[0])
+	}
+	if err := fakeClient.Get(ctx, req.NamespacedName, &fleetforgev1.Cell{}); err != nil {
+		t.Errorf("Expected the merged cell to be kept while players are left: %v", err)
+	}
+
+	// The cell and its pod are removed once it is empty
+	requester.players = 0
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := fakeClient.Get(ctx, req.NamespacedName, &fleetforgev1.Cell{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the drained merged cell to be deleted, got %v", err)
+	}
+	if err := fakeClient.Get(ctx, req.NamespacedName, &appsv1.Deployment{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the drained cell's deployment to be deleted, got %v", err)
+	}
+}
+
+func TestHTTPCellStatusScraper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/status":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"id": "cell-0", "health": "Healthy", "currentPlayers": 17, "maxPlayers": 100, "ready": true, "playerPositions": [{"x": 1.5, "y": -2}]}`)
+		case "/handoff":
+			if r.Header.Get("Authorization") != "Bearer cell-token" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"id": "cell-0", "health": "Healthy", "currentPlayers": 0, "maxPlayers": 100, "ready": true}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to parse server address: %v", err)
+	}
+	port, _ := strconv.Atoi(portStr)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "cell-0-pod"},
+		Status:     corev1.PodStatus{PodIP: host},
+	}
+
+	scraper := &HTTPCellStatusScraper{Client: server.Client(), Port: port, Timeout: time.Second}
+	report, err := scraper.ScrapeCellStatus(context.Background(), pod)
+	if err != nil {
+		t.Fatalf("ScrapeCellStatus failed: %v", err)
+	}
+	if report.CurrentPlayers != 17 || report.MaxPlayers != 100 || !report.Ready {
+		t.Errorf("Unexpected status report: %+v", report)
+	}
+	if len(report.PlayerPositions) != 1 || report.PlayerPositions[0] != (fleetforgev1.PlayerPosition{X: 1.5, Y: -2}) {
+		t.Errorf("Expected the reported player positions, got %v", report.PlayerPositions)
+	}
+
+	if _, err := scraper.ScrapeCellStatus(context.Background(), &corev1.Pod{}); err == nil {
+		t.Error("Expected an error for a pod without an IP")
+	}
+
+	// Handoffs are authenticated with the cell's token
+	targets := []cell.HandoffTarget{{ID: "cell-0-child-1"}}
+	if _, err := scraper.RequestHandoff(context.Background(), pod, "cell-token", targets); err != nil {
+		t.Errorf("RequestHandoff failed: %v", err)
+	}
+	if _, err := scraper.RequestHandoff(context.Background(), pod, "wrong-token", targets); err == nil {
+		t.Error("Expected a handoff with the wrong token to be refused")
+	}
+}
+
+func TestHTTPCellStatusScraper_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	host, portStr, _ := net.SplitHostPort(server.Listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "slow-pod"},
+		Status:     corev1.PodStatus{PodIP: host},
+	}
+
+	scraper := &HTTPCellStatusScraper{Client: server.Client(), Port: port, Timeout: 50 * time.Millisecond}
+	start := time.Now()
+	if _, err := scraper.ScrapeCellStatus(context.Background(), pod); err == nil {
+		t.Fatal("Expected a slow pod to time out")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the query to give up after its timeout, took %v", elapsed)
+	}
+}
+
+func containsArg(args []string, want string) bool {
+	for _, arg := range args {
+		if arg == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 FleetForge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	fleetforgev1 "github.com/astrosteveo/fleetforge/api/v1"
+	"github.com/astrosteveo/fleetforge/pkg/cell"
+)
+
+const (
+	// cellHealthPort is the port cell pods serve their health and status endpoints on
+	cellHealthPort = 8081
+
+	// defaultCellStatusTimeout bounds a single pod status query
+	defaultCellStatusTimeout = 2 * time.Second
+)
+
+// CellStatusReport is the status a cell pod reports on its /status endpoint
+type CellStatusReport struct {
+	ID             string `json:"id"`
+	Health         string `json:"health"`
+	CurrentPlayers int32  `json:"currentPlayers"`
+	MaxPlayers     int32  `json:"maxPlayers"`
+	Ready          bool   `json:"ready"`
+	// SplitRequested is set when the cell's density calls for a split, which
+	// the pod leaves to the controller
+	SplitRequested bool `json:"splitRequested"`
+	// PlayerPositions is a sample of where the cell's players are
+	PlayerPositions []fleetforgev1.PlayerPosition `json:"playerPositions,omitempty"`
+}
+
+// CellStatusScraper queries a cell pod for its status
+type CellStatusScraper interface {
+	ScrapeCellStatus(ctx context.Context, pod *corev1.Pod) (*CellStatusReport, error)
+}
+
+// CellHandoffRequester asks the pod of a split or merged cell to hand its
+// players off to the cells taking over its space, returning the pod's status
+// afterwards. The request carries the cell's handoff token, without which the
+// pod refuses it.
+type CellHandoffRequester interface {
+	RequestHandoff(ctx context.Context, pod *corev1.Pod, token string, targets []cell.HandoffTarget) (*CellStatusReport, error)
+}
+
+// HTTPCellStatusScraper queries the /status endpoint on a cell pod's health
+// port, and requests handoffs on its /handoff endpoint
+type HTTPCellStatusScraper struct {
+	Client  *http.Client
+	Port    int
+	Timeout time.Duration
+}
+
+// NewHTTPCellStatusScraper creates a scraper for the default health port and timeout
+func NewHTTPCellStatusScraper() *HTTPCellStatusScraper {
+	return &HTTPCellStatusScraper{
+		Client:  &http.Client{},
+		Port:    cellHealthPort,
+		Timeout: defaultCellStatusTimeout,
+	}
+}
+
+// ScrapeCellStatus fetches and decodes a pod's status report
+func (s *HTTPCellStatusScraper) ScrapeCellStatus(ctx context.Context, pod *corev1.Pod) (*CellStatusReport, error) {
+	return s.query(ctx, pod, http.MethodGet, "/status", "", nil)
+}
+
+// RequestHandoff posts the cells taking over a retired cell's players to its
+// pod, authenticated with the cell's handoff token as a bearer token
+func (s *HTTPCellStatusScraper) RequestHandoff(ctx context.Context, pod *corev1.Pod, token string, targets []cell.HandoffTarget) (*CellStatusReport, error) {
+	body, err := json.Marshal(struct {
+		Cells []cell.HandoffTarget `json:"cells"`
+	}{Cells: targets})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode handoff request: %w", err)
+	}
+	return s.query(ctx, pod, http.MethodPost, "/handoff", token, bytes.NewReader(body))
+}
+
+// query sends a request to a pod's health port and decodes the status report it returns
+func (s *HTTPCellStatusScraper) query(ctx context.Context, pod *corev1.Pod, method, path, token string, body io.Reader) (*CellStatusReport, error) {
+	if pod.Status.PodIP == "" {
+		return nil, fmt.Errorf("pod %s has no IP", pod.Name)
+	}
+
+	// Each pod gets its own deadline so one slow cell cannot stall the others
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+
+	url := fmt.Sprintf("http://%s:%d%s", pod.Status.PodIP, s.Port, path)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s request: %w", path, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpClient := s.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s of pod %s: %w", path, pod.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s endpoint of pod %s returned %s", path, pod.Name, resp.Status)
+	}
+
+	report := &CellStatusReport{}
+	if err := json.NewDecoder(resp.Body).Decode(report); err != nil {
+		return nil, fmt.Errorf("failed to decode status of pod %s: %w", pod.Name, err)
+	}
+	return report, nil
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2024 FleetForge Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// splitBudgetDenials counts splits refused because a world is at its MaxCells
+var splitBudgetDenials = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "fleetforge_world_split_budget_denials_total",
+		Help: "Number of cell splits denied because the world is at its maximum cell count",
+	},
+	[]string{"namespace", "world"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(splitBudgetDenials)
+}
@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,10 +28,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,20 +42,53 @@ import (
 const (
 	// ForceSplitAnnotation is the annotation key used to trigger manual cell splits
 	ForceSplitAnnotation = "fleetforge.io/force-split"
+
+	// ForceMergeAnnotation is the annotation key used to merge split cells back into their parent
+	ForceMergeAnnotation = "fleetforge.io/force-merge"
+
+	// cellCheckpointDir is where cell pods write their checkpoints when persistence is enabled
+	cellCheckpointDir = "/var/lib/fleetforge/checkpoints"
+
+	// cellCheckpointStorage is the size of the volume claimed for each cell's checkpoints
+	cellCheckpointStorage = "1Gi"
+)
+
+// Annotations recording a cell's split and merge history, so the controller can
+// back off cells that keep re-splitting without keeping state of its own
+const (
+	// splitBackoffAnnotation is the split backoff level of a cell born from a
+	// split or merge. Its split cooldown doubles with each level.
+	splitBackoffAnnotation = "fleetforge.io/split-backoff-level"
+
+	// lineageChangedAnnotation is when a cell was born from a split or merge;
+	// its split cooldown counts from then, or from its creation without it
+	lineageChangedAnnotation = "fleetforge.io/lineage-changed-at"
+
+	// lineageChangesAnnotation lists when a cell recently split or had its
+	// children merged back, to flag cells that oscillate
+	lineageChangesAnnotation = "fleetforge.io/lineage-changes"
+
+	// underloadedSinceAnnotation is when the children of a split cell last fell
+	// below the world's ScaleDownThreshold, so they are merged back into it once
+	// they stay there for the merge hysteresis
+	underloadedSinceAnnotation = "fleetforge.io/underloaded-since"
 )
 
+// cellAnnotations are the annotations the controller owns on Cell resources
+var cellAnnotations = []string{splitBackoffAnnotation, lineageChangedAnnotation, lineageChangesAnnotation, underloadedSinceAnnotation}
+
 // WorldSpecReconciler reconciles a WorldSpec object
 type WorldSpecReconciler struct {
 	client.Client
-	Scheme      *runtime.Scheme
-	Log         logr.Logger
-	Recorder    record.EventRecorder
-	CellManager cell.CellManager
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=fleetforge.io,resources=worldspecs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=fleetforge.io,resources=worldspecs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=fleetforge.io,resources=worldspecs/finalizers,verbs=update
+//+kubebuilder:rbac:groups=fleetforge.io,resources=cells,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
@@ -103,6 +135,25 @@ func (r *WorldSpecReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	// Check for manual merge override annotation
+	if forceMergeValue, hasAnnotation := worldSpec.Annotations[ForceMergeAnnotation]; hasAnnotation {
+		if err := r.handleManualMergeOverride(ctx, worldSpec, forceMergeValue, log); err != nil {
+			log.Error(err, "Failed to handle manual merge override")
+			r.Recorder.Event(worldSpec, corev1.EventTypeWarning, "ManualMergeFailed",
+				fmt.Sprintf("Manual merge override failed: %v", err))
+		}
+	}
+
+	// Split the cells whose pods ask for it
+	if err := r.handleRequestedSplits(ctx, worldSpec, log); err != nil {
+		log.Error(err, "Failed to split cells at their pods' request")
+	}
+
+	// Merge split cells whose children have stayed underloaded
+	if err := r.handleUnderloadedMerges(ctx, worldSpec, log); err != nil {
+		log.Error(err, "Failed to merge underloaded cells")
+	}
+
 	// Handle creation/update of cell pods
 	result, err := r.reconcileCells(ctx, worldSpec, log)
 	if err != nil {
@@ -118,23 +169,35 @@ func (r *WorldSpecReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	// Requeue for status updates and annotation monitoring
-	if _, hasAnnotation := worldSpec.Annotations[ForceSplitAnnotation]; hasAnnotation {
-		// Aggressive polling if manual split annotation is present
+	_, hasSplitAnnotation := worldSpec.Annotations[ForceSplitAnnotation]
+	_, hasMergeAnnotation := worldSpec.Annotations[ForceMergeAnnotation]
+	if hasSplitAnnotation || hasMergeAnnotation {
+		// Aggressive polling if a manual split or merge annotation is present
 		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 	}
 	// Default (less aggressive) polling interval
 	return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
 }
 
-// reconcileCells manages the lifecycle of cell pods
+// reconcileCells manages the world's Cell resources. Each cell's pods are
+// managed by the CellReconciler.
 func (r *WorldSpecReconciler) reconcileCells(ctx context.Context, worldSpec *fleetforgev1.WorldSpec, log logr.Logger) (ctrl.Result, error) {
-	// Calculate cell boundaries based on world topology
-	cells := calculateCellBoundaries(worldSpec.Spec.Topology)
+	cells, err := r.cellTopology(ctx, worldSpec)
+	if err != nil {
+		log.Error(err, "Failed to get cell topology")
+		return ctrl.Result{}, err
+	}
+
+	live := liveCells(cells)
+	cellBounds := make([]fleetforgev1.WorldBounds, len(live))
+	for i, cellObj := range live {
+		cellBounds[i] = cellObj.Spec.Boundaries
+	}
 
 	// Validate that cell boundaries properly partition the parent space
 	// Use a small tolerance for floating-point precision issues
 	tolerance := 1e-6
-	if err := validateCellPartitioning(worldSpec.Spec.Topology.WorldBoundaries, cells, tolerance); err != nil {
+	if err := validateCellPartitioning(worldSpec.Spec.Topology.WorldBoundaries, cellBounds, tolerance); err != nil {
 		log.Error(err, "Cell boundary validation failed", "worldSpec", worldSpec.Name)
 		r.Recorder.Event(worldSpec, corev1.EventTypeWarning, "ValidationFailed",
 			fmt.Sprintf("Cell boundary validation failed: %v", err))
@@ -144,228 +207,162 @@ func (r *WorldSpecReconciler) reconcileCells(ctx context.Context, worldSpec *fle
 	log.Info("Cell boundary validation passed",
 		"worldSpec", worldSpec.Name,
 		"parentArea", worldSpec.Spec.Topology.WorldBoundaries.CalculateArea(),
-		"numCells", len(cells))
-
-	// Track cells being processed for status updates
-	cellsCreated := 0
-	cellsUpdated := 0
-	cellsErrored := 0
-
-	for i, cellBounds := range cells {
-		cellID := fmt.Sprintf("%s-cell-%d", worldSpec.Name, i)
+		"numCells", len(live))
 
-		// Create or update deployment for this cell
-		deploymentResult, err := r.reconcileCellDeployment(ctx, worldSpec, cellID, cellBounds, log)
-		if err != nil {
-			cellsErrored++
-			log.Error(err, "Failed to reconcile cell deployment", "cellID", cellID)
-			r.Recorder.Event(worldSpec, corev1.EventTypeWarning, "CellDeploymentFailed",
-				fmt.Sprintf("Failed to reconcile deployment for cell %s: %v", cellID, err))
-			return ctrl.Result{}, fmt.Errorf("failed to reconcile cell deployment %s: %w", cellID, err)
-		}
-
-		if deploymentResult == controllerutil.OperationResultCreated {
-			cellsCreated++
-			r.Recorder.Event(worldSpec, corev1.EventTypeNormal, "CellDeploymentCreated",
-				fmt.Sprintf("Created deployment for cell %s", cellID))
-		} else if deploymentResult == controllerutil.OperationResultUpdated {
-			cellsUpdated++
-			r.Recorder.Event(worldSpec, corev1.EventTypeNormal, "CellDeploymentUpdated",
-				fmt.Sprintf("Updated deployment for cell %s", cellID))
-		}
-
-		// Create or update service for this cell
-		serviceResult, err := r.reconcileCellService(ctx, worldSpec, cellID, log)
-		if err != nil {
-			cellsErrored++
-			log.Error(err, "Failed to reconcile cell service", "cellID", cellID)
-			r.Recorder.Event(worldSpec, corev1.EventTypeWarning, "CellServiceFailed",
-				fmt.Sprintf("Failed to reconcile service for cell %s: %v", cellID, err))
-			return ctrl.Result{}, fmt.Errorf("failed to reconcile cell service %s: %w", cellID, err)
-		}
+	cellsCreated, cellsUpdated, err := r.applyCells(ctx, worldSpec, cells, log)
+	if err != nil {
+		r.Recorder.Event(worldSpec, corev1.EventTypeWarning, "CellReconcileFailed",
+			fmt.Sprintf("Failed to reconcile cells: %v", err))
+		return ctrl.Result{}, err
+	}
 
-		if serviceResult == controllerutil.OperationResultCreated {
-			r.Recorder.Event(worldSpec, corev1.EventTypeNormal, "CellServiceCreated",
-				fmt.Sprintf("Created service for cell %s", cellID))
-		} else if serviceResult == controllerutil.OperationResultUpdated {
-			r.Recorder.Event(worldSpec, corev1.EventTypeNormal, "CellServiceUpdated",
-				fmt.Sprintf("Updated service for cell %s", cellID))
-		}
+	// Remove cells that left the topology, such as when InitialCells shrinks
+	cellsDeleted, err := r.deleteStaleCells(ctx, worldSpec, cells, log)
+	if err != nil {
+		log.Error(err, "Failed to delete stale cells")
+		return ctrl.Result{}, err
 	}
 
 	// Log summary of cell reconciliation
 	log.Info("Cell reconciliation completed",
 		"cellsCreated", cellsCreated,
 		"cellsUpdated", cellsUpdated,
-		"cellsErrored", cellsErrored,
+		"cellsDeleted", cellsDeleted,
+		"liveCells", len(live),
 		"totalCells", len(cells))
 
 	// Emit summary events if there were significant changes
 	if cellsCreated > 0 {
 		r.Recorder.Event(worldSpec, corev1.EventTypeNormal, "CellsCreated",
-			fmt.Sprintf("Created %d new cell deployments for world %s", cellsCreated, worldSpec.Name))
+			fmt.Sprintf("Created %d new cells for world %s", cellsCreated, worldSpec.Name))
 	}
 
 	if cellsUpdated > 0 {
 		r.Recorder.Event(worldSpec, corev1.EventTypeNormal, "CellsUpdated",
-			fmt.Sprintf("Updated %d cell deployments for world %s", cellsUpdated, worldSpec.Name))
+			fmt.Sprintf("Updated %d cells for world %s", cellsUpdated, worldSpec.Name))
+	}
+
+	if cellsDeleted > 0 {
+		r.Recorder.Event(worldSpec, corev1.EventTypeNormal, "CellsDeleted",
+			fmt.Sprintf("Deleted %d stale cells for world %s", cellsDeleted, worldSpec.Name))
 	}
 
 	return ctrl.Result{}, nil
 }
 
-// reconcileCellDeployment creates or updates a deployment for a cell
-func (r *WorldSpecReconciler) reconcileCellDeployment(ctx context.Context, worldSpec *fleetforgev1.WorldSpec, cellID string, bounds fleetforgev1.WorldBounds, log logr.Logger) (controllerutil.OperationResult, error) {
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      cellID,
-			Namespace: worldSpec.Namespace,
-		},
+// applyCells creates or updates the world's Cell resources. Live cells are
+// applied before split and merged ones so a retired cell's pods keep running
+// until the cells taking over its space exist. It returns how many cells were
+// created and updated.
+func (r *WorldSpecReconciler) applyCells(ctx context.Context, worldSpec *fleetforgev1.WorldSpec, cells []fleetforgev1.Cell, log logr.Logger) (int, int, error) {
+	ordered := liveCells(cells)
+	for _, cellObj := range cells {
+		if !isLive(&cellObj) {
+			ordered = append(ordered, cellObj)
+		}
 	}
 
-	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
-		// Set owner reference
-		if err := controllerutil.SetControllerReference(worldSpec, deployment, r.Scheme); err != nil {
-			return err
+	created, updated := 0, 0
+	for i := range ordered {
+		result, err := r.reconcileCell(ctx, worldSpec, &ordered[i])
+		if err != nil {
+			log.Error(err, "Failed to reconcile cell", "cellID", ordered[i].Name)
+			return created, updated, fmt.Errorf("failed to reconcile cell %s: %w", ordered[i].Name, err)
 		}
 
-		// Configure deployment spec
-		deployment.Spec = appsv1.DeploymentSpec{
-			Replicas: int32Ptr(1),
-			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"app":     "fleetforge-cell",
-					"cell-id": cellID,
-					"world":   worldSpec.Name,
-				},
-			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"app":     "fleetforge-cell",
-						"cell-id": cellID,
-						"world":   worldSpec.Name,
-					},
-				},
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
-						{
-							Name:  "cell-simulator",
-							Image: worldSpec.Spec.GameServerImage,
-							Args: []string{
-								fmt.Sprintf("--cell-id=%s", cellID),
-								fmt.Sprintf("--x-min=%f", bounds.XMin),
-								fmt.Sprintf("--x-max=%f", bounds.XMax),
-								fmt.Sprintf("--max-players=%d", worldSpec.Spec.Capacity.MaxPlayersPerCell),
-							},
-							Resources: corev1.ResourceRequirements{
-								Limits: corev1.ResourceList{
-									corev1.ResourceCPU:    r.parseResourceQuantity(worldSpec.Spec.Capacity.CPULimitPerCell, "cpu"),
-									corev1.ResourceMemory: r.parseResourceQuantity(worldSpec.Spec.Capacity.MemoryLimitPerCell, "memory"),
-								},
-								Requests: corev1.ResourceList{
-									corev1.ResourceCPU:    r.parseResourceQuantity(getStringValue(worldSpec.Spec.Capacity.CPURequestPerCell, "500m"), "cpu"),
-									corev1.ResourceMemory: r.parseResourceQuantity(getStringValue(worldSpec.Spec.Capacity.MemoryRequestPerCell, "1Gi"), "memory"),
-								},
-							},
-							Ports: []corev1.ContainerPort{
-								{
-									Name:          "health",
-									ContainerPort: 8081,
-									Protocol:      corev1.ProtocolTCP,
-								},
-								{
-									Name:          "metrics",
-									ContainerPort: 8080,
-									Protocol:      corev1.ProtocolTCP,
-								},
-							},
-							LivenessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Path: "/health",
-										Port: intstr.FromString("health"),
-									},
-								},
-								InitialDelaySeconds: 30,
-								PeriodSeconds:       10,
-							},
-							ReadinessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									HTTPGet: &corev1.HTTPGetAction{
-										Path: "/ready",
-										Port: intstr.FromString("health"),
-									},
-								},
-								InitialDelaySeconds: 5,
-								PeriodSeconds:       5,
-							},
-						},
-					},
-				},
-			},
+		if result == controllerutil.OperationResultCreated {
+			created++
+			r.Recorder.Event(worldSpec, corev1.EventTypeNormal, "CellCreated",
+				fmt.Sprintf("Created cell %s", ordered[i].Name))
+		} else if result == controllerutil.OperationResultUpdated {
+			updated++
+			r.Recorder.Event(worldSpec, corev1.EventTypeNormal, "CellUpdated",
+				fmt.Sprintf("Updated cell %s", ordered[i].Name))
 		}
-
-		return nil
-	})
-
-	if err != nil {
-		log.Error(err, "Failed to create or update deployment", "deployment", cellID)
-		return controllerutil.OperationResultNone, err
 	}
-
-	log.Info("Successfully reconciled deployment", "deployment", cellID, "operation", result)
-	return result, nil
+	return created, updated, nil
 }
 
-// reconcileCellService creates or updates a service for a cell
-func (r *WorldSpecReconciler) reconcileCellService(ctx context.Context, worldSpec *fleetforgev1.WorldSpec, cellID string, log logr.Logger) (controllerutil.OperationResult, error) {
-	service := &corev1.Service{
+// reconcileCell creates or updates a Cell resource owned by the world
+func (r *WorldSpecReconciler) reconcileCell(ctx context.Context, worldSpec *fleetforgev1.WorldSpec, desired *fleetforgev1.Cell) (controllerutil.OperationResult, error) {
+	cellObj := &fleetforgev1.Cell{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cellID + "-service",
+			Name:      desired.Name,
 			Namespace: worldSpec.Namespace,
 		},
 	}
 
-	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, func() error {
+	return controllerutil.CreateOrUpdate(ctx, r.Client, cellObj, func() error {
 		// Set owner reference
-		if err := controllerutil.SetControllerReference(worldSpec, service, r.Scheme); err != nil {
+		if err := controllerutil.SetControllerReference(worldSpec, cellObj, r.Scheme); err != nil {
 			return err
 		}
-
-		// Configure service spec
-		service.Spec = corev1.ServiceSpec{
-			Selector: map[string]string{
-				"app":     "fleetforge-cell",
-				"cell-id": cellID,
-			},
-			Ports: []corev1.ServicePort{
-				{
-					Name:       "health",
-					Port:       8081,
-					TargetPort: intstr.FromString("health"),
-					Protocol:   corev1.ProtocolTCP,
-				},
-				{
-					Name:       "metrics",
-					Port:       8080,
-					TargetPort: intstr.FromString("metrics"),
-					Protocol:   corev1.ProtocolTCP,
-				},
-			},
-			Type: corev1.ServiceTypeClusterIP,
+		cellObj.Labels = cellLabels(worldSpec.Name, desired.Name)
+		cellObj.Spec = *desired.Spec.DeepCopy()
+
+		// Other annotations on the cell are left alone
+		for _, key := range cellAnnotations {
+			if value, ok := desired.Annotations[key]; ok {
+				metav1.SetMetaDataAnnotation(&cellObj.ObjectMeta, key, value)
+			} else {
+				delete(cellObj.Annotations, key)
+			}
 		}
-
 		return nil
 	})
+}
 
+// deleteStaleCells deletes the Cell resources this world owns that are no
+// longer in its topology, returning how many were deleted. Their deployments
+// and services are garbage collected with them.
+func (r *WorldSpecReconciler) deleteStaleCells(ctx context.Context, worldSpec *fleetforgev1.WorldSpec, cells []fleetforgev1.Cell, log logr.Logger) (int, error) {
+	inTopology := make(map[string]bool, len(cells))
+	for _, cellObj := range cells {
+		inTopology[cellObj.Name] = true
+	}
+
+	existing, err := r.listCells(ctx, worldSpec)
 	if err != nil {
-		log.Error(err, "Failed to create or update service", "service", cellID+"-service")
-		return controllerutil.OperationResultNone, err
+		return 0, err
+	}
+
+	deleted := 0
+	for i := range existing {
+		cellObj := &existing[i]
+		if inTopology[cellObj.Name] || !metav1.IsControlledBy(cellObj, worldSpec) {
+			continue
+		}
+		if err := r.Delete(ctx, cellObj); client.IgnoreNotFound(err) != nil {
+			return deleted, fmt.Errorf("failed to delete cell %s: %w", cellObj.Name, err)
+		}
+		deleted++
+		log.Info("Deleted stale cell", "cellID", cellObj.Name)
 	}
+	return deleted, nil
+}
 
-	log.Info("Successfully reconciled service", "service", cellID+"-service", "operation", result)
-	return result, nil
+// scalingArgs returns the cell simulator flags for a world's scaling
+// configuration. Pods only decide when to request a split; the split itself,
+// its strategy and cooldown and the world's MinCells and MaxCells are applied
+// by the controller against the whole world.
+func scalingArgs(scaling fleetforgev1.ScalingConfiguration) []string {
+	var args []string
+	if scaling.ScaleUpThreshold > 0 {
+		args = append(args, fmt.Sprintf("--scale-up-threshold=%f", scaling.ScaleUpThreshold))
+	}
+	if scaling.SplitHysteresis > 0 {
+		args = append(args, fmt.Sprintf("--split-hysteresis=%f", scaling.SplitHysteresis))
+	}
+	if scaling.SustainedBreach != "" {
+		args = append(args, fmt.Sprintf("--sustained-breach=%s", scaling.SustainedBreach))
+	}
+	if scaling.PredictiveEnabled {
+		args = append(args, "--predictive-scaling")
+		if scaling.PredictionHorizon != "" {
+			args = append(args, fmt.Sprintf("--prediction-horizon=%s", scaling.PredictionHorizon))
+		}
+	}
+	return args
 }
 
 // UpdateWorldSpecStatus updates the status of the WorldSpec (exported for testing)
@@ -398,32 +395,50 @@ func (r *WorldSpecReconciler) updateWorldSpecStatus(ctx context.Context, worldSp
 		}
 	}
 
+	// Create a map of cell ID to deployment for quick lookup
+	deploymentMap := make(map[string]*appsv1.Deployment)
+	for i := range deploymentList.Items {
+		deployment := &deploymentList.Items[i]
+		deploymentMap[deployment.Name] = deployment
+	}
+
+	// Every live cell in the topology is expected to be running
+	topology, err := r.cellTopology(ctx, worldSpec)
+	if err != nil {
+		return err
+	}
+	live := liveCells(topology)
 	activeCells := int32(0)
-	expectedCells := worldSpec.Spec.Topology.InitialCells
+	expectedCells := int32(len(live))
+	deployedCells := 0
 	var cellStatuses []fleetforgev1.CellStatus
-	totalPlayers := int32(0) // This would be populated from actual metrics in a real implementation
+	totalPlayers := int32(0)
 
-	for _, deployment := range deploymentList.Items {
-		cellID := deployment.Name
+	for _, cellObj := range live {
+		cellID := cellObj.Name
 		cellStatus := fleetforgev1.CellStatus{
-			ID:         cellID,
-			PodName:    "",
-			Health:     "Unknown",
-			Boundaries: r.getCellBoundaries(cellID, worldSpec), // Helper to get boundaries from deployment
+			ID:             cellID,
+			PodName:        "",
+			Health:         "Unknown",
+			Boundaries:     cellObj.Spec.Boundaries,
+			CurrentPlayers: cellObj.Status.Players,
+			LastHeartbeat:  cellObj.Status.LastHeartbeat,
 		}
+		// Player counts are scraped from the cell pods by the Cell controller
+		totalPlayers += cellObj.Status.Players
+
+		deployment, exists := deploymentMap[cellID]
+		if !exists {
+			cellStatus.Health = "Pending"
+			cellStatuses = append(cellStatuses, cellStatus)
+			continue
+		}
+		deployedCells++
 
 		// Check if there's a corresponding pod
 		if pod, exists := podMap[cellID]; exists {
 			cellStatus.PodName = pod.Name
-			cellStatus.Health = r.getPodHealthStatus(pod)
-
-			// Update last heartbeat from pod condition
-			for _, condition := range pod.Status.Conditions {
-				if condition.Type == corev1.PodReady {
-					cellStatus.LastHeartbeat = &condition.LastTransitionTime
-					break
-				}
-			}
+			cellStatus.Health = podHealthStatus(pod)
 		}
 
 		// Determine if cell is active based on deployment and pod status
@@ -442,8 +457,9 @@ func (r *WorldSpecReconciler) updateWorldSpecStatus(ctx context.Context, worldSp
 
 	// Update basic status fields
 	worldSpec.Status.ActiveCells = activeCells
-	worldSpec.Status.TotalPlayers = totalPlayers // Would be updated from metrics
-	worldSpec.Status.Cells = cellStatuses
+	worldSpec.Status.TotalPlayers = totalPlayers
+	// Per-cell status is reported on each Cell resource to keep this status small
+	worldSpec.Status.Cells = nil
 	worldSpec.Status.LastUpdateTime = &metav1.Time{Time: time.Now()}
 
 	// Determine if world is ready (all expected cells are active and healthy)
@@ -471,9 +487,9 @@ func (r *WorldSpecReconciler) updateWorldSpecStatus(ctx context.Context, worldSp
 		}
 	} else {
 		// Determine if we're still creating or if there's an issue
-		if len(deploymentList.Items) < int(expectedCells) {
+		if deployedCells < int(expectedCells) {
 			worldSpec.Status.Phase = "Creating"
-			worldSpec.Status.Message = fmt.Sprintf("Creating cell deployments (%d/%d)", len(deploymentList.Items), expectedCells)
+			worldSpec.Status.Message = fmt.Sprintf("Creating cell deployments (%d/%d)", deployedCells, expectedCells)
 		} else {
 			worldSpec.Status.Phase = "Initializing"
 			worldSpec.Status.Message = fmt.Sprintf("Waiting for cells to become ready (%d/%d)", activeCells, expectedCells)
@@ -536,8 +552,7 @@ func (r *WorldSpecReconciler) updateStatus(ctx context.Context, worldSpec *fleet
 func (r *WorldSpecReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&fleetforgev1.WorldSpec{}).
-		Owns(&appsv1.Deployment{}).
-		Owns(&corev1.Service{}).
+		Owns(&fleetforgev1.Cell{}).
 		Complete(r)
 }
 
@@ -668,30 +683,159 @@ func CalculateCellBoundaries(topology fleetforgev1.WorldTopology) []fleetforgev1
 	return calculateCellBoundaries(topology)
 }
 
+// calculateCellBoundaries partitions the world into a grid of equal cells,
+// ordered along X first, then Y, then Z
 func calculateCellBoundaries(topology fleetforgev1.WorldTopology) []fleetforgev1.WorldBounds {
-	// Simple implementation: divide world into equal cells
-	cells := make([]fleetforgev1.WorldBounds, topology.InitialCells)
+	world := topology.WorldBoundaries
+	cols, rows, layers := topology.GridDimensions()
+	cells := make([]fleetforgev1.WorldBounds, 0, cols*rows*layers)
+
+	for layer := int32(0); layer < layers; layer++ {
+		for row := int32(0); row < rows; row++ {
+			for col := int32(0); col < cols; col++ {
+				// Axes with a single slice keep the world's own bounds
+				cellBounds := world
+				cellBounds.XMin, cellBounds.XMax = gridSlice(world.XMin, world.XMax, col, cols)
+				if world.YMin != nil && world.YMax != nil && rows > 1 {
+					yMin, yMax := gridSlice(*world.YMin, *world.YMax, row, rows)
+					cellBounds.YMin, cellBounds.YMax = &yMin, &yMax
+				}
+				if world.ZMin != nil && world.ZMax != nil && layers > 1 {
+					zMin, zMax := gridSlice(*world.ZMin, *world.ZMax, layer, layers)
+					cellBounds.ZMin, cellBounds.ZMax = &zMin, &zMax
+				}
+				cells = append(cells, cellBounds)
+			}
+		}
+	}
 
-	worldWidth := topology.WorldBoundaries.XMax - topology.WorldBoundaries.XMin
-	cellWidth := worldWidth / float64(topology.InitialCells)
+	return cells
+}
+
+// gridSlice returns the bounds of the i-th of n equal slices of [min, max]
+func gridSlice(min, max float64, i, n int32) (float64, float64) {
+	width := (max - min) / float64(n)
+	sliceMin := min + float64(i)*width
+	return sliceMin, sliceMin + width
+}
 
-	for i := int32(0); i < topology.InitialCells; i++ {
-		xMin := topology.WorldBoundaries.XMin + (float64(i) * cellWidth)
-		xMax := xMin + cellWidth
+// listCells returns the Cell resources of a world
+func (r *WorldSpecReconciler) listCells(ctx context.Context, worldSpec *fleetforgev1.WorldSpec) ([]fleetforgev1.Cell, error) {
+	cellList := &fleetforgev1.CellList{}
+	if err := r.List(ctx, cellList, client.InNamespace(worldSpec.Namespace), client.MatchingLabels{"world": worldSpec.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list cells: %w", err)
+	}
+	return cellList.Items, nil
+}
 
-		cells[i] = fleetforgev1.WorldBounds{
-			XMin: xMin,
-			XMax: xMax,
-			YMin: topology.WorldBoundaries.YMin,
-			YMax: topology.WorldBoundaries.YMax,
-			ZMin: topology.WorldBoundaries.ZMin,
-			ZMax: topology.WorldBoundaries.ZMax,
+// cellTopology returns every cell of the world, including split cells. Once a
+// cell has been split the topology is the world's Cell resources; until then it
+// follows the initial partition, so changes to InitialCells still take effect.
+func (r *WorldSpecReconciler) cellTopology(ctx context.Context, worldSpec *fleetforgev1.WorldSpec) ([]fleetforgev1.Cell, error) {
+	cells, err := r.listCells(ctx, worldSpec)
+	if err != nil {
+		return nil, err
+	}
+	for _, cellObj := range cells {
+		if cellObj.Spec.IsSplit() || cellObj.Spec.ParentID != "" {
+			return cells, nil
 		}
 	}
 
+	// Keep the creation time, lineage and reported status of cells that already exist
+	initial := initialCells(worldSpec)
+	for i := range initial {
+		if j := findCell(cells, initial[i].Name); j >= 0 {
+			initial[i].CreationTimestamp = cells[j].CreationTimestamp
+			initial[i].Annotations = cells[j].Annotations
+			initial[i].Status = cells[j].Status
+		}
+	}
+	return initial, nil
+}
+
+// initialCells returns the cells of a world's initial partition
+func initialCells(worldSpec *fleetforgev1.WorldSpec) []fleetforgev1.Cell {
+	bounds := calculateCellBoundaries(worldSpec.Spec.Topology)
+	cells := make([]fleetforgev1.Cell, len(bounds))
+	for i, cellBounds := range bounds {
+		cells[i] = fleetforgev1.Cell{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-cell-%d", worldSpec.Name, i),
+				Namespace: worldSpec.Namespace,
+			},
+			Spec: fleetforgev1.CellSpec{
+				WorldRef:   worldSpec.Name,
+				Boundaries: cellBounds,
+			},
+		}
+	}
 	return cells
 }
 
+// liveCells returns the cells that have been neither split nor merged, which
+// serve the world's space
+func liveCells(cells []fleetforgev1.Cell) []fleetforgev1.Cell {
+	live := make([]fleetforgev1.Cell, 0, len(cells))
+	for _, cellObj := range cells {
+		if isLive(&cellObj) {
+			live = append(live, cellObj)
+		}
+	}
+	return live
+}
+
+// isLive reports whether a cell serves its own space
+func isLive(cellObj *fleetforgev1.Cell) bool {
+	return !cellObj.Spec.IsSplit() && !cellObj.Spec.IsMerged()
+}
+
+// findCell returns the position of a cell in the topology, or -1
+func findCell(cells []fleetforgev1.Cell, cellID string) int {
+	for i, cellObj := range cells {
+		if cellObj.Name == cellID {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitChildren records a split on its parent cell and returns the child
+// cells covering the given bounds
+func splitChildren(parent *fleetforgev1.Cell, childBounds []fleetforgev1.WorldBounds) []fleetforgev1.Cell {
+	childIDs := make([]string, len(childBounds))
+	for i := range childBounds {
+		childIDs[i] = string(cell.ChildCellID(cell.CellID(parent.Name), i+1))
+	}
+
+	result := make([]fleetforgev1.Cell, len(childBounds))
+	for i, bounds := range childBounds {
+		siblingIDs := make([]string, 0, len(childIDs)-1)
+		for j, siblingID := range childIDs {
+			if j != i {
+				siblingIDs = append(siblingIDs, siblingID)
+			}
+		}
+
+		result[i] = fleetforgev1.Cell{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      childIDs[i],
+				Namespace: parent.Namespace,
+			},
+			Spec: fleetforgev1.CellSpec{
+				WorldRef:   parent.Spec.WorldRef,
+				Boundaries: *bounds.DeepCopy(),
+				ParentID:   parent.Name,
+				SiblingIDs: siblingIDs,
+				Generation: parent.Spec.Generation + 1,
+			},
+		}
+	}
+
+	parent.Spec.ChildIDs = childIDs
+	return result
+}
+
 func int32Ptr(i int32) *int32 {
 	return &i
 }
@@ -705,13 +849,21 @@ func getStringValue(ptr *string, defaultValue string) string {
 
 // handleManualSplitOverride processes manual split override annotations
 func (r *WorldSpecReconciler) handleManualSplitOverride(ctx context.Context, worldSpec *fleetforgev1.WorldSpec, cellIDSpec string, log logr.Logger) error {
-	// Initialize cell manager if not already done
-	if r.CellManager == nil {
-		r.CellManager = cell.NewCellManager()
+	cells, err := r.cellTopology(ctx, worldSpec)
+	if err != nil {
+		return err
 	}
 
 	// Parse the annotation value - could be a specific cell ID or "all"
-	cellIDs := r.parseCellIDsFromAnnotation(cellIDSpec, worldSpec)
+	var cellIDs []string
+	if strings.EqualFold(strings.TrimSpace(cellIDSpec), "all") {
+		// After earlier splits the live cells are no longer the initial partition
+		for _, cellObj := range liveCells(cells) {
+			cellIDs = append(cellIDs, cellObj.Name)
+		}
+	} else {
+		cellIDs = r.parseCellIDsFromAnnotation(cellIDSpec, worldSpec)
+	}
 
 	if len(cellIDs) == 0 {
 		return fmt.Errorf("no valid cell IDs found in annotation value: %s", cellIDSpec)
@@ -726,24 +878,30 @@ func (r *WorldSpecReconciler) handleManualSplitOverride(ctx context.Context, wor
 	for _, cellID := range cellIDs {
 		log.Info("Processing manual split override", "cellID", cellID, "userInfo", userInfo)
 
-		// Use CellManager to perform the manual split
-		childCells, err := r.CellManager.ManualSplitCell(cell.CellID(cellID), userInfo)
+		children, err := r.splitCell(worldSpec, cells, cellID, log)
 		if err != nil {
 			splitErrors = append(splitErrors, fmt.Sprintf("cell %s: %v", cellID, err))
-			log.Error(err, "Manual split failed", "cellID", cellID)
 			continue
 		}
 
+		cells = append(cells, children...)
 		successfulSplits++
-		log.Info("Manual split successful", "cellID", cellID, "childCells", len(childCells))
+		log.Info("Manual split successful", "cellID", cellID, "childCells", len(children))
 
 		// Record event for successful manual split
 		r.Recorder.Event(worldSpec, corev1.EventTypeNormal, "ManualOverride",
-			fmt.Sprintf("Cell %s manually split into %d children by user", cellID, len(childCells)))
+			fmt.Sprintf("Cell %s manually split into %d children by user", cellID, len(children)))
+	}
+
+	// Persist the new topology before the annotation is removed so the split is not lost
+	if successfulSplits > 0 {
+		if _, _, err := r.applyCells(ctx, worldSpec, cells, log); err != nil {
+			return err
+		}
 	}
 
 	// Remove the annotation after processing to prevent re-processing
-	if err := r.removeAnnotation(ctx, worldSpec, log); err != nil {
+	if err := r.removeAnnotation(ctx, worldSpec, ForceSplitAnnotation, log); err != nil {
 		log.Error(err, "Failed to remove manual split annotation")
 		return err
 	}
@@ -761,6 +919,451 @@ func (r *WorldSpecReconciler) handleManualSplitOverride(ctx context.Context, wor
 	return nil
 }
 
+// handleRequestedSplits splits the live cells whose pods ask for it. Pods leave
+// their splits to the controller, which applies the world's split cooldown,
+// backed off for lineages that keep re-splitting, and its cell budget.
+func (r *WorldSpecReconciler) handleRequestedSplits(ctx context.Context, worldSpec *fleetforgev1.WorldSpec, log logr.Logger) error {
+	cells, err := r.cellTopology(ctx, worldSpec)
+	if err != nil {
+		return err
+	}
+
+	cooldown, err := splitCooldown(worldSpec)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var requested []string
+	for _, cellObj := range liveCells(cells) {
+		if !cellObj.Status.SplitRequested {
+			continue
+		}
+		level := splitBackoffLevel(&cellObj)
+		if remaining := splitCooldownFor(cooldown, level) - now.Sub(lineageChangedAt(&cellObj)); remaining > 0 {
+			log.Info("Requested split is waiting for the split cooldown", "cellID", cellObj.Name,
+				"remaining", remaining, "backoffLevel", level)
+			continue
+		}
+		requested = append(requested, cellObj.Name)
+	}
+
+	var splitErrors []string
+	successfulSplits := 0
+	for _, cellID := range requested {
+		children, err := r.splitCell(worldSpec, cells, cellID, log)
+		if err != nil {
+			splitErrors = append(splitErrors, fmt.Sprintf("cell %s: %v", cellID, err))
+			continue
+		}
+
+		cells = append(cells, children...)
+		successfulSplits++
+		log.Info("Requested split successful", "cellID", cellID, "childCells", len(children))
+	}
+
+	if successfulSplits > 0 {
+		if _, _, err := r.applyCells(ctx, worldSpec, cells, log); err != nil {
+			return err
+		}
+	}
+
+	if len(splitErrors) > 0 {
+		return fmt.Errorf("requested split failures: %s", strings.Join(splitErrors, "; "))
+	}
+	return nil
+}
+
+// Split cooldown backoff
+const (
+	// defaultSplitCooldown is the split cooldown of worlds that do not set one
+	defaultSplitCooldown = 5 * time.Minute
+	// maxSplitBackoffLevel caps the cooldown at 2^maxSplitBackoffLevel times the world's cooldown
+	maxSplitBackoffLevel = 5
+	// oscillationChanges splits and merges of one cell within the oscillation window flag it as oscillating
+	oscillationChanges = 3
+	// oscillationWindowCooldowns is the oscillation window in multiples of the world's split cooldown
+	oscillationWindowCooldowns = 6
+)
+
+// splitCooldown returns the world's base split cooldown
+func splitCooldown(worldSpec *fleetforgev1.WorldSpec) (time.Duration, error) {
+	cooldown, err := worldSpec.Spec.Scaling.ParseSplitCooldown()
+	if err != nil {
+		return 0, err
+	}
+	if cooldown == 0 {
+		cooldown = defaultSplitCooldown
+	}
+	return cooldown, nil
+}
+
+// splitCooldownFor returns the split cooldown at a backoff level, doubling with each level
+func splitCooldownFor(cooldown time.Duration, level int) time.Duration {
+	return cooldown << min(level, maxSplitBackoffLevel)
+}
+
+// splitBackoffLevel returns a cell's split backoff level, 0 for cells not born
+// from a split or merge
+func splitBackoffLevel(cellObj *fleetforgev1.Cell) int {
+	level, err := strconv.Atoi(cellObj.Annotations[splitBackoffAnnotation])
+	if err != nil || level < 0 {
+		return 0
+	}
+	return min(level, maxSplitBackoffLevel)
+}
+
+// lineageChangedAt returns when a cell was born from a split or merge, or its
+// creation time when it was not
+func lineageChangedAt(cellObj *fleetforgev1.Cell) time.Time {
+	if at, err := time.Parse(time.RFC3339, cellObj.Annotations[lineageChangedAnnotation]); err == nil {
+		return at
+	}
+	return cellObj.CreationTimestamp.Time
+}
+
+// childBackoffLevel returns the backoff level for the children of a splitting
+// cell. A cell born from a split or merge that splits again before twice its own
+// cooldown has passed hands its children a longer cooldown; otherwise the
+// backoff resets.
+func childBackoffLevel(parent *fleetforgev1.Cell, cooldown time.Duration, now time.Time) int {
+	if _, tracked := parent.Annotations[splitBackoffAnnotation]; !tracked {
+		return 0
+	}
+	level := splitBackoffLevel(parent)
+	if now.Sub(lineageChangedAt(parent)) < 2*splitCooldownFor(cooldown, level) {
+		return min(level+1, maxSplitBackoffLevel)
+	}
+	return 0
+}
+
+// markLineageChanged records that a cell was born from a split or merge at a backoff level
+func markLineageChanged(cellObj *fleetforgev1.Cell, level int, now time.Time) {
+	metav1.SetMetaDataAnnotation(&cellObj.ObjectMeta, splitBackoffAnnotation, strconv.Itoa(level))
+	metav1.SetMetaDataAnnotation(&cellObj.ObjectMeta, lineageChangedAnnotation, now.UTC().Format(time.RFC3339))
+}
+
+// recordLineageChange notes that a cell split or had its children merged back,
+// and flags it as oscillating when that keeps happening within the
+// oscillation window
+func (r *WorldSpecReconciler) recordLineageChange(worldSpec *fleetforgev1.WorldSpec, cellObj *fleetforgev1.Cell, change string, cooldown time.Duration, now time.Time) {
+	window := cooldown * oscillationWindowCooldowns
+
+	var changes []string
+	for _, value := range strings.Split(cellObj.Annotations[lineageChangesAnnotation], ",") {
+		if at, err := time.Parse(time.RFC3339, value); err == nil && now.Sub(at) < window {
+			changes = append(changes, value)
+		}
+	}
+	changes = append(changes, now.UTC().Format(time.RFC3339))
+
+	if len(changes) < oscillationChanges {
+		metav1.SetMetaDataAnnotation(&cellObj.ObjectMeta, lineageChangesAnnotation, strings.Join(changes, ","))
+		return
+	}
+
+	// Start counting afresh so a flapping cell is flagged once per run of changes
+	delete(cellObj.Annotations, lineageChangesAnnotation)
+	r.Log.Info("Cell is oscillating", "cellID", cellObj.Name, "changes", len(changes), "window", window, "lastChange", change)
+	r.Recorder.Event(worldSpec, corev1.EventTypeWarning, string(cell.CellEventOscillating),
+		fmt.Sprintf("Cell %s is oscillating: %d splits and merges within %s, last a %s (backoff level %d)",
+			cellObj.Name, len(changes), window, change, splitBackoffLevel(cellObj)))
+}
+
+// splitCell splits a live cell of the world with the world's split strategy,
+// within its cell budget. The split is recorded on the parent in cells, and the
+// children are returned for the caller to add to the topology.
+func (r *WorldSpecReconciler) splitCell(worldSpec *fleetforgev1.WorldSpec, cells []fleetforgev1.Cell, cellID string, log logr.Logger) ([]fleetforgev1.Cell, error) {
+	index := findCell(cells, cellID)
+	if index < 0 || !isLive(&cells[index]) {
+		return nil, fmt.Errorf("not a live cell of the world")
+	}
+
+	// Its new children would take the IDs of the merged ones still handing off
+	for _, cellObj := range cells {
+		if cellObj.Spec.MergedInto == cellID {
+			return nil, fmt.Errorf("merged cell %s is still handing its players off", cellObj.Name)
+		}
+	}
+
+	strategy, err := cell.NewSplitStrategy(worldSpec.Spec.Scaling.SplitStrategy)
+	if err != nil {
+		return nil, err
+	}
+	cooldown, err := splitCooldown(worldSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cuts are placed from the sample of player positions the cell's pod reports
+	reported := cells[index].Status.PlayerPositions
+	positions := make([]cell.WorldPosition, len(reported))
+	for i, pos := range reported {
+		positions[i] = cell.WorldPosition{X: pos.X, Y: pos.Y}
+	}
+	childBounds := strategy.Split(cells[index].Spec.Boundaries, positions)
+
+	// The parent is replaced, so a split adds one cell fewer than it has children
+	if err := checkSplitBudget(worldSpec, len(liveCells(cells)), len(childBounds)-1); err != nil {
+		r.recordSplitDenied(worldSpec, cellID, err)
+		return nil, err
+	}
+
+	predicted := cell.PredictChildPopulations(childBounds, positions)
+	log.Info("Splitting cell", "cellID", cellID, "strategy", strategy.Name(),
+		"childCells", len(childBounds), "sampledPlayers", len(positions), "predictedChildPlayers", predicted)
+	r.Recorder.Event(worldSpec, corev1.EventTypeNormal, string(cell.CellEventSplit),
+		fmt.Sprintf("Cell %s split into %d children with the %s strategy; %d sampled players predicted per child: %v",
+			cellID, len(childBounds), strategy.Name(), len(positions), predicted))
+
+	// Children that keep re-splitting wait longer each time
+	now := time.Now()
+	parent := &cells[index]
+	children := splitChildren(parent, childBounds)
+	level := childBackoffLevel(parent, cooldown, now)
+	for i := range children {
+		markLineageChanged(&children[i], level, now)
+	}
+	r.recordLineageChange(worldSpec, parent, "split", cooldown, now)
+	return children, nil
+}
+
+// handleManualMergeOverride processes manual merge override annotations. The
+// annotation names split cells whose children are merged back into them.
+func (r *WorldSpecReconciler) handleManualMergeOverride(ctx context.Context, worldSpec *fleetforgev1.WorldSpec, parentIDSpec string, log logr.Logger) error {
+	parentIDs := r.parseCellIDsFromAnnotation(parentIDSpec, worldSpec)
+	if len(parentIDs) == 0 {
+		return fmt.Errorf("no valid cell IDs found in annotation value: %s", parentIDSpec)
+	}
+
+	cells, err := r.cellTopology(ctx, worldSpec)
+	if err != nil {
+		return err
+	}
+
+	var mergeErrors []string
+	successfulMerges := 0
+	for _, parentID := range parentIDs {
+		log.Info("Processing manual merge override", "parentID", parentID)
+
+		if err := r.mergeCell(worldSpec, cells, parentID, log); err != nil {
+			mergeErrors = append(mergeErrors, fmt.Sprintf("cell %s: %v", parentID, err))
+			log.Error(err, "Manual merge failed", "parentID", parentID)
+			continue
+		}
+		successfulMerges++
+
+		r.Recorder.Event(worldSpec, corev1.EventTypeNormal, "ManualOverride",
+			fmt.Sprintf("Children of cell %s manually merged back into it by user", parentID))
+	}
+
+	// Persist the new topology before the annotation is removed so the merge is not lost
+	if successfulMerges > 0 {
+		if _, _, err := r.applyCells(ctx, worldSpec, cells, log); err != nil {
+			return err
+		}
+	}
+
+	if err := r.removeAnnotation(ctx, worldSpec, ForceMergeAnnotation, log); err != nil {
+		log.Error(err, "Failed to remove manual merge annotation")
+		return err
+	}
+
+	if len(mergeErrors) > 0 {
+		return fmt.Errorf("manual merge failures: %s", strings.Join(mergeErrors, "; "))
+	}
+
+	return nil
+}
+
+// mergeCell merges a split cell's children back into it, recording the merge
+// in cells for the caller to apply. The parent serves its space again and the
+// children are marked merged, so the Cell controller has their pods hand their
+// players off to it once it runs and then deletes them. Children that have
+// themselves been split must be merged first.
+func (r *WorldSpecReconciler) mergeCell(worldSpec *fleetforgev1.WorldSpec, cells []fleetforgev1.Cell, parentID string, log logr.Logger) error {
+	index := findCell(cells, parentID)
+	if index < 0 || !cells[index].Spec.IsSplit() {
+		return fmt.Errorf("not a split cell of the world")
+	}
+	parent := &cells[index]
+
+	children := make([]int, 0, len(parent.Spec.ChildIDs))
+	for _, childID := range parent.Spec.ChildIDs {
+		childIndex := findCell(cells, childID)
+		if childIndex < 0 {
+			continue
+		}
+		if cells[childIndex].Spec.IsSplit() {
+			return fmt.Errorf("child cell %s has been split and must be merged first", childID)
+		}
+		children = append(children, childIndex)
+	}
+
+	// The parent replaces all of its children
+	if err := checkMergeBudget(worldSpec, len(liveCells(cells)), len(parent.Spec.ChildIDs)-1); err != nil {
+		return err
+	}
+	cooldown, err := splitCooldown(worldSpec)
+	if err != nil {
+		return err
+	}
+
+	for _, childIndex := range children {
+		cells[childIndex].Spec.MergedInto = parentID
+	}
+	parent.Spec.ChildIDs = nil
+	delete(parent.Annotations, underloadedSinceAnnotation)
+
+	// The parent resumes the backoff it had before it split
+	now := time.Now()
+	markLineageChanged(parent, splitBackoffLevel(parent), now)
+	r.recordLineageChange(worldSpec, parent, "merge", cooldown, now)
+
+	log.Info("Merging cell", "parentID", parentID, "childCells", len(children))
+	return nil
+}
+
+// defaultMergeHysteresis is how long the children of a split cell must stay
+// underloaded before they are merged, for worlds that do not set it
+const defaultMergeHysteresis = 5 * time.Minute
+
+// handleUnderloadedMerges merges split cells back together once their children
+// have stayed below the world's ScaleDownThreshold for its merge hysteresis. The
+// children's density is their reported players against the capacity of the
+// merged cell. Children born from a recent split or merge wait for the split
+// cooldown, and the world's MinCells is kept.
+func (r *WorldSpecReconciler) handleUnderloadedMerges(ctx context.Context, worldSpec *fleetforgev1.WorldSpec, log logr.Logger) error {
+	threshold := worldSpec.Spec.Scaling.ScaleDownThreshold
+	capacity := worldSpec.Spec.Capacity.MaxPlayersPerCell
+	if threshold <= 0 || capacity <= 0 {
+		return nil
+	}
+
+	hysteresis, err := worldSpec.Spec.Scaling.ParseMergeHysteresis()
+	if err != nil {
+		return err
+	}
+	if hysteresis == 0 {
+		hysteresis = defaultMergeHysteresis
+	}
+	cooldown, err := splitCooldown(worldSpec)
+	if err != nil {
+		return err
+	}
+
+	cells, err := r.cellTopology(ctx, worldSpec)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	changed := false
+	for i := range cells {
+		parent := &cells[i]
+		if !parent.Spec.IsSplit() || parent.Spec.IsMerged() {
+			continue
+		}
+
+		players, ok := childPlayers(cells, parent)
+		density := float64(players) / float64(capacity)
+		if !ok || density >= threshold {
+			if _, tracked := parent.Annotations[underloadedSinceAnnotation]; tracked {
+				delete(parent.Annotations, underloadedSinceAnnotation)
+				changed = true
+			}
+			continue
+		}
+
+		since, err := time.Parse(time.RFC3339, parent.Annotations[underloadedSinceAnnotation])
+		if err != nil {
+			metav1.SetMetaDataAnnotation(&parent.ObjectMeta, underloadedSinceAnnotation, now.UTC().Format(time.RFC3339))
+			changed = true
+			continue
+		}
+		if now.Sub(since) < hysteresis {
+			continue
+		}
+
+		// Stay tracked so the children merge as soon as they may
+		if remaining := mergeCooldownRemaining(cells, parent, cooldown, now); remaining > 0 {
+			log.Info("Merge blocked by the split cooldown", "parentID", parent.Name,
+				"remaining", remaining, "density", density)
+			continue
+		}
+		if err := r.mergeCell(worldSpec, cells, parent.Name, log); err != nil {
+			log.Info("Underloaded cell cannot be merged", "parentID", parent.Name, "error", err.Error())
+			continue
+		}
+		changed = true
+
+		r.Recorder.Event(worldSpec, corev1.EventTypeNormal, string(cell.CellEventMerged),
+			fmt.Sprintf("Children of cell %s merged back into it on scale down: %d players, density %.2f below %.2f for %s",
+				parent.Name, players, density, threshold, now.Sub(since).Round(time.Second)))
+	}
+
+	if !changed {
+		return nil
+	}
+	_, _, err = r.applyCells(ctx, worldSpec, cells, log)
+	return err
+}
+
+// childPlayers returns how many players a split cell's children report, and
+// whether they are all live, running and reporting
+func childPlayers(cells []fleetforgev1.Cell, parent *fleetforgev1.Cell) (int, bool) {
+	players := 0
+	for _, childID := range parent.Spec.ChildIDs {
+		index := findCell(cells, childID)
+		if index < 0 {
+			return 0, false
+		}
+		child := &cells[index]
+		if !isLive(child) || child.Status.Phase != "Running" || child.Status.Stale {
+			return 0, false
+		}
+		players += int(child.Status.Players)
+	}
+	return players, true
+}
+
+// mergeCooldownRemaining returns how long until none of a split cell's children
+// was born from a split or merge within the split cooldown
+func mergeCooldownRemaining(cells []fleetforgev1.Cell, parent *fleetforgev1.Cell, cooldown time.Duration, now time.Time) time.Duration {
+	var remaining time.Duration
+	for _, childID := range parent.Spec.ChildIDs {
+		if index := findCell(cells, childID); index >= 0 {
+			remaining = max(remaining, cooldown-now.Sub(lineageChangedAt(&cells[index])))
+		}
+	}
+	return remaining
+}
+
+// checkSplitBudget reports whether adding cells to a world's live cells stays
+// within its MaxCells
+func checkSplitBudget(worldSpec *fleetforgev1.WorldSpec, live, added int) error {
+	if limit := worldSpec.Spec.Scaling.MaxCells; limit != nil && live+added > int(*limit) {
+		return fmt.Errorf("%w: %d cells would exceed maxCells %d", cell.ErrCellLimitReached, live+added, *limit)
+	}
+	return nil
+}
+
+// checkMergeBudget reports whether removing cells from a world's live cells
+// stays within its MinCells
+func checkMergeBudget(worldSpec *fleetforgev1.WorldSpec, live, removed int) error {
+	if limit := worldSpec.Spec.Scaling.MinCells; limit != nil && live-removed < int(*limit) {
+		return fmt.Errorf("%w: %d cells would fall below minCells %d", cell.ErrCellLimitReached, live-removed, *limit)
+	}
+	return nil
+}
+
+// recordSplitDenied records a split refused by the world's cell budget
+func (r *WorldSpecReconciler) recordSplitDenied(worldSpec *fleetforgev1.WorldSpec, cellID string, err error) {
+	splitBudgetDenials.WithLabelValues(worldSpec.Namespace, worldSpec.Name).Inc()
+	r.Recorder.Event(worldSpec, corev1.EventTypeWarning, string(cell.CellEventSplitDenied),
+		fmt.Sprintf("Split of cell %s denied: %v", cellID, err))
+}
+
 // parseCellIDsFromAnnotation parses the annotation value to extract cell IDs
 func (r *WorldSpecReconciler) parseCellIDsFromAnnotation(value string, worldSpec *fleetforgev1.WorldSpec) []string {
 	value = strings.TrimSpace(value)
@@ -772,7 +1375,7 @@ func (r *WorldSpecReconciler) parseCellIDsFromAnnotation(value string, worldSpec
 	// Handle "all" keyword to split all active cells
 	if strings.ToLower(value) == "all" {
 		var cellIDs []string
-		for i := int32(0); i < worldSpec.Spec.Topology.InitialCells; i++ {
+		for i := int32(0); i < worldSpec.Spec.Topology.CellCount(); i++ {
 			cellID := fmt.Sprintf("%s-cell-%d", worldSpec.Name, i)
 			cellIDs = append(cellIDs, cellID)
 		}
@@ -822,42 +1425,27 @@ func (r *WorldSpecReconciler) extractUserIdentity(worldSpec *fleetforgev1.WorldS
 	return userInfo
 }
 
-// removeAnnotation removes the force split annotation after processing
-func (r *WorldSpecReconciler) removeAnnotation(ctx context.Context, worldSpec *fleetforgev1.WorldSpec, log logr.Logger) error {
+// removeAnnotation removes a force split or merge annotation after processing
+func (r *WorldSpecReconciler) removeAnnotation(ctx context.Context, worldSpec *fleetforgev1.WorldSpec, key string, log logr.Logger) error {
 	if worldSpec.Annotations == nil {
 		return nil
 	}
 
 	// Create a deep copy and remove the annotation from the copy
 	patch := worldSpec.DeepCopy()
-	delete(patch.Annotations, ForceSplitAnnotation)
+	delete(patch.Annotations, key)
 
 	// Use MergeFrom to patch only the annotation field
 	if err := r.Client.Patch(ctx, patch, client.MergeFrom(worldSpec)); err != nil {
 		return fmt.Errorf("failed to remove annotation via patch: %w", err)
 	}
 
-	log.Info("Removed manual split annotation", "annotation", ForceSplitAnnotation)
-	return nil
-}
+	// Keep the in-memory object current so the status update that follows does not conflict
+	worldSpec.Annotations = patch.Annotations
+	worldSpec.ResourceVersion = patch.ResourceVersion
 
-// parseResourceQuantity parses a resource quantity string, logs errors, and uses contextually appropriate defaults.
-func (r *WorldSpecReconciler) parseResourceQuantity(s string, resourceType string) resource.Quantity {
-	qty, err := resource.ParseQuantity(s)
-	if err != nil {
-		var defaultVal string
-		switch resourceType {
-		case "cpu":
-			defaultVal = "100m"
-		case "memory":
-			defaultVal = "128Mi"
-		default:
-			defaultVal = "100m"
-		}
-		r.Log.Error(err, "Failed to parse resource quantity", "value", s, "resourceType", resourceType, "usingDefault", defaultVal)
-		return resource.MustParse(defaultVal)
-	}
-	return qty
+	log.Info("Removed manual override annotation", "annotation", key)
+	return nil
 }
 
 // requeueWithBackoff implements exponential backoff for retries
@@ -962,54 +1550,3 @@ func (r *WorldSpecReconciler) categorizeError(err error) string {
 func randInt(min, max int) int {
 	return min + int(time.Now().UnixNano())%(max-min+1)
 }
-
-// getCellBoundaries extracts cell boundaries from cell ID and world spec
-func (r *WorldSpecReconciler) getCellBoundaries(cellID string, worldSpec *fleetforgev1.WorldSpec) fleetforgev1.WorldBounds {
-	// Extract cell index from cell ID (format: worldname-cell-N)
-	cellIndex := -1
-	_, err := fmt.Sscanf(cellID, worldSpec.Name+"-cell-%d", &cellIndex)
-	if err != nil || cellIndex < 0 {
-		// Return empty bounds if we can't parse the cell ID
-		return fleetforgev1.WorldBounds{}
-	}
-
-	// Recalculate boundaries for this specific cell
-	cells := calculateCellBoundaries(worldSpec.Spec.Topology)
-	if cellIndex < len(cells) {
-		return cells[cellIndex]
-	}
-
-	// Return empty bounds if index is out of range
-	return fleetforgev1.WorldBounds{}
-}
-
-// getPodHealthStatus determines health status based on pod conditions
-func (r *WorldSpecReconciler) getPodHealthStatus(pod *corev1.Pod) string {
-	// Check if pod is running and ready
-	if pod.Status.Phase == corev1.PodRunning {
-		for _, condition := range pod.Status.Conditions {
-			if condition.Type == corev1.PodReady {
-				if condition.Status == corev1.ConditionTrue {
-					return "Healthy"
-				} else {
-					return "NotReady"
-				}
-			}
-		}
-		return "Starting"
-	} else if pod.Status.Phase == corev1.PodPending {
-		// Check if it's stuck in pending due to scheduling issues
-		for _, condition := range pod.Status.Conditions {
-			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
-				return "SchedulingFailed"
-			}
-		}
-		return "Pending"
-	} else if pod.Status.Phase == corev1.PodFailed {
-		return "Failed"
-	} else if pod.Status.Phase == corev1.PodSucceeded {
-		return "Completed"
-	}
-
-	return "Unknown"
-}
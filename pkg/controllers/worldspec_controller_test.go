@@ -2,9 +2,14 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -18,6 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	fleetforgev1 "github.com/astrosteveo/fleetforge/api/v1"
+	"github.com/astrosteveo/fleetforge/pkg/cell"
 )
 
 func TestWorldSpecController_UpdateStatus(t *testing.T) {
@@ -283,6 +289,43 @@ func TestWorldSpecController_UpdateStatus(t *testing.T) {
 			// Good, no events
 		}
 	})
+
+	t.Run("TotalPlayers sums the players reported by each cell", func(t *testing.T) {
+		testWorldSpec := worldSpec.DeepCopy()
+		var objects []client.Object
+		objects = append(objects, testWorldSpec)
+		for i, players := range []int32{12, 30} {
+			objects = append(objects, &fleetforgev1.Cell{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("test-world-cell-%d", i),
+					Namespace: "default",
+					Labels:    cellLabels("test-world", fmt.Sprintf("test-world-cell-%d", i)),
+				},
+				Spec:   fleetforgev1.CellSpec{WorldRef: "test-world"},
+				Status: fleetforgev1.CellObservedStatus{Players: players},
+			})
+		}
+
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objects...).
+			WithStatusSubresource(testWorldSpec, &fleetforgev1.Cell{}).
+			Build()
+
+		reconciler := &WorldSpecReconciler{
+			Client:   fakeClient,
+			Scheme:   scheme,
+			Log:      ctrl.Log.WithName("test"),
+			Recorder: record.NewFakeRecorder(10),
+		}
+
+		if err := reconciler.updateWorldSpecStatus(context.Background(), testWorldSpec, reconciler.Log); err != nil {
+			t.Fatalf("updateWorldSpecStatus failed: %v", err)
+		}
+		if testWorldSpec.Status.TotalPlayers != 42 {
+			t.Errorf("Expected 42 total players, got %d", testWorldSpec.Status.TotalPlayers)
+		}
+	})
 }
 
 func TestWorldSpecController_Reconcile(t *testing.T) {
@@ -549,6 +592,46 @@ func TestBoundsOverlap(t *testing.T) {
 	}
 }
 
+func TestScalingArgs(t *testing.T) {
+	args := scalingArgs(fleetforgev1.ScalingConfiguration{
+		ScaleUpThreshold:   0.8,
+		ScaleDownThreshold: 0.2,
+		SplitCooldown:      "2m",
+		SplitHysteresis:    0.1,
+		SustainedBreach:    "30s",
+		MaxCells:           int32Ptr(50),
+		SplitStrategy:      fleetforgev1.SplitStrategyQuad,
+	})
+
+	expected := []string{
+		"--scale-up-threshold=0.800000",
+		"--split-hysteresis=0.100000",
+		"--sustained-breach=30s",
+	}
+	if len(args) != len(expected) {
+		t.Fatalf("scalingArgs() = %v, expected %v", args, expected)
+	}
+	for i := range expected {
+		if args[i] != expected[i] {
+			t.Errorf("scalingArgs()[%d] = %q, expected %q", i, args[i], expected[i])
+		}
+	}
+
+	// Splits, their cooldown and the world's cell budget are the controller's,
+	// so pods only get what decides when to request one
+	for _, arg := range args {
+		for _, controllerFlag := range []string{"--max-cells", "--min-cells", "--split-cooldown", "--split-strategy", "--scale-down-threshold"} {
+			if strings.HasPrefix(arg, controllerFlag) {
+				t.Errorf("Expected %s not to be passed to pods, got %q", controllerFlag, arg)
+			}
+		}
+	}
+
+	if args := scalingArgs(fleetforgev1.ScalingConfiguration{}); len(args) != 0 {
+		t.Errorf("Expected no flags for a default scaling configuration, got %v", args)
+	}
+}
+
 func TestCalculateCellBoundaries(t *testing.T) {
 	yMin := -500.0
 	yMax := 500.0
@@ -567,26 +650,22 @@ func TestCalculateCellBoundaries(t *testing.T) {
 
 	// Verify correct number of cells
 	if len(cells) != 4 {
-		t.Errorf("Expected 4 cells, got %d", len(cells))
+		t.Fatalf("Expected 4 cells, got %d", len(cells))
 	}
 
-	// Verify cells cover the entire space and have correct dimensions
-	expectedCellWidth := 2000.0 / 4.0 // 500.0
+	// A 2D world is partitioned into a 2x2 grid, ordered along X first
+	expected := []struct{ xMin, xMax, yMin, yMax float64 }{
+		{-1000.0, 0.0, -500.0, 0.0},
+		{0.0, 1000.0, -500.0, 0.0},
+		{-1000.0, 0.0, 0.0, 500.0},
+		{0.0, 1000.0, 0.0, 500.0},
+	}
 	for i, cell := range cells {
-		expectedXMin := -1000.0 + float64(i)*expectedCellWidth
-		expectedXMax := expectedXMin + expectedCellWidth
-
-		if cell.XMin != expectedXMin {
-			t.Errorf("Cell %d: expected XMin %f, got %f", i, expectedXMin, cell.XMin)
+		if cell.XMin != expected[i].xMin || cell.XMax != expected[i].xMax {
+			t.Errorf("Cell %d: expected X [%f, %f], got [%f, %f]", i, expected[i].xMin, expected[i].xMax, cell.XMin, cell.XMax)
 		}
-		if cell.XMax != expectedXMax {
-			t.Errorf("Cell %d: expected XMax %f, got %f", i, expectedXMax, cell.XMax)
-		}
-		if cell.YMin == nil || *cell.YMin != yMin {
-			t.Errorf("Cell %d: expected YMin %f, got %v", i, yMin, cell.YMin)
-		}
-		if cell.YMax == nil || *cell.YMax != yMax {
-			t.Errorf("Cell %d: expected YMax %f, got %v", i, yMax, cell.YMax)
+		if cell.YMin == nil || cell.YMax == nil || *cell.YMin != expected[i].yMin || *cell.YMax != expected[i].yMax {
+			t.Errorf("Cell %d: expected Y [%f, %f], got [%v, %v]", i, expected[i].yMin, expected[i].yMax, cell.YMin, cell.YMax)
 		}
 	}
 
@@ -598,6 +677,94 @@ func TestCalculateCellBoundaries(t *testing.T) {
 	}
 }
 
+func TestCalculateCellBoundaries_Grid(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name      string
+		topology  fleetforgev1.WorldTopology
+		wantCells int
+		wantWidth float64
+	}{
+		{
+			name: "1D world is split into strips",
+			topology: fleetforgev1.WorldTopology{
+				InitialCells:    5,
+				WorldBoundaries: fleetforgev1.WorldBounds{XMin: 0, XMax: 1000},
+			},
+			wantCells: 5,
+			wantWidth: 200,
+		},
+		{
+			name: "Prime cell count in 2D falls back to strips",
+			topology: fleetforgev1.WorldTopology{
+				InitialCells:    7,
+				WorldBoundaries: fleetforgev1.WorldBounds{XMin: 0, XMax: 700, YMin: ptr(0), YMax: ptr(700)},
+			},
+			wantCells: 7,
+			wantWidth: 100,
+		},
+		{
+			name: "More columns along the longer axis",
+			topology: fleetforgev1.WorldTopology{
+				InitialCells:    6,
+				WorldBoundaries: fleetforgev1.WorldBounds{XMin: 0, XMax: 3000, YMin: ptr(0), YMax: ptr(1000)},
+			},
+			wantCells: 6,
+			wantWidth: 1000,
+		},
+		{
+			name: "3D world is split into a cube",
+			topology: fleetforgev1.WorldTopology{
+				InitialCells: 8,
+				WorldBoundaries: fleetforgev1.WorldBounds{
+					XMin: 0, XMax: 1000, YMin: ptr(0), YMax: ptr(1000), ZMin: ptr(0), ZMax: ptr(1000),
+				},
+			},
+			wantCells: 8,
+			wantWidth: 500,
+		},
+		{
+			name: "CellSize overrides InitialCells",
+			topology: fleetforgev1.WorldTopology{
+				InitialCells:    2,
+				WorldBoundaries: fleetforgev1.WorldBounds{XMin: -1000, XMax: 1000, YMin: ptr(-1000), YMax: ptr(1000)},
+				CellSize:        &fleetforgev1.WorldBounds{XMin: 0, XMax: 500, YMin: ptr(0), YMax: ptr(1000)},
+			},
+			wantCells: 8,
+			wantWidth: 500,
+		},
+		{
+			name: "CellSize rounds up to keep cells no larger than requested",
+			topology: fleetforgev1.WorldTopology{
+				InitialCells:    1,
+				WorldBoundaries: fleetforgev1.WorldBounds{XMin: 0, XMax: 1000},
+				CellSize:        &fleetforgev1.WorldBounds{XMin: 0, XMax: 300},
+			},
+			wantCells: 4,
+			wantWidth: 250,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cells := calculateCellBoundaries(tt.topology)
+			if len(cells) != tt.wantCells {
+				t.Fatalf("Expected %d cells, got %d", tt.wantCells, len(cells))
+			}
+			if int(tt.topology.CellCount()) != len(cells) {
+				t.Errorf("Expected CellCount %d to match the partition, got %d", len(cells), tt.topology.CellCount())
+			}
+			if width := cells[0].XMax - cells[0].XMin; width != tt.wantWidth {
+				t.Errorf("Expected cell width %f, got %f", tt.wantWidth, width)
+			}
+			if err := validateCellPartitioning(tt.topology.WorldBoundaries, cells, 1e-6); err != nil {
+				t.Errorf("Validation failed for calculated cell boundaries: %v", err)
+			}
+		})
+	}
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substring string) bool {
 	return len(s) >= len(substring) &&
@@ -640,8 +807,12 @@ func TestManualSplitOverride(t *testing.T) {
 				},
 				ManagedFields: []metav1.ManagedFieldsEntry{
 					{
-						Manager: "test-user",
-						Time:    &metav1.Time{Time: time.Now()},
+						Manager:    "test-user",
+						Operation:  metav1.ManagedFieldsOperationUpdate,
+						APIVersion: fleetforgev1.GroupVersion.String(),
+						FieldsType: "FieldsV1",
+						FieldsV1:   &metav1.FieldsV1{Raw: []byte("{}")},
+						Time:       &metav1.Time{Time: time.Now()},
 					},
 				},
 			},
@@ -756,3 +927,856 @@ func TestManualSplitOverride(t *testing.T) {
 		}
 	})
 }
+
+// TestReconcileManagesCellTopology tests that manual splits and merges are
+// recorded on the world's Cell resources with their lineage
+func TestReconcileManagesCellTopology(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	yMin := -1000.0
+	yMax := 1000.0
+	worldSpec := &fleetforgev1.WorldSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-world",
+			Namespace: "default",
+		},
+		Spec: fleetforgev1.WorldSpecSpec{
+			Topology: fleetforgev1.WorldTopology{
+				InitialCells: 2,
+				WorldBoundaries: fleetforgev1.WorldBounds{
+					XMin: -1000.0,
+					XMax: 1000.0,
+					YMin: &yMin,
+					YMax: &yMax,
+				},
+			},
+			Capacity: fleetforgev1.CellCapacity{
+				MaxPlayersPerCell:  100,
+				CPULimitPerCell:    "1000m",
+				MemoryLimitPerCell: "2Gi",
+			},
+			GameServerImage: "fleetforge-cell:latest",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(worldSpec.DeepCopy()).
+		WithStatusSubresource(&fleetforgev1.WorldSpec{}, &fleetforgev1.Cell{}).
+		Build()
+
+	reconciler := &WorldSpecReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: record.NewFakeRecorder(100),
+	}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "test-world", Namespace: "default"}}
+
+	reconcileWith := func(annotation, value string) {
+		t.Helper()
+		if annotation != "" {
+			current := &fleetforgev1.WorldSpec{}
+			if err := fakeClient.Get(ctx, req.NamespacedName, current); err != nil {
+				t.Fatalf("Failed to get WorldSpec: %v", err)
+			}
+			current.Annotations = map[string]string{annotation: value}
+			if err := fakeClient.Update(ctx, current); err != nil {
+				t.Fatalf("Failed to annotate WorldSpec: %v", err)
+			}
+		}
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile failed: %v", err)
+		}
+
+		updated := &fleetforgev1.WorldSpec{}
+		if err := fakeClient.Get(ctx, req.NamespacedName, updated); err != nil {
+			t.Fatalf("Failed to get WorldSpec: %v", err)
+		}
+		if _, exists := updated.Annotations[annotation]; annotation != "" && exists {
+			t.Errorf("Expected annotation %s to be removed", annotation)
+		}
+		if len(updated.Status.Cells) != 0 {
+			t.Errorf("Expected per-cell status to stay out of the WorldSpec, got %d cells", len(updated.Status.Cells))
+		}
+	}
+
+	getCells := func() map[string]fleetforgev1.Cell {
+		t.Helper()
+		cellList := &fleetforgev1.CellList{}
+		if err := fakeClient.List(ctx, cellList, client.InNamespace("default")); err != nil {
+			t.Fatalf("Failed to list cells: %v", err)
+		}
+		cells := make(map[string]fleetforgev1.Cell)
+		for _, cellObj := range cellList.Items {
+			cells[cellObj.Name] = cellObj
+		}
+		return cells
+	}
+
+	expectLiveCells := func(expected ...string) map[string]fleetforgev1.Cell {
+		t.Helper()
+		cells := getCells()
+		var live []string
+		for name, cellObj := range cells {
+			if isLive(&cellObj) {
+				live = append(live, name)
+			}
+		}
+		sort.Strings(live)
+		sort.Strings(expected)
+		if !reflect.DeepEqual(live, expected) {
+			t.Errorf("Expected live cells %v, got %v", expected, live)
+		}
+		return cells
+	}
+
+	reconcileWith("", "")
+	cells := expectLiveCells("test-world-cell-0", "test-world-cell-1")
+	root := cells["test-world-cell-0"]
+	if root.Spec.WorldRef != "test-world" || len(root.OwnerReferences) != 1 || root.OwnerReferences[0].Kind != "WorldSpec" {
+		t.Errorf("Expected cells to be owned by the world, got %+v", root.ObjectMeta)
+	}
+
+	// Splitting keeps the parent for its lineage and adds its children
+	reconcileWith(ForceSplitAnnotation, "test-world-cell-0")
+	cells = expectLiveCells("test-world-cell-0-child-1", "test-world-cell-0-child-2", "test-world-cell-1")
+
+	parent := cells["test-world-cell-0"]
+	if !reflect.DeepEqual(parent.Spec.ChildIDs, []string{"test-world-cell-0-child-1", "test-world-cell-0-child-2"}) {
+		t.Errorf("Expected the parent to list its children, got %v", parent.Spec.ChildIDs)
+	}
+	child := cells["test-world-cell-0-child-1"]
+	if child.Spec.ParentID != "test-world-cell-0" || child.Spec.Generation != 1 ||
+		!reflect.DeepEqual(child.Spec.SiblingIDs, []string{"test-world-cell-0-child-2"}) {
+		t.Errorf("Expected child lineage under test-world-cell-0, got %+v", child.Spec)
+	}
+
+	// The split topology survives further reconciles
+	reconcileWith("", "")
+	expectLiveCells("test-world-cell-0-child-1", "test-world-cell-0-child-2", "test-world-cell-1")
+
+	// A cell whose children have been split cannot be merged
+	reconcileWith(ForceSplitAnnotation, "test-world-cell-0-child-1")
+	reconcileWith(ForceMergeAnnotation, "test-world-cell-0")
+	expectLiveCells("test-world-cell-0-child-1-child-1", "test-world-cell-0-child-1-child-2",
+		"test-world-cell-0-child-2", "test-world-cell-1")
+
+	// Merging innermost first restores the original cell. The merged children
+	// are kept to hand their players off to it, which the Cell controller does.
+	reconcileWith(ForceMergeAnnotation, "test-world-cell-0-child-1, test-world-cell-0")
+	cells = expectLiveCells("test-world-cell-0", "test-world-cell-1")
+	mergedInto := map[string]string{
+		"test-world-cell-0-child-1-child-1": "test-world-cell-0-child-1",
+		"test-world-cell-0-child-1-child-2": "test-world-cell-0-child-1",
+		"test-world-cell-0-child-1":         "test-world-cell-0",
+		"test-world-cell-0-child-2":         "test-world-cell-0",
+	}
+	for childID, parentID := range mergedInto {
+		if child := cells[childID]; child.Spec.MergedInto != parentID {
+			t.Errorf("Expected %s to be merged into %s, got %q", childID, parentID, child.Spec.MergedInto)
+		}
+	}
+	merged := cells["test-world-cell-0"]
+	if merged.Spec.Boundaries.XMin != -1000 || merged.Spec.Boundaries.XMax != 0 {
+		t.Errorf("Expected the merged cell to keep its bounds, got %+v", merged.Spec.Boundaries)
+	}
+	if _, tracked := merged.Annotations[lineageChangedAnnotation]; !tracked {
+		t.Error("Expected the merge to be recorded in the merged cell's lineage")
+	}
+
+	// Its children cannot be recreated while the merged ones hand off
+	reconcileWith(ForceSplitAnnotation, "test-world-cell-0")
+	expectLiveCells("test-world-cell-0", "test-world-cell-1")
+}
+
+// TestManualOverridesRespectCellBudget tests that manual splits and merges are
+// held to the world's MinCells and MaxCells across all of its live cells
+func TestManualOverridesRespectCellBudget(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	yMin := -1000.0
+	yMax := 1000.0
+	worldSpec := &fleetforgev1.WorldSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "budget-world",
+			Namespace: "default",
+		},
+		Spec: fleetforgev1.WorldSpecSpec{
+			Topology: fleetforgev1.WorldTopology{
+				InitialCells: 2,
+				WorldBoundaries: fleetforgev1.WorldBounds{
+					XMin: -1000.0,
+					XMax: 1000.0,
+					YMin: &yMin,
+					YMax: &yMax,
+				},
+			},
+			Capacity: fleetforgev1.CellCapacity{
+				MaxPlayersPerCell: 100,
+			},
+			Scaling: fleetforgev1.ScalingConfiguration{
+				MinCells: int32Ptr(3),
+				MaxCells: int32Ptr(3),
+			},
+			GameServerImage: "fleetforge-cell:latest",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(worldSpec.DeepCopy()).
+		WithStatusSubresource(&fleetforgev1.WorldSpec{}, &fleetforgev1.Cell{}).
+		Build()
+
+	// The controller's cell manager has no budget of its own; the world's applies
+
+	recorder := record.NewFakeRecorder(100)
+	reconciler := &WorldSpecReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: recorder,
+	}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "budget-world", Namespace: "default"}}
+	reconcileWith := func(annotation, value string) {
+		t.Helper()
+		current := &fleetforgev1.WorldSpec{}
+		if err := fakeClient.Get(ctx, req.NamespacedName, current); err != nil {
+			t.Fatalf("Failed to get WorldSpec: %v", err)
+		}
+		current.Annotations = map[string]string{annotation: value}
+		if err := fakeClient.Update(ctx, current); err != nil {
+			t.Fatalf("Failed to annotate WorldSpec: %v", err)
+		}
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile failed: %v", err)
+		}
+	}
+	liveCellCount := func() int {
+		t.Helper()
+		cellList := &fleetforgev1.CellList{}
+		if err := fakeClient.List(ctx, cellList, client.InNamespace("default")); err != nil {
+			t.Fatalf("Failed to list cells: %v", err)
+		}
+		return len(liveCells(cellList.Items))
+	}
+	denials := func() float64 {
+		return testutil.ToFloat64(splitBudgetDenials.WithLabelValues("default", "budget-world"))
+	}
+
+	// The first split brings the world to maxCells
+	reconcileWith(ForceSplitAnnotation, "budget-world-cell-0")
+	if live := liveCellCount(); live != 3 {
+		t.Fatalf("Expected 3 live cells after the first split, got %d", live)
+	}
+
+	// Splitting another cell would exceed it, however many cells the manager holds
+	before := denials()
+	reconcileWith(ForceSplitAnnotation, "budget-world-cell-1")
+	if live := liveCellCount(); live != 3 {
+		t.Errorf("Expected the denied split to leave 3 live cells, got %d", live)
+	}
+	if denied := denials() - before; denied != 1 {
+		t.Errorf("Expected one split budget denial to be counted, got %v", denied)
+	}
+
+	deniedEvent := false
+	for len(recorder.Events) > 0 {
+		if event := <-recorder.Events; strings.Contains(event, string(cell.CellEventSplitDenied)) {
+			deniedEvent = true
+		}
+	}
+	if !deniedEvent {
+		t.Error("Expected a split denied event on the world")
+	}
+
+	// Merging back would fall below minCells
+	reconcileWith(ForceMergeAnnotation, "budget-world-cell-0")
+	if live := liveCellCount(); live != 3 {
+		t.Errorf("Expected the denied merge to leave 3 live cells, got %d", live)
+	}
+}
+
+func TestRequestedSplits(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	yMin := -1000.0
+	yMax := 1000.0
+	worldSpec := &fleetforgev1.WorldSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "requested-world",
+			Namespace: "default",
+		},
+		Spec: fleetforgev1.WorldSpecSpec{
+			Topology: fleetforgev1.WorldTopology{
+				InitialCells: 2,
+				WorldBoundaries: fleetforgev1.WorldBounds{
+					XMin: -1000.0,
+					XMax: 1000.0,
+					YMin: &yMin,
+					YMax: &yMax,
+				},
+			},
+			Capacity: fleetforgev1.CellCapacity{
+				MaxPlayersPerCell: 100,
+			},
+			Scaling: fleetforgev1.ScalingConfiguration{
+				MaxCells:      int32Ptr(3),
+				SplitCooldown: "10m",
+			},
+			GameServerImage: "fleetforge-cell:latest",
+		},
+	}
+
+	// Both pods ask for a split, but only the older cell is past the cooldown
+	requestingCell := func(name string, created time.Time) *fleetforgev1.Cell {
+		return &fleetforgev1.Cell{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				Labels:            cellLabels("requested-world", name),
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec:   fleetforgev1.CellSpec{WorldRef: "requested-world"},
+			Status: fleetforgev1.CellObservedStatus{Phase: "Running", SplitRequested: true},
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			worldSpec.DeepCopy(),
+			requestingCell("requested-world-cell-0", time.Now().Add(-time.Hour)),
+			requestingCell("requested-world-cell-1", time.Now().Add(-time.Minute)),
+		).
+		WithStatusSubresource(&fleetforgev1.WorldSpec{}, &fleetforgev1.Cell{}).
+		Build()
+
+	recorder := record.NewFakeRecorder(100)
+	reconciler := &WorldSpecReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: recorder,
+	}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "requested-world", Namespace: "default"}}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	cellList := &fleetforgev1.CellList{}
+	if err := fakeClient.List(ctx, cellList, client.InNamespace("default")); err != nil {
+		t.Fatalf("Failed to list cells: %v", err)
+	}
+	if live := liveCells(cellList.Items); len(live) != 3 {
+		t.Fatalf("Expected the split to leave 3 live cells, got %d", len(live))
+	}
+	children := 0
+	for _, cellObj := range cellList.Items {
+		switch {
+		case cellObj.Name == "requested-world-cell-0":
+			if !cellObj.Spec.IsSplit() {
+				t.Error("Expected the cell past its cooldown to be split")
+			}
+		case cellObj.Name == "requested-world-cell-1":
+			if cellObj.Spec.IsSplit() {
+				t.Error("Expected the cell within its cooldown not to be split")
+			}
+		case cellObj.Spec.ParentID == "requested-world-cell-0":
+			children++
+		}
+	}
+	if children != 2 {
+		t.Errorf("Expected 2 children of the split cell, got %d", children)
+	}
+
+	splitEvent := false
+	for len(recorder.Events) > 0 {
+		if event := <-recorder.Events; strings.Contains(event, "CellSplit") {
+			splitEvent = true
+		}
+	}
+	if !splitEvent {
+		t.Error("Expected a split event on the world")
+	}
+
+	// Once its cooldown has passed the other cell would exceed maxCells
+	current := &fleetforgev1.WorldSpec{}
+	if err := fakeClient.Get(ctx, req.NamespacedName, current); err != nil {
+		t.Fatalf("Failed to get WorldSpec: %v", err)
+	}
+	current.Spec.Scaling.SplitCooldown = "30s"
+	if err := fakeClient.Update(ctx, current); err != nil {
+		t.Fatalf("Failed to update WorldSpec: %v", err)
+	}
+
+	before := testutil.ToFloat64(splitBudgetDenials.WithLabelValues("default", "requested-world"))
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if denied := testutil.ToFloat64(splitBudgetDenials.WithLabelValues("default", "requested-world")) - before; denied != 1 {
+		t.Errorf("Expected one split budget denial to be counted, got %v", denied)
+	}
+	if err := fakeClient.List(ctx, cellList, client.InNamespace("default")); err != nil {
+		t.Fatalf("Failed to list cells: %v", err)
+	}
+	if live := liveCells(cellList.Items); len(live) != 3 {
+		t.Errorf("Expected the denied split to leave 3 live cells, got %d", len(live))
+	}
+}
+
+// TestSplitsUseEachWorldsStrategy tests that one reconciler splits the cells of
+// each world with that world's own split strategy
+func TestSplitsUseEachWorldsStrategy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	yMin := -1000.0
+	yMax := 1000.0
+	world := func(name, strategy string) *fleetforgev1.WorldSpec {
+		return &fleetforgev1.WorldSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Annotations: map[string]string{ForceSplitAnnotation: name + "-cell-0"},
+			},
+			Spec: fleetforgev1.WorldSpecSpec{
+				Topology: fleetforgev1.WorldTopology{
+					InitialCells: 1,
+					WorldBoundaries: fleetforgev1.WorldBounds{
+						XMin: -1000.0,
+						XMax: 1000.0,
+						YMin: &yMin,
+						YMax: &yMax,
+					},
+				},
+				Capacity: fleetforgev1.CellCapacity{
+					MaxPlayersPerCell: 100,
+				},
+				Scaling: fleetforgev1.ScalingConfiguration{
+					SplitStrategy: strategy,
+				},
+				GameServerImage: "fleetforge-cell:latest",
+			},
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(world("quad-world", fleetforgev1.SplitStrategyQuad), world("axis-world", fleetforgev1.SplitStrategyLongestAxis)).
+		WithStatusSubresource(&fleetforgev1.WorldSpec{}, &fleetforgev1.Cell{}).
+		Build()
+
+	reconciler := &WorldSpecReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: record.NewFakeRecorder(100),
+	}
+
+	ctx := context.Background()
+	expectedChildren := map[string]int{"quad-world": 4, "axis-world": 2}
+	for _, name := range []string{"quad-world", "axis-world"} {
+		req := ctrl.Request{NamespacedName: client.ObjectKey{Name: name, Namespace: "default"}}
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile of %s failed: %v", name, err)
+		}
+	}
+
+	for name, expected := range expectedChildren {
+		parent := &fleetforgev1.Cell{}
+		if err := fakeClient.Get(ctx, client.ObjectKey{Name: name + "-cell-0", Namespace: "default"}, parent); err != nil {
+			t.Fatalf("Failed to get cell of %s: %v", name, err)
+		}
+		if len(parent.Spec.ChildIDs) != expected {
+			t.Errorf("Expected %s to split its cell into %d children, got %v", name, expected, parent.Spec.ChildIDs)
+		}
+	}
+}
+
+// TestRequestedSplitBalancesReportedPlayers tests that a player-median split
+// places its cut from the player positions the cell's pod reports
+func TestRequestedSplitBalancesReportedPlayers(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	yMin := -1000.0
+	yMax := 1000.0
+	worldSpec := &fleetforgev1.WorldSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "median-world", Namespace: "default"},
+		Spec: fleetforgev1.WorldSpecSpec{
+			Topology: fleetforgev1.WorldTopology{
+				InitialCells: 1,
+				WorldBoundaries: fleetforgev1.WorldBounds{
+					XMin: -1000.0,
+					XMax: 1000.0,
+					YMin: &yMin,
+					YMax: &yMax,
+				},
+			},
+			Capacity: fleetforgev1.CellCapacity{MaxPlayersPerCell: 100},
+			Scaling: fleetforgev1.ScalingConfiguration{
+				SplitStrategy: fleetforgev1.SplitStrategyPlayerMedian,
+			},
+			GameServerImage: "fleetforge-cell:latest",
+		},
+	}
+
+	// The players crowd the east of the cell, spread out along X
+	var positions []fleetforgev1.PlayerPosition
+	for i := 0; i < 10; i++ {
+		positions = append(positions, fleetforgev1.PlayerPosition{X: 200 + float64(i)*50, Y: 10})
+	}
+	cellObj := &fleetforgev1.Cell{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "median-world-cell-0",
+			Namespace:         "default",
+			Labels:            cellLabels("median-world", "median-world-cell-0"),
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+		Spec: fleetforgev1.CellSpec{WorldRef: "median-world", Boundaries: worldSpec.Spec.Topology.WorldBoundaries},
+		Status: fleetforgev1.CellObservedStatus{
+			Phase:           "Running",
+			Players:         10,
+			SplitRequested:  true,
+			PlayerPositions: positions,
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(worldSpec, cellObj).
+		WithStatusSubresource(&fleetforgev1.WorldSpec{}, &fleetforgev1.Cell{}).
+		Build()
+
+	recorder := record.NewFakeRecorder(100)
+	reconciler := &WorldSpecReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: recorder,
+	}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "median-world", Namespace: "default"}}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	child := &fleetforgev1.Cell{}
+	if err := fakeClient.Get(ctx, client.ObjectKey{Name: "median-world-cell-0-child-1", Namespace: "default"}, child); err != nil {
+		t.Fatalf("Failed to get child cell: %v", err)
+	}
+	// Five players either side of the median, between x=400 and x=450
+	if child.Spec.Boundaries.XMax != 425 {
+		t.Errorf("Expected the cut at the players' median x=425, got bounds %+v", child.Spec.Boundaries)
+	}
+
+	predicted := false
+	for len(recorder.Events) > 0 {
+		if event := <-recorder.Events; strings.Contains(event, string(cell.CellEventSplit)) && strings.Contains(event, "[5 5]") {
+			predicted = true
+		}
+	}
+	if !predicted {
+		t.Error("Expected the split event to record the predicted child populations")
+	}
+}
+
+// TestRequestedSplitsBackOffPerLineage tests that cells that keep re-splitting
+// wait exponentially longer between splits and are flagged when they oscillate
+func TestRequestedSplitsBackOffPerLineage(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	yMin := -1000.0
+	yMax := 1000.0
+	worldBounds := fleetforgev1.WorldBounds{XMin: -1000.0, XMax: 1000.0, YMin: &yMin, YMax: &yMax}
+	worldSpec := &fleetforgev1.WorldSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "backoff-world", Namespace: "default"},
+		Spec: fleetforgev1.WorldSpecSpec{
+			Topology:        fleetforgev1.WorldTopology{InitialCells: 1, WorldBoundaries: worldBounds},
+			Capacity:        fleetforgev1.CellCapacity{MaxPlayersPerCell: 100},
+			Scaling:         fleetforgev1.ScalingConfiguration{SplitCooldown: "10m"},
+			GameServerImage: "fleetforge-cell:latest",
+		},
+	}
+
+	now := time.Now()
+	ago := func(d time.Duration) string { return now.Add(-d).UTC().Format(time.RFC3339) }
+	parent := &fleetforgev1.Cell{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backoff-world-cell-0",
+			Namespace: "default",
+			Labels:    cellLabels("backoff-world", "backoff-world-cell-0"),
+		},
+		Spec: fleetforgev1.CellSpec{
+			WorldRef:   "backoff-world",
+			Boundaries: worldBounds,
+			ChildIDs:   []string{"backoff-world-cell-0-child-1", "backoff-world-cell-0-child-2"},
+		},
+	}
+	// Both children split 15 minutes ago. The first is already backed off to a
+	// 20 minute cooldown; the second has split twice within the hour before.
+	child := func(n int, bounds fleetforgev1.WorldBounds, annotations map[string]string) *fleetforgev1.Cell {
+		name := fmt.Sprintf("backoff-world-cell-0-child-%d", n)
+		return &fleetforgev1.Cell{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Labels:      cellLabels("backoff-world", name),
+				Annotations: annotations,
+			},
+			Spec:   fleetforgev1.CellSpec{WorldRef: "backoff-world", Boundaries: bounds, ParentID: "backoff-world-cell-0", Generation: 1},
+			Status: fleetforgev1.CellObservedStatus{Phase: "Running", SplitRequested: true},
+		}
+	}
+	west, east := worldBounds, worldBounds
+	west.XMax, east.XMin = 0, 0
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(worldSpec, parent,
+			child(1, west, map[string]string{
+				splitBackoffAnnotation:   "1",
+				lineageChangedAnnotation: ago(15 * time.Minute),
+			}),
+			child(2, east, map[string]string{
+				splitBackoffAnnotation:   "0",
+				lineageChangedAnnotation: ago(15 * time.Minute),
+				lineageChangesAnnotation: ago(50*time.Minute) + "," + ago(30*time.Minute),
+			}),
+		).
+		WithStatusSubresource(&fleetforgev1.WorldSpec{}, &fleetforgev1.Cell{}).
+		Build()
+
+	recorder := record.NewFakeRecorder(100)
+	reconciler := &WorldSpecReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: recorder,
+	}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "backoff-world", Namespace: "default"}}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	getCell := func(name string) *fleetforgev1.Cell {
+		t.Helper()
+		cellObj := &fleetforgev1.Cell{}
+		if err := fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: "default"}, cellObj); err != nil {
+			t.Fatalf("Failed to get cell %s: %v", name, err)
+		}
+		return cellObj
+	}
+
+	if getCell("backoff-world-cell-0-child-1").Spec.IsSplit() {
+		t.Error("Expected the backed off cell to wait out its doubled cooldown")
+	}
+	resplit := getCell("backoff-world-cell-0-child-2")
+	if !resplit.Spec.IsSplit() {
+		t.Fatal("Expected the cell past its cooldown to be split")
+	}
+	if _, tracked := resplit.Annotations[lineageChangesAnnotation]; tracked {
+		t.Error("Expected the oscillation count to start afresh once flagged")
+	}
+
+	// It split again within twice its cooldown, so its children back off further
+	grandchild := getCell(resplit.Spec.ChildIDs[0])
+	if level := grandchild.Annotations[splitBackoffAnnotation]; level != "1" {
+		t.Errorf("Expected the re-split's children at backoff level 1, got %q", level)
+	}
+	if cooldown := splitCooldownFor(10*time.Minute, splitBackoffLevel(grandchild)); cooldown != 20*time.Minute {
+		t.Errorf("Expected a 20m cooldown at backoff level 1, got %s", cooldown)
+	}
+
+	oscillating := false
+	for len(recorder.Events) > 0 {
+		if event := <-recorder.Events; strings.Contains(event, string(cell.CellEventOscillating)) {
+			oscillating = true
+		}
+	}
+	if !oscillating {
+		t.Error("Expected a third split within the oscillation window to flag the cell")
+	}
+}
+
+func TestUnderloadedChildrenMergeBackIntoParent(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	worldBounds := fleetforgev1.WorldBounds{XMin: -1000.0, XMax: 1000.0}
+	worldSpec := &fleetforgev1.WorldSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "merge-world", Namespace: "default"},
+		Spec: fleetforgev1.WorldSpecSpec{
+			Topology: fleetforgev1.WorldTopology{InitialCells: 1, WorldBoundaries: worldBounds},
+			Capacity: fleetforgev1.CellCapacity{MaxPlayersPerCell: 100},
+			Scaling: fleetforgev1.ScalingConfiguration{
+				ScaleUpThreshold:   0.8,
+				ScaleDownThreshold: 0.3,
+				SplitCooldown:      "5m",
+				MergeHysteresis:    "10m",
+			},
+			GameServerImage: "fleetforge-cell:latest",
+		},
+	}
+
+	now := time.Now()
+	ago := func(d time.Duration) string { return now.Add(-d).UTC().Format(time.RFC3339) }
+	childIDs := []string{"merge-world-cell-0-child-1", "merge-world-cell-0-child-2"}
+	objects := []client.Object{
+		worldSpec,
+		&fleetforgev1.Cell{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "merge-world-cell-0",
+				Namespace: "default",
+				Labels:    cellLabels("merge-world", "merge-world-cell-0"),
+			},
+			Spec:   fleetforgev1.CellSpec{WorldRef: "merge-world", Boundaries: worldBounds, ChildIDs: childIDs},
+			Status: fleetforgev1.CellObservedStatus{Phase: "Split"},
+		},
+	}
+	for i, childID := range childIDs {
+		objects = append(objects, &fleetforgev1.Cell{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        childID,
+				Namespace:   "default",
+				Labels:      cellLabels("merge-world", childID),
+				Annotations: map[string]string{splitBackoffAnnotation: "0", lineageChangedAnnotation: ago(time.Hour)},
+			},
+			Spec: fleetforgev1.CellSpec{
+				WorldRef:   "merge-world",
+				Boundaries: fleetforgev1.WorldBounds{XMin: -1000.0 + float64(i)*1000.0, XMax: float64(i) * 1000.0},
+				ParentID:   "merge-world-cell-0",
+				Generation: 1,
+			},
+			Status: fleetforgev1.CellObservedStatus{Phase: "Running", Players: 10},
+		})
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&fleetforgev1.WorldSpec{}, &fleetforgev1.Cell{}).
+		Build()
+
+	recorder := record.NewFakeRecorder(100)
+	reconciler := &WorldSpecReconciler{
+		Client:   fakeClient,
+		Scheme:   scheme,
+		Log:      ctrl.Log.WithName("test"),
+		Recorder: recorder,
+	}
+
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "merge-world", Namespace: "default"}}
+	reconcile := func() {
+		t.Helper()
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile failed: %v", err)
+		}
+	}
+	getCell := func(name string) *fleetforgev1.Cell {
+		t.Helper()
+		cellObj := &fleetforgev1.Cell{}
+		if err := fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: "default"}, cellObj); err != nil {
+			t.Fatalf("Failed to get cell %s: %v", name, err)
+		}
+		return cellObj
+	}
+	setPlayers := func(name string, players int32) {
+		t.Helper()
+		cellObj := getCell(name)
+		cellObj.Status.Players = players
+		if err := fakeClient.Status().Update(ctx, cellObj); err != nil {
+			t.Fatalf("Failed to update cell %s: %v", name, err)
+		}
+	}
+	annotate := func(name, key, value string) {
+		t.Helper()
+		cellObj := getCell(name)
+		metav1.SetMetaDataAnnotation(&cellObj.ObjectMeta, key, value)
+		if err := fakeClient.Update(ctx, cellObj); err != nil {
+			t.Fatalf("Failed to update cell %s: %v", name, err)
+		}
+	}
+
+	// 20 players against the parent's 100 is below the 0.3 threshold
+	reconcile()
+	parent := getCell("merge-world-cell-0")
+	if !parent.Spec.IsSplit() {
+		t.Fatal("Expected the children to wait out the merge hysteresis")
+	}
+	if _, tracked := parent.Annotations[underloadedSinceAnnotation]; !tracked {
+		t.Fatal("Expected the underloaded children to be tracked")
+	}
+
+	// Players coming back end the underload
+	setPlayers(childIDs[0], 30)
+	reconcile()
+	if _, tracked := getCell("merge-world-cell-0").Annotations[underloadedSinceAnnotation]; tracked {
+		t.Error("Expected the tracking to end once the children are busy again")
+	}
+
+	// A child born from a recent split or merge holds the merge back
+	setPlayers(childIDs[0], 10)
+	reconcile()
+	annotate("merge-world-cell-0", underloadedSinceAnnotation, ago(11*time.Minute))
+	annotate(childIDs[1], lineageChangedAnnotation, ago(time.Minute))
+	reconcile()
+	if !getCell("merge-world-cell-0").Spec.IsSplit() {
+		t.Fatal("Expected the merge to wait for the split cooldown")
+	}
+
+	annotate(childIDs[1], lineageChangedAnnotation, ago(time.Hour))
+	reconcile()
+	parent = getCell("merge-world-cell-0")
+	if parent.Spec.IsSplit() {
+		t.Fatal("Expected the children to be merged back after the hysteresis")
+	}
+	if _, tracked := parent.Annotations[underloadedSinceAnnotation]; tracked {
+		t.Error("Expected the merged cell to no longer be tracked")
+	}
+	for _, childID := range childIDs {
+		if mergedInto := getCell(childID).Spec.MergedInto; mergedInto != "merge-world-cell-0" {
+			t.Errorf("Expected %s to be merged into its parent, got %q", childID, mergedInto)
+		}
+	}
+
+	merged := false
+	for len(recorder.Events) > 0 {
+		if event := <-recorder.Events; strings.Contains(event, string(cell.CellEventMerged)) && strings.Contains(event, "20 players") {
+			merged = true
+		}
+	}
+	if !merged {
+		t.Error("Expected a CellMerged event for the scale down")
+	}
+}
@@ -0,0 +1,145 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/astrosteveo/fleetforge/pkg/cell"
+)
+
+// DiscoverySource lists the cells the gateway should route to
+type DiscoverySource interface {
+	// Name identifies the source in logs
+	Name() string
+	// Discover returns every cell the source currently knows about
+	Discover(ctx context.Context) ([]*CellInfo, error)
+}
+
+// CellDiscoverer keeps the router in line with a discovery source. Cells the
+// source lists are registered, and unregistered again once it stops listing
+// them; cells registered through the API are left alone. A cell's health and
+// load are only taken from the source when it is registered or its reported
+// health changes, so they do not overwrite what the health checker observed.
+type CellDiscoverer struct {
+	source DiscoverySource
+	router *CellRouter
+	logger Logger
+
+	// discovered maps the cells registered by the source to the health the
+	// source last reported for them
+	discovered map[cell.CellID]bool
+	mutex      sync.Mutex
+}
+
+// NewCellDiscoverer creates a discoverer that registers a source's cells with a router
+func NewCellDiscoverer(source DiscoverySource, router *CellRouter, logger Logger) *CellDiscoverer {
+	if logger == nil {
+		logger = &noOpLogger{}
+	}
+
+	return &CellDiscoverer{
+		source:     source,
+		router:     router,
+		logger:     logger,
+		discovered: make(map[cell.CellID]bool),
+	}
+}
+
+// Sync registers new or moved cells from the source and unregisters the ones
+// it no longer lists. When the source fails nothing is changed.
+func (d *CellDiscoverer) Sync(ctx context.Context) error {
+	cells, err := d.source.Discover(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to discover cells from %s: %w", d.source.Name(), err)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	listed := make(map[cell.CellID]bool, len(cells))
+	for _, cellInfo := range cells {
+		if cellInfo == nil || cellInfo.ID == "" {
+			continue
+		}
+		listed[cellInfo.ID] = true
+
+		current, err := d.router.GetCell(cellInfo.ID)
+		if err != nil || current.Address != cellInfo.Address || current.Port != cellInfo.Port || current.Capacity != cellInfo.Capacity {
+			registered := *cellInfo
+			if err := d.router.RegisterCell(&registered); err != nil {
+				d.logger.Error(err, "failed to register discovered cell", "cellId", cellInfo.ID, "source", d.source.Name())
+				continue
+			}
+		} else if reported, known := d.discovered[cellInfo.ID]; known && reported != cellInfo.Healthy {
+			d.router.UpdateCellHealth(cellInfo.ID, cellInfo.Healthy)
+		}
+		d.discovered[cellInfo.ID] = cellInfo.Healthy
+	}
+
+	for cellID := range d.discovered {
+		if listed[cellID] {
+			continue
+		}
+		delete(d.discovered, cellID)
+		if err := d.router.UnregisterCell(cellID); err == nil {
+			d.logger.Info("discovered cell removed", "cellId", cellID, "source", d.source.Name())
+		}
+	}
+
+	return nil
+}
+
+// staticCell is a cell entry in a static discovery file. Cells are healthy
+// unless the file says otherwise.
+type staticCell struct {
+	CellInfo
+	Healthy *bool `json:"healthy,omitempty"`
+}
+
+// StaticDiscoverySource lists the cells in a JSON file. The file is re-read on
+// every discovery, so cells can be added or removed by editing it.
+type StaticDiscoverySource struct {
+	path string
+}
+
+// NewStaticDiscoverySource creates a source for the cells listed in a JSON file
+func NewStaticDiscoverySource(path string) *StaticDiscoverySource {
+	return &StaticDiscoverySource{path: path}
+}
+
+// Name identifies the source in logs
+func (s *StaticDiscoverySource) Name() string {
+	return "static:" + s.path
+}
+
+// Discover reads the cells from the file
+func (s *StaticDiscoverySource) Discover(ctx context.Context) ([]*CellInfo, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cell file: %w", err)
+	}
+
+	var entries []staticCell
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse cell file %s: %w", s.path, err)
+	}
+
+	cells := make([]*CellInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entry.ID == "" {
+			return nil, fmt.Errorf("cell file %s lists a cell without an id", s.path)
+		}
+		cellInfo := entry.CellInfo
+		cellInfo.Healthy = entry.Healthy == nil || *entry.Healthy
+		cells = append(cells, &cellInfo)
+	}
+
+	sort.Slice(cells, func(i, j int) bool {
+		return cells[i].ID < cells[j].ID
+	})
+	return cells, nil
+}
@@ -0,0 +1,152 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fleetforgev1 "github.com/astrosteveo/fleetforge/api/v1"
+	"github.com/astrosteveo/fleetforge/pkg/cell"
+)
+
+const (
+	// cellAppLabel marks the Services and pods the controller runs for cells
+	cellAppLabel = "fleetforge-cell"
+	// cellHealthPortName is the Service port serving a cell's /health, /status and /ws
+	cellHealthPortName = "health"
+	// defaultDiscoveredCapacity is used when a cell's WorldSpec cannot be read
+	defaultDiscoveredCapacity = 100
+)
+
+// KubernetesDiscoverySource lists the cells the controller runs, from the
+// per-cell Services labelled app=fleetforge-cell. A cell is healthy while one
+// of its pods is ready, and its capacity comes from its WorldSpec.
+type KubernetesDiscoverySource struct {
+	client    client.Reader
+	namespace string
+	world     string
+}
+
+// NewKubernetesDiscoverySource creates a source for the cells in a namespace,
+// optionally only those of one world. An empty namespace covers all namespaces.
+func NewKubernetesDiscoverySource(reader client.Reader, namespace, world string) *KubernetesDiscoverySource {
+	return &KubernetesDiscoverySource{
+		client:    reader,
+		namespace: namespace,
+		world:     world,
+	}
+}
+
+// Name identifies the source in logs
+func (k *KubernetesDiscoverySource) Name() string {
+	if k.world != "" {
+		return fmt.Sprintf("kubernetes:%s/%s", k.namespace, k.world)
+	}
+	return "kubernetes:" + k.namespace
+}
+
+// Discover lists the cell Services and the readiness of their pods
+func (k *KubernetesDiscoverySource) Discover(ctx context.Context) ([]*CellInfo, error) {
+	labels := client.MatchingLabels{"app": cellAppLabel}
+	if k.world != "" {
+		labels["world"] = k.world
+	}
+	opts := []client.ListOption{labels}
+	if k.namespace != "" {
+		opts = append(opts, client.InNamespace(k.namespace))
+	}
+
+	services := &corev1.ServiceList{}
+	if err := k.client.List(ctx, services, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list cell services: %w", err)
+	}
+
+	pods := &corev1.PodList{}
+	if err := k.client.List(ctx, pods, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list cell pods: %w", err)
+	}
+	ready := make(map[string]bool)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if podReady(pod) {
+			ready[pod.Namespace+"/"+pod.Labels["cell-id"]] = true
+		}
+	}
+
+	capacities := make(map[string]int)
+	cells := make([]*CellInfo, 0, len(services.Items))
+	for i := range services.Items {
+		service := &services.Items[i]
+		cellID := service.Labels["cell-id"]
+		port, found := servicePort(service, cellHealthPortName)
+		if cellID == "" || !found {
+			continue
+		}
+
+		address := service.Spec.ClusterIP
+		if address == "" || address == corev1.ClusterIPNone {
+			address = fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+		}
+
+		worldKey := service.Namespace + "/" + service.Labels["world"]
+		capacity, cached := capacities[worldKey]
+		if !cached {
+			capacity = k.worldCapacity(ctx, service.Namespace, service.Labels["world"])
+			capacities[worldKey] = capacity
+		}
+
+		cells = append(cells, &CellInfo{
+			ID:       cell.CellID(cellID),
+			Address:  address,
+			Port:     port,
+			Healthy:  ready[service.Namespace+"/"+cellID],
+			Capacity: capacity,
+		})
+	}
+
+	sort.Slice(cells, func(i, j int) bool {
+		return cells[i].ID < cells[j].ID
+	})
+	return cells, nil
+}
+
+// worldCapacity returns the per-cell player limit of a world
+func (k *KubernetesDiscoverySource) worldCapacity(ctx context.Context, namespace, world string) int {
+	if world == "" {
+		return defaultDiscoveredCapacity
+	}
+	worldSpec := &fleetforgev1.WorldSpec{}
+	if err := k.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: world}, worldSpec); err != nil {
+		return defaultDiscoveredCapacity
+	}
+	if worldSpec.Spec.Capacity.MaxPlayersPerCell <= 0 {
+		return defaultDiscoveredCapacity
+	}
+	return int(worldSpec.Spec.Capacity.MaxPlayersPerCell)
+}
+
+// servicePort returns the port of a Service with the given name
+func servicePort(service *corev1.Service, name string) (int, bool) {
+	for _, port := range service.Spec.Ports {
+		if port.Name == name {
+			return int(port.Port), true
+		}
+	}
+	return 0, false
+}
+
+// podReady reports whether a pod is running and passing its readiness probe
+func podReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fleetforgev1 "github.com/astrosteveo/fleetforge/api/v1"
+)
+
+// fakeDiscoverySource returns whatever cells the test last gave it
+type fakeDiscoverySource struct {
+	mu    sync.Mutex
+	cells []*CellInfo
+	err   error
+}
+
+func (f *fakeDiscoverySource) Name() string {
+	return "fake"
+}
+
+func (f *fakeDiscoverySource) Discover(ctx context.Context) ([]*CellInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.cells, f.err
+}
+
+func (f *fakeDiscoverySource) set(cells []*CellInfo, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cells, f.err = cells, err
+}
+
+func TestCellDiscoverer_Sync(t *testing.T) {
+	source := &fakeDiscoverySource{}
+	router := NewCellRouter(&noOpLogger{})
+	discoverer := NewCellDiscoverer(source, router, nil)
+
+	// A cell registered through the API is not the source's to remove
+	router.RegisterCell(&CellInfo{ID: "manual", Address: "10.0.0.9", Port: 8081, Healthy: true, Capacity: 100})
+
+	source.set([]*CellInfo{
+		{ID: "cell-a", Address: "10.0.0.1", Port: 8081, Healthy: true, Capacity: 100},
+		{ID: "cell-b", Address: "10.0.0.2", Port: 8081, Healthy: false, Capacity: 100},
+	}, nil)
+	if err := discoverer.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if cells := router.GetAvailableCells(); len(cells) != 3 {
+		t.Fatalf("Expected 3 cells after discovery, got %d", len(cells))
+	}
+	if cellB, _ := router.GetCell("cell-b"); cellB.Healthy {
+		t.Error("Expected cell-b to be registered unhealthy")
+	}
+
+	// Load observed since registration survives a sync that changes nothing
+	router.UpdateCellLoad("cell-a", 40, 0.4)
+	if err := discoverer.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if cellA, _ := router.GetCell("cell-a"); cellA.PlayerCount != 40 {
+		t.Errorf("Expected cell-a to keep its load, got %d players", cellA.PlayerCount)
+	}
+
+	// cell-b's pod becomes ready, cell-a moves and cell-c is gone before it
+	// was ever seen
+	source.set([]*CellInfo{
+		{ID: "cell-a", Address: "10.0.0.3", Port: 8081, Healthy: true, Capacity: 100},
+		{ID: "cell-b", Address: "10.0.0.2", Port: 8081, Healthy: true, Capacity: 100},
+	}, nil)
+	if err := discoverer.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if cellA, _ := router.GetCell("cell-a"); cellA.Address != "10.0.0.3" {
+		t.Errorf("Expected cell-a to be re-registered at its new address, got %s", cellA.Address)
+	}
+	if cellB, _ := router.GetCell("cell-b"); !cellB.Healthy {
+		t.Error("Expected cell-b to become healthy")
+	}
+
+	// A failing source leaves the router as it was
+	source.set(nil, errors.New("api server unavailable"))
+	if err := discoverer.Sync(context.Background()); err == nil {
+		t.Error("Expected a source error to be returned")
+	}
+	if cells := router.GetAvailableCells(); len(cells) != 3 {
+		t.Errorf("Expected a failed sync to keep 3 cells, got %d", len(cells))
+	}
+
+	// Cells the source stops listing are unregistered
+	source.set([]*CellInfo{
+		{ID: "cell-b", Address: "10.0.0.2", Port: 8081, Healthy: true, Capacity: 100},
+	}, nil)
+	if err := discoverer.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if _, err := router.GetCell("cell-a"); err == nil {
+		t.Error("Expected cell-a to be unregistered")
+	}
+	if _, err := router.GetCell("manual"); err != nil {
+		t.Error("Expected the manually registered cell to be kept")
+	}
+}
+
+func TestStaticDiscoverySource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cells.json")
+	source := NewStaticDiscoverySource(path)
+
+	if _, err := source.Discover(context.Background()); err == nil {
+		t.Error("Expected a missing file to fail")
+	}
+
+	contents := `[
+		{"id": "cell-b", "address": "10.0.0.2", "port": 8081, "capacity": 50, "healthy": false},
+		{"id": "cell-a", "address": "10.0.0.1", "port": 8081, "capacity": 100}
+	]`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("Failed to write cell file: %v", err)
+	}
+
+	cells, err := source.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(cells) != 2 || cells[0].ID != "cell-a" || cells[1].ID != "cell-b" {
+		t.Fatalf("Expected cell-a and cell-b, got %+v", cells)
+	}
+	if !cells[0].Healthy || cells[1].Healthy {
+		t.Errorf("Expected cells to be healthy unless the file says otherwise, got %v and %v", cells[0].Healthy, cells[1].Healthy)
+	}
+	if cells[1].Capacity != 50 || cells[1].Address != "10.0.0.2" {
+		t.Errorf("Unexpected cell-b: %+v", cells[1])
+	}
+
+	if err := os.WriteFile(path, []byte(`[{"address": "10.0.0.1"}]`), 0o644); err != nil {
+		t.Fatalf("Failed to write cell file: %v", err)
+	}
+	if _, err := source.Discover(context.Background()); err == nil {
+		t.Error("Expected a cell without an id to be rejected")
+	}
+}
+
+func TestKubernetesDiscoverySource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = fleetforgev1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	cellService := func(namespace, world, cellID, clusterIP string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cellID + "-service",
+				Namespace: namespace,
+				Labels:    map[string]string{"app": "fleetforge-cell", "cell-id": cellID, "world": world},
+			},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: clusterIP,
+				Ports: []corev1.ServicePort{
+					{Name: "metrics", Port: 8080},
+					{Name: "health", Port: 8081},
+				},
+			},
+		}
+	}
+	cellPod := func(namespace, world, cellID string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cellID + "-pod",
+				Namespace: namespace,
+				Labels:    map[string]string{"app": "fleetforge-cell", "cell-id": cellID, "world": world},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+
+	worldSpec := &fleetforgev1.WorldSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "world-1", Namespace: "games"},
+		Spec: fleetforgev1.WorldSpecSpec{
+			Capacity: fleetforgev1.CellCapacity{MaxPlayersPerCell: 250},
+		},
+	}
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			worldSpec,
+			cellService("games", "world-1", "world-1-cell-0", "10.96.0.10"),
+			cellPod("games", "world-1", "world-1-cell-0", corev1.ConditionTrue),
+			cellService("games", "world-1", "world-1-cell-1", corev1.ClusterIPNone),
+			cellPod("games", "world-1", "world-1-cell-1", corev1.ConditionFalse),
+			cellService("games", "world-2", "world-2-cell-0", "10.96.0.20"),
+			cellService("other", "world-1", "other-cell-0", "10.96.0.30"),
+		).
+		Build()
+
+	cells, err := NewKubernetesDiscoverySource(k8sClient, "games", "world-1").Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(cells) != 2 {
+		t.Fatalf("Expected the 2 cells of world-1 in games, got %+v", cells)
+	}
+
+	ready, notReady := cells[0], cells[1]
+	if ready.ID != "world-1-cell-0" || ready.Address != "10.96.0.10" || ready.Port != 8081 || !ready.Healthy || ready.Capacity != 250 {
+		t.Errorf("Unexpected ready cell: %+v", ready)
+	}
+	if notReady.ID != "world-1-cell-1" || notReady.Address != "world-1-cell-1-service.games.svc" || notReady.Healthy {
+		t.Errorf("Unexpected not-ready cell: %+v", notReady)
+	}
+
+	// Without a world filter every cell in the namespace is found, and cells
+	// whose WorldSpec is missing fall back to the default capacity
+	cells, err = NewKubernetesDiscoverySource(k8sClient, "games", "").Discover(context.Background())
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+	if len(cells) != 3 || cells[2].ID != "world-2-cell-0" || cells[2].Capacity != defaultDiscoveredCapacity || cells[2].Healthy {
+		t.Errorf("Unexpected cells for the whole namespace: %+v", cells)
+	}
+}
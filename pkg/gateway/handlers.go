@@ -1,6 +1,7 @@
 package gateway
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -348,6 +349,48 @@ func (s *DefaultGatewayServer) startBackgroundWorkers() {
 			}
 		}
 	}()
+
+	// Cell discovery worker
+	if s.discovery != nil && s.config.CellDiscovery.RefreshInterval > 0 {
+		s.workerGroup.Add(1)
+		go func() {
+			defer s.workerGroup.Done()
+			ticker := time.NewTicker(s.config.CellDiscovery.RefreshInterval)
+			defer ticker.Stop()
+
+			// Find the cells right away rather than waiting out the first interval
+			for {
+				if err := s.discovery.Sync(context.Background()); err != nil {
+					s.logger.Error(err, "cell discovery failed")
+				}
+
+				select {
+				case <-ticker.C:
+				case <-s.stopChan:
+					return
+				}
+			}
+		}()
+	}
+
+	// Cell health check worker
+	if s.config.CellDiscovery.HealthCheck && s.config.CellDiscovery.RefreshInterval > 0 {
+		s.workerGroup.Add(1)
+		go func() {
+			defer s.workerGroup.Done()
+			ticker := time.NewTicker(s.config.CellDiscovery.RefreshInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					s.health.CheckAll(context.Background())
+				case <-s.stopChan:
+					return
+				}
+			}
+		}()
+	}
 }
 
 // cleanupExpiredSessions removes expired sessions
@@ -390,6 +433,9 @@ func (s *DefaultGatewayServer) cleanupStaleConnections() {
 	}
 
 	for _, connID := range staleConnections {
+		if ws := s.connections[connID].WSConn; ws != nil {
+			ws.Close(CloseGoingAway, "idle timeout")
+		}
 		delete(s.connections, connID)
 	}
 
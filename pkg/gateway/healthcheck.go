@@ -0,0 +1,189 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/astrosteveo/fleetforge/pkg/cell"
+)
+
+// cellStatusReport is the part of a cell's /status response the gateway routes on
+type cellStatusReport struct {
+	CurrentPlayers int `json:"currentPlayers"`
+	MaxPlayers     int `json:"maxPlayers"`
+}
+
+// cellProbeState counts a cell's consecutive probe results
+type cellProbeState struct {
+	failures    int
+	successes   int
+	unreachable int
+}
+
+// CellHealthChecker polls registered cells' /health and /status endpoints and
+// keeps the router's health and load in line with what the cells report. A
+// cell is marked unhealthy after FailureThreshold failed probes in a row,
+// healthy again after SuccessThreshold good ones, and unregistered once it has
+// been unreachable for EvictAfter probes.
+type CellHealthChecker struct {
+	router *CellRouter
+	client *http.Client
+	logger Logger
+
+	failureThreshold int
+	successThreshold int
+	evictAfter       int
+
+	probes map[cell.CellID]*cellProbeState
+	mutex  sync.Mutex
+}
+
+// NewCellHealthChecker creates a health checker for the cells in a router
+func NewCellHealthChecker(router *CellRouter, config *GatewayConfig, logger Logger) *CellHealthChecker {
+	if logger == nil {
+		logger = &noOpLogger{}
+	}
+
+	return &CellHealthChecker{
+		router:           router,
+		client:           &http.Client{Timeout: config.CellDiscovery.HealthTimeout},
+		logger:           logger,
+		failureThreshold: max(config.CellDiscovery.FailureThreshold, 1),
+		successThreshold: max(config.CellDiscovery.SuccessThreshold, 1),
+		evictAfter:       config.CellDiscovery.EvictAfter,
+		probes:           make(map[cell.CellID]*cellProbeState),
+	}
+}
+
+// CheckAll probes every registered cell once, concurrently
+func (hc *CellHealthChecker) CheckAll(ctx context.Context) {
+	cells := hc.router.GetAvailableCells()
+
+	var wg sync.WaitGroup
+	for _, cellInfo := range cells {
+		wg.Add(1)
+		go func(cellInfo *CellInfo) {
+			defer wg.Done()
+			hc.checkCell(ctx, cellInfo)
+		}(cellInfo)
+	}
+	wg.Wait()
+
+	// Forget cells that were unregistered since the last round
+	registered := make(map[cell.CellID]bool, len(cells))
+	for _, cellInfo := range cells {
+		registered[cellInfo.ID] = true
+	}
+	hc.mutex.Lock()
+	for cellID := range hc.probes {
+		if !registered[cellID] {
+			delete(hc.probes, cellID)
+		}
+	}
+	hc.mutex.Unlock()
+}
+
+// checkCell probes a single cell and applies the result to the router
+func (hc *CellHealthChecker) checkCell(ctx context.Context, cellInfo *CellInfo) {
+	healthy, reachable, err := hc.probeHealth(ctx, cellInfo)
+	if err != nil {
+		hc.logger.Debug("cell health check failed", "cellId", cellInfo.ID, "error", err.Error())
+	}
+
+	if healthy {
+		if report, err := hc.probeStatus(ctx, cellInfo); err != nil {
+			hc.logger.Debug("cell status check failed", "cellId", cellInfo.ID, "error", err.Error())
+		} else {
+			load := 0.0
+			if report.MaxPlayers > 0 {
+				load = float64(report.CurrentPlayers) / float64(report.MaxPlayers)
+			}
+			hc.router.UpdateCellLoad(cellInfo.ID, report.CurrentPlayers, load)
+		}
+	}
+
+	hc.mutex.Lock()
+	probe, exists := hc.probes[cellInfo.ID]
+	if !exists {
+		probe = &cellProbeState{}
+		hc.probes[cellInfo.ID] = probe
+	}
+	if healthy {
+		probe.successes++
+		probe.failures = 0
+	} else {
+		probe.failures++
+		probe.successes = 0
+	}
+	if reachable {
+		probe.unreachable = 0
+	} else {
+		probe.unreachable++
+	}
+	markHealthy := healthy && !cellInfo.Healthy && probe.successes >= hc.successThreshold
+	markUnhealthy := !healthy && cellInfo.Healthy && probe.failures >= hc.failureThreshold
+	evict := hc.evictAfter > 0 && probe.unreachable >= hc.evictAfter
+	if evict {
+		delete(hc.probes, cellInfo.ID)
+	}
+	hc.mutex.Unlock()
+
+	switch {
+	case evict:
+		if err := hc.router.UnregisterCell(cellInfo.ID); err == nil {
+			hc.logger.Info("evicted unreachable cell", "cellId", cellInfo.ID, "intervals", hc.evictAfter)
+		}
+	case markUnhealthy:
+		hc.router.UpdateCellHealth(cellInfo.ID, false)
+		hc.logger.Info("cell marked unhealthy", "cellId", cellInfo.ID, "failures", hc.failureThreshold)
+	case markHealthy:
+		hc.router.UpdateCellHealth(cellInfo.ID, true)
+		hc.logger.Info("cell marked healthy", "cellId", cellInfo.ID)
+	}
+}
+
+// probeHealth queries a cell's /health endpoint. A cell that answers with an
+// error status is reachable but unhealthy.
+func (hc *CellHealthChecker) probeHealth(ctx context.Context, cellInfo *CellInfo) (healthy, reachable bool, err error) {
+	resp, err := hc.get(ctx, cellInfo, "/health")
+	if err != nil {
+		return false, false, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, true, fmt.Errorf("health endpoint returned %s", resp.Status)
+	}
+	return true, true, nil
+}
+
+// probeStatus fetches a cell's player count and capacity from /status
+func (hc *CellHealthChecker) probeStatus(ctx context.Context, cellInfo *CellInfo) (*cellStatusReport, error) {
+	resp, err := hc.get(ctx, cellInfo, "/status")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status endpoint returned %s", resp.Status)
+	}
+
+	report := &cellStatusReport{}
+	if err := json.NewDecoder(resp.Body).Decode(report); err != nil {
+		return nil, fmt.Errorf("failed to decode status: %w", err)
+	}
+	return report, nil
+}
+
+func (hc *CellHealthChecker) get(ctx context.Context, cellInfo *CellInfo, path string) (*http.Response, error) {
+	url := fmt.Sprintf("http://%s:%d%s", cellInfo.Address, cellInfo.Port, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	return hc.client.Do(req)
+}
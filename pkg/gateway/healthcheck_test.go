@@ -0,0 +1,138 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newProbedCell starts a cell that answers /health with the given status and
+// reports players out of a capacity of 100 on /status
+func newProbedCell(t *testing.T, status *atomic.Int32, players int) (*httptest.Server, *CellInfo) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.WriteHeader(int(status.Load()))
+		case "/status":
+			fmt.Fprintf(w, `{"id":"probed","currentPlayers":%d,"maxPlayers":100}`, players)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	addr := server.Listener.Addr().(*net.TCPAddr)
+	return server, &CellInfo{ID: "probed-cell", Address: addr.IP.String(), Port: addr.Port, Healthy: true, Capacity: 100}
+}
+
+func newTestHealthChecker(failures, successes, evictAfter int) (*CellRouter, *CellHealthChecker) {
+	config := DefaultGatewayConfig()
+	config.CellDiscovery.HealthTimeout = time.Second
+	config.CellDiscovery.FailureThreshold = failures
+	config.CellDiscovery.SuccessThreshold = successes
+	config.CellDiscovery.EvictAfter = evictAfter
+
+	router := NewCellRouter(&noOpLogger{})
+	return router, NewCellHealthChecker(router, config, nil)
+}
+
+func TestCellHealthChecker_UpdatesLoad(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	_, cellInfo := newProbedCell(t, &status, 40)
+
+	router, checker := newTestHealthChecker(3, 2, 0)
+	router.RegisterCell(cellInfo)
+
+	checker.CheckAll(context.Background())
+
+	probed, err := router.GetCell(cellInfo.ID)
+	if err != nil {
+		t.Fatalf("Failed to get cell: %v", err)
+	}
+	if probed.PlayerCount != 40 || probed.Load != 0.4 {
+		t.Errorf("Expected 40 players at load 0.4, got %d at %v", probed.PlayerCount, probed.Load)
+	}
+	if !probed.Healthy {
+		t.Error("Expected the cell to stay healthy")
+	}
+}
+
+func TestCellHealthChecker_Thresholds(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	_, cellInfo := newProbedCell(t, &status, 0)
+
+	router, checker := newTestHealthChecker(3, 2, 1)
+	router.RegisterCell(cellInfo)
+
+	healthy := func() bool {
+		probed, err := router.GetCell(cellInfo.ID)
+		if err != nil {
+			t.Fatalf("Expected the cell to stay registered: %v", err)
+		}
+		return probed.Healthy
+	}
+
+	// A cell that answers but reports unhealthy is never evicted, only marked
+	// unhealthy once the failures add up
+	for i := 1; i <= 3; i++ {
+		checker.CheckAll(context.Background())
+		if expected := i < 3; healthy() != expected {
+			t.Fatalf("After %d failed checks expected healthy=%v", i, expected)
+		}
+	}
+
+	// It takes two good checks in a row to route to it again
+	status.Store(http.StatusOK)
+	checker.CheckAll(context.Background())
+	if healthy() {
+		t.Error("Expected one successful check not to restore the cell")
+	}
+	status.Store(http.StatusServiceUnavailable)
+	checker.CheckAll(context.Background())
+	status.Store(http.StatusOK)
+	checker.CheckAll(context.Background())
+	if healthy() {
+		t.Error("Expected a failure to reset the success count")
+	}
+	checker.CheckAll(context.Background())
+	if !healthy() {
+		t.Error("Expected two successful checks to restore the cell")
+	}
+}
+
+func TestCellHealthChecker_EvictsUnreachableCells(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	server, cellInfo := newProbedCell(t, &status, 0)
+
+	router, checker := newTestHealthChecker(1, 1, 3)
+	router.RegisterCell(cellInfo)
+	checker.CheckAll(context.Background())
+
+	server.Close()
+
+	checker.CheckAll(context.Background())
+	probed, err := router.GetCell(cellInfo.ID)
+	if err != nil || probed.Healthy {
+		t.Fatalf("Expected an unreachable cell to be marked unhealthy first, got %+v (%v)", probed, err)
+	}
+
+	checker.CheckAll(context.Background())
+	if _, err := router.GetCell(cellInfo.ID); err != nil {
+		t.Fatalf("Expected the cell to be kept until it is evicted: %v", err)
+	}
+
+	checker.CheckAll(context.Background())
+	if _, err := router.GetCell(cellInfo.ID); err == nil {
+		t.Error("Expected the cell to be evicted after 3 unreachable checks")
+	}
+}
@@ -0,0 +1,321 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/astrosteveo/fleetforge/pkg/cell"
+)
+
+// cellProxy relays a player's gameplay messages between their WebSocket and
+// the cell their session is assigned to. The link to the cell follows the
+// session affinity: when the player is assigned another cell, the new cell is
+// dialed and the old link is closed without the client reconnecting.
+type cellProxy struct {
+	server   *DefaultGatewayServer
+	conn     *Connection
+	playerID cell.PlayerID
+
+	mu       sync.Mutex
+	cellID   cell.CellID
+	upstream *WSConn
+	closed   bool
+	// handoffPosition is where the cell that handed the player off last saw
+	// them, passed to the cell that takes them over
+	handoffPosition *cell.WorldPosition
+}
+
+func newCellProxy(server *DefaultGatewayServer, conn *Connection, playerID cell.PlayerID) *cellProxy {
+	return &cellProxy{
+		server:   server,
+		conn:     conn,
+		playerID: playerID,
+	}
+}
+
+// forward sends a client message to the player's cell. A failed write is
+// retried once on a fresh link in case the cell restarted.
+func (p *cellProxy) forward(msg WSMessage) error {
+	msg.PlayerID = p.playerID
+
+	var lastErr error
+	for attempt := 0; attempt < 2; attempt++ {
+		upstream, cellID, err := p.route()
+		if err != nil {
+			return err
+		}
+
+		msg.CellID = cellID
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("failed to encode message: %w", err)
+		}
+
+		upstream.SetWriteDeadline(time.Now().Add(p.server.config.WebSocket.WriteTimeout))
+		if lastErr = upstream.WriteMessage(TextMessage, data); lastErr == nil {
+			return nil
+		}
+		p.drop(upstream)
+	}
+	return fmt.Errorf("failed to forward message to cell: %w", lastErr)
+}
+
+// route returns the link to the player's current cell, dialing it if the
+// affinity moved since the last message or the previous link was lost
+func (p *cellProxy) route() (*WSConn, cell.CellID, error) {
+	cellInfo, err := p.server.sessionCell(p.playerID, p.conn.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, "", ErrWebSocketClosed
+	}
+	if p.upstream != nil && p.cellID == cellInfo.ID {
+		upstream := p.upstream
+		p.mu.Unlock()
+		return upstream, cellInfo.ID, nil
+	}
+
+	upstream, err := p.server.dialCell(cellInfo, p.playerID, p.handoffPosition)
+	if err != nil {
+		p.mu.Unlock()
+		return nil, "", err
+	}
+	p.handoffPosition = nil
+
+	previousCell, previous := p.cellID, p.upstream
+	p.cellID, p.upstream = cellInfo.ID, upstream
+	p.mu.Unlock()
+
+	go p.relay(upstream, cellInfo.ID)
+	if previous != nil {
+		previous.Close(CloseGoingAway, "player moved to another cell")
+	}
+
+	p.server.connMutex.Lock()
+	p.conn.CellID = cellInfo.ID
+	p.server.connMutex.Unlock()
+
+	if previousCell != "" && previousCell != cellInfo.ID {
+		p.server.logger.Info("player rerouted",
+			"playerId", p.playerID,
+			"fromCell", previousCell,
+			"toCell", cellInfo.ID)
+		p.server.sendWebSocket(p.conn, WSMessage{
+			Type:     WSMessageCellChanged,
+			PlayerID: p.playerID,
+			CellID:   cellInfo.ID,
+		})
+	}
+
+	return upstream, cellInfo.ID, nil
+}
+
+// relay fans messages from a cell out to the player until the link closes
+func (p *cellProxy) relay(upstream *WSConn, cellID cell.CellID) {
+	for {
+		messageType, data, err := upstream.ReadMessage()
+		if err != nil {
+			p.drop(upstream)
+			var closeErr *CloseError
+			if !errors.As(err, &closeErr) || closeErr.Code != CloseGoingAway {
+				p.server.logger.Debug("cell link closed",
+					"playerId", p.playerID,
+					"cellId", cellID,
+					"error", err.Error())
+			}
+			return
+		}
+		if messageType != TextMessage {
+			continue
+		}
+
+		var msg WSMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			p.server.logger.Debug("dropping invalid message from cell", "cellId", cellID, "error", err.Error())
+			continue
+		}
+		if msg.Type == WSMessageHandoff {
+			var handoff struct {
+				Position *cell.WorldPosition `json:"position"`
+			}
+			if json.Unmarshal(msg.Payload, &handoff) == nil && handoff.Position != nil {
+				p.mu.Lock()
+				p.handoffPosition = handoff.Position
+				p.mu.Unlock()
+			}
+
+			// The cell moved the player; route() closes this link once the new
+			// one is open
+			if err := p.server.HandoffSession(p.playerID, cellID, msg.CellID); err != nil {
+				p.server.logger.Error(err, "player handoff failed", "playerId", p.playerID, "fromCell", cellID, "toCell", msg.CellID)
+			}
+			continue
+		}
+		if msg.Type == "" {
+			msg.Type = WSMessageGameplay
+		}
+		msg.PlayerID = p.playerID
+		msg.CellID = cellID
+
+		if err := p.server.sendWebSocket(p.conn, msg); err != nil {
+			upstream.Close(CloseGoingAway, "player disconnected")
+			return
+		}
+	}
+}
+
+// drop forgets a link that failed, unless it was already replaced
+func (p *cellProxy) drop(upstream *WSConn) {
+	p.mu.Lock()
+	if p.upstream == upstream {
+		p.upstream = nil
+	}
+	p.mu.Unlock()
+	upstream.closeConn()
+}
+
+// close shuts down the link to the cell once the player disconnects
+func (p *cellProxy) close() {
+	p.mu.Lock()
+	p.closed = true
+	upstream := p.upstream
+	p.upstream = nil
+	p.mu.Unlock()
+
+	if upstream != nil {
+		upstream.Close(CloseNormalClosure, "player disconnected")
+	}
+}
+
+// HandoffSession moves a player's session to the cell that took them over in a
+// split or merge and re-routes their live connection, without the client
+// reconnecting. The handoff only applies while the session is still assigned
+// to fromCellID, so a late or repeated handoff cannot undo a newer assignment.
+// The target must be registered and healthy; until it is, the player stays on
+// fromCellID and the cell is expected to send the handoff again.
+func (s *DefaultGatewayServer) HandoffSession(playerID cell.PlayerID, fromCellID, toCellID cell.CellID) error {
+	target, err := s.router.GetCell(toCellID)
+	if err != nil {
+		return fmt.Errorf("cannot hand off player %s: %w", playerID, err)
+	}
+	if !target.Healthy {
+		return fmt.Errorf("cannot hand off player %s: cell %s is not healthy", playerID, toCellID)
+	}
+
+	s.sessionMutex.Lock()
+	session, exists := s.sessions[playerID]
+	if !exists {
+		s.sessionMutex.Unlock()
+		return fmt.Errorf("session not found for player %s", playerID)
+	}
+	if session.CellID != fromCellID {
+		currentCell := session.CellID
+		s.sessionMutex.Unlock()
+		if currentCell == toCellID {
+			return nil
+		}
+		return fmt.Errorf("player %s is assigned to cell %s, not %s", playerID, currentCell, fromCellID)
+	}
+	session.CellID = toCellID
+	session.AssignedAt = time.Now()
+	connectionID := session.ConnectionID
+	s.sessionMutex.Unlock()
+
+	s.logger.Info("session handed off",
+		"playerId", playerID,
+		"fromCell", fromCellID,
+		"toCell", toCellID)
+
+	// Re-route in place so gameplay pauses only for the dial to the new cell
+	s.connMutex.RLock()
+	var proxy *cellProxy
+	if conn, exists := s.connections[connectionID]; exists {
+		proxy = conn.proxy
+	}
+	s.connMutex.RUnlock()
+
+	if proxy == nil {
+		return nil
+	}
+	if _, _, err := proxy.route(); err != nil && !errors.Is(err, ErrWebSocketClosed) {
+		return fmt.Errorf("failed to re-route player %s: %w", playerID, err)
+	}
+	return nil
+}
+
+// sessionCell returns the cell a player's session is assigned to, assigning a
+// new one when the session expired or its cell is no longer healthy
+func (s *DefaultGatewayServer) sessionCell(playerID cell.PlayerID, connID ConnectionID) (*CellInfo, error) {
+	if affinity, err := s.GetSessionAffinity(playerID); err == nil {
+		if cellInfo, err := s.router.GetCell(affinity.CellID); err == nil && cellInfo.Healthy {
+			return cellInfo, nil
+		}
+	}
+
+	if err := s.CreateSession(playerID, connID); err != nil {
+		return nil, err
+	}
+	affinity, err := s.GetSessionAffinity(playerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.router.GetCell(affinity.CellID)
+}
+
+// dialCell opens a player's link to a cell. A player handed off by another
+// cell is passed the position they had there, so they keep it in the new cell.
+func (s *DefaultGatewayServer) dialCell(cellInfo *CellInfo, playerID cell.PlayerID, position *cell.WorldPosition) (*WSConn, error) {
+	query := url.Values{"playerId": {string(playerID)}}
+	if position != nil {
+		query.Set("x", strconv.FormatFloat(position.X, 'g', -1, 64))
+		query.Set("y", strconv.FormatFloat(position.Y, 'g', -1, 64))
+	}
+	target := url.URL{
+		Scheme:   "ws",
+		Host:     fmt.Sprintf("%s:%d", cellInfo.Address, cellInfo.Port),
+		Path:     s.config.CellProxy.Path,
+		RawQuery: query.Encode(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.CellProxy.DialTimeout)
+	defer cancel()
+
+	upstream, err := Dial(ctx, target.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to cell %s: %w", cellInfo.ID, err)
+	}
+	upstream.SetReadLimit(s.config.WebSocket.MaxMessageSize)
+
+	s.logger.Debug("cell link opened", "playerId", playerID, "cellId", cellInfo.ID)
+	return upstream, nil
+}
+
+// rerouteConnection moves a connection's cell link to its player's current
+// affinity in the background
+func (s *DefaultGatewayServer) rerouteConnection(connID ConnectionID) {
+	s.connMutex.RLock()
+	var proxy *cellProxy
+	if conn, exists := s.connections[connID]; exists {
+		proxy = conn.proxy
+	}
+	s.connMutex.RUnlock()
+
+	if proxy == nil {
+		return
+	}
+	go func() {
+		if _, _, err := proxy.route(); err != nil && !errors.Is(err, ErrWebSocketClosed) {
+			s.logger.Debug("failed to reroute player", "playerId", proxy.playerID, "error", err.Error())
+		}
+	}()
+}
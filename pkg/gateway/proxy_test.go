@@ -0,0 +1,318 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/astrosteveo/fleetforge/pkg/cell"
+)
+
+// fakeCell is a cell that accepts player links from the gateway, records the
+// messages it receives and echoes gameplay messages back to the player
+type fakeCell struct {
+	info     *CellInfo
+	messages chan WSMessage
+	links    chan *WSConn
+	closes   chan error
+
+	mu        sync.Mutex
+	open      []*WSConn
+	lastQuery url.Values
+}
+
+func newFakeCell(t *testing.T, id cell.CellID) *fakeCell {
+	t.Helper()
+
+	fc := &fakeCell{
+		messages: make(chan WSMessage, 16),
+		links:    make(chan *WSConn, 4),
+		closes:   make(chan error, 4),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ws" || r.URL.Query().Get("playerId") == "" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		ws, err := Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		fc.mu.Lock()
+		fc.open = append(fc.open, ws)
+		fc.lastQuery = r.URL.Query()
+		fc.mu.Unlock()
+		fc.links <- ws
+
+		for {
+			_, data, err := ws.ReadMessage()
+			if err != nil {
+				fc.closes <- err
+				return
+			}
+			var msg WSMessage
+			json.Unmarshal(data, &msg)
+			fc.messages <- msg
+			if msg.Type == WSMessageGameplay {
+				ws.WriteMessage(TextMessage, data)
+			}
+		}
+	}))
+	t.Cleanup(func() {
+		fc.mu.Lock()
+		for _, ws := range fc.open {
+			ws.closeConn()
+		}
+		fc.mu.Unlock()
+		server.Close()
+	})
+
+	addr := server.Listener.Addr().(*net.TCPAddr)
+	fc.info = &CellInfo{ID: id, Address: addr.IP.String(), Port: addr.Port, Healthy: true, Capacity: 100}
+	return fc
+}
+
+func (fc *fakeCell) waitForLink(t *testing.T) *WSConn {
+	t.Helper()
+	select {
+	case ws := <-fc.links:
+		return ws
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Cell %s was never dialed", fc.info.ID)
+		return nil
+	}
+}
+
+func (fc *fakeCell) waitForClose(t *testing.T) error {
+	t.Helper()
+	select {
+	case err := <-fc.closes:
+		return err
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Link to cell %s was never closed", fc.info.ID)
+		return nil
+	}
+}
+
+func (fc *fakeCell) waitForMessage(t *testing.T) WSMessage {
+	t.Helper()
+	select {
+	case msg := <-fc.messages:
+		return msg
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Cell %s received no message", fc.info.ID)
+		return WSMessage{}
+	}
+}
+
+func TestCellProxy_ForwardsGameplay(t *testing.T) {
+	_, server, cellA := newTestWebSocketServer(t)
+	_, ws := dialTestWebSocket(t, server, "/?playerId=proxy-player-1")
+
+	if msg := readWSMessage(t, ws); msg.Type != WSMessageConnected {
+		t.Fatalf("Expected a connected message, got %+v", msg)
+	}
+	link := cellA.waitForLink(t)
+
+	// Client messages reach the cell with the player's identity filled in by
+	// the gateway, whatever the client claimed
+	if err := ws.WriteMessage(TextMessage, []byte(`{"type":"gameplay","playerId":"spoofed","payload":{"action":"jump"}}`)); err != nil {
+		t.Fatalf("Failed to write message: %v", err)
+	}
+	received := cellA.waitForMessage(t)
+	if received.PlayerID != "proxy-player-1" || received.CellID != cellA.info.ID {
+		t.Errorf("Cell received message for player %q in cell %q", received.PlayerID, received.CellID)
+	}
+	if string(received.Payload) != `{"action":"jump"}` {
+		t.Errorf("Cell received payload %s", received.Payload)
+	}
+
+	// The echo travels back to the player
+	echo := readWSMessage(t, ws)
+	if echo.Type != WSMessageGameplay || echo.CellID != cellA.info.ID || string(echo.Payload) != `{"action":"jump"}` {
+		t.Errorf("Unexpected relayed message: %+v", echo)
+	}
+
+	// Cells can message players without being asked
+	if err := link.WriteMessage(TextMessage, []byte(`{"payload":{"event":"storm"}}`)); err != nil {
+		t.Fatalf("Failed to write from cell: %v", err)
+	}
+	pushed := readWSMessage(t, ws)
+	if pushed.Type != WSMessageGameplay || pushed.PlayerID != "proxy-player-1" || string(pushed.Payload) != `{"event":"storm"}` {
+		t.Errorf("Unexpected cell-originated message: %+v", pushed)
+	}
+}
+
+func TestCellProxy_FollowsAffinity(t *testing.T) {
+	gateway, server, cellA := newTestWebSocketServer(t)
+	_, ws := dialTestWebSocket(t, server, "/?playerId=proxy-player-2")
+
+	readWSMessage(t, ws)
+	cellA.waitForLink(t)
+
+	// The player's cell fails and another one takes over
+	cellB := newFakeCell(t, "ws-cell-2")
+	if err := gateway.RegisterCell(cellB.info); err != nil {
+		t.Fatalf("Failed to register cell: %v", err)
+	}
+	gateway.router.UpdateCellHealth(cellA.info.ID, false)
+
+	if err := ws.WriteMessage(TextMessage, []byte(`{"type":"gameplay","payload":{"action":"run"}}`)); err != nil {
+		t.Fatalf("Failed to write message: %v", err)
+	}
+
+	changed := readWSMessage(t, ws)
+	if changed.Type != WSMessageCellChanged || changed.CellID != cellB.info.ID {
+		t.Fatalf("Expected a cellChanged message for %s, got %+v", cellB.info.ID, changed)
+	}
+	cellB.waitForLink(t)
+	if received := cellB.waitForMessage(t); received.CellID != cellB.info.ID {
+		t.Errorf("Expected the message to reach %s, got %+v", cellB.info.ID, received)
+	}
+	if echo := readWSMessage(t, ws); echo.CellID != cellB.info.ID {
+		t.Errorf("Expected the echo from %s, got %+v", cellB.info.ID, echo)
+	}
+
+	affinity, err := gateway.GetSessionAffinity("proxy-player-2")
+	if err != nil || affinity.CellID != cellB.info.ID {
+		t.Errorf("Expected the session to move to %s, got %+v (%v)", cellB.info.ID, affinity, err)
+	}
+
+	// The old cell's link is closed so it can release the player
+	err = cellA.waitForClose(t)
+	if closeErr, ok := err.(*CloseError); !ok || closeErr.Code != CloseGoingAway {
+		t.Errorf("Expected the link to the old cell to close with %d, got %v", CloseGoingAway, err)
+	}
+}
+
+func TestCellProxy_ClosesLinkOnDisconnect(t *testing.T) {
+	gateway, server, cellA := newTestWebSocketServer(t)
+	_, ws := dialTestWebSocket(t, server, "/?playerId=proxy-player-3")
+
+	readWSMessage(t, ws)
+	cellA.waitForLink(t)
+
+	ws.Close(CloseNormalClosure, "")
+
+	err := cellA.waitForClose(t)
+	if closeErr, ok := err.(*CloseError); !ok || closeErr.Code != CloseNormalClosure {
+		t.Errorf("Expected the gateway to close the cell link normally, got %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for gateway.GetConnectionCount() != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if gateway.GetConnectionCount() != 0 {
+		t.Errorf("Expected the connection to be removed, got %d", gateway.GetConnectionCount())
+	}
+}
+
+func TestCellProxy_RequiresConnect(t *testing.T) {
+	_, server, _ := newTestWebSocketServer(t)
+	_, ws := dialTestWebSocket(t, server, "/")
+
+	if err := ws.WriteMessage(TextMessage, []byte(`{"type":"gameplay","payload":{}}`)); err != nil {
+		t.Fatalf("Failed to write message: %v", err)
+	}
+	if msg := readWSMessage(t, ws); msg.Type != WSMessageError {
+		t.Errorf("Expected an error before connecting, got %+v", msg)
+	}
+}
+
+func TestCellProxy_HandoffFromCell(t *testing.T) {
+	gateway, server, cellA := newTestWebSocketServer(t)
+	cellB := newFakeCell(t, "ws-cell-2")
+	_, ws := dialTestWebSocket(t, server, "/?playerId=proxy-player-4")
+
+	readWSMessage(t, ws)
+	if err := gateway.RegisterCell(cellB.info); err != nil {
+		t.Fatalf("Failed to register cell: %v", err)
+	}
+	linkA := cellA.waitForLink(t)
+
+	// Cell A splits and tells the gateway which child took the player over
+	handoff := `{"type":"handoff","cellId":"ws-cell-2","payload":{"position":{"x":12.5,"y":-3}}}`
+	if err := linkA.WriteMessage(TextMessage, []byte(handoff)); err != nil {
+		t.Fatalf("Failed to write handoff: %v", err)
+	}
+
+	changed := readWSMessage(t, ws)
+	if changed.Type != WSMessageCellChanged || changed.CellID != cellB.info.ID {
+		t.Fatalf("Expected a cellChanged message for %s, got %+v", cellB.info.ID, changed)
+	}
+	cellB.waitForLink(t)
+
+	// The player keeps their position in the cell that took them over
+	cellB.mu.Lock()
+	query := cellB.lastQuery
+	cellB.mu.Unlock()
+	if query.Get("x") != "12.5" || query.Get("y") != "-3" {
+		t.Errorf("Expected the handed off position in the link to %s, got %v", cellB.info.ID, query)
+	}
+
+	affinity, err := gateway.GetSessionAffinity("proxy-player-4")
+	if err != nil || affinity.CellID != cellB.info.ID {
+		t.Errorf("Expected the session to be handed off to %s, got %+v (%v)", cellB.info.ID, affinity, err)
+	}
+
+	// The new link is open before the old one closes, and gameplay carries on
+	// without the client reconnecting
+	err = cellA.waitForClose(t)
+	if closeErr, ok := err.(*CloseError); !ok || closeErr.Code != CloseGoingAway {
+		t.Errorf("Expected the link to the old cell to close with %d, got %v", CloseGoingAway, err)
+	}
+	if err := ws.WriteMessage(TextMessage, []byte(`{"type":"gameplay","payload":{"action":"walk"}}`)); err != nil {
+		t.Fatalf("Failed to write message: %v", err)
+	}
+	if received := cellB.waitForMessage(t); received.PlayerID != "proxy-player-4" {
+		t.Errorf("Expected the message to reach %s, got %+v", cellB.info.ID, received)
+	}
+}
+
+func TestHandoffSession(t *testing.T) {
+	gateway := NewGatewayServer(DefaultGatewayConfig(), nil)
+	for _, id := range []cell.CellID{"parent", "parent-child-1", "parent-child-1-child-1"} {
+		if err := gateway.RegisterCell(&CellInfo{ID: id, Address: "127.0.0.1", Port: 1, Healthy: true, Capacity: 100}); err != nil {
+			t.Fatalf("Failed to register cell: %v", err)
+		}
+	}
+	gateway.sessions["player-1"] = &SessionAffinity{PlayerID: "player-1", CellID: "parent", ConnectionID: "conn-1"}
+
+	// Steps run in order against the same session
+	steps := []struct {
+		name      string
+		from      cell.CellID
+		to        cell.CellID
+		expectErr bool
+	}{
+		{name: "Unknown target cell", from: "parent", to: "missing", expectErr: true},
+		{name: "Handoff to child", from: "parent", to: "parent-child-1"},
+		{name: "Repeated handoff", from: "parent", to: "parent-child-1"},
+		{name: "Handoff to grandchild", from: "parent-child-1", to: "parent-child-1-child-1"},
+		{name: "Stale handoff", from: "parent", to: "parent-child-1", expectErr: true},
+	}
+
+	for _, step := range steps {
+		err := gateway.HandoffSession("player-1", step.from, step.to)
+		if step.expectErr && err == nil {
+			t.Errorf("%s: expected handoff to fail", step.name)
+		}
+		if !step.expectErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", step.name, err)
+		}
+	}
+
+	affinity, _ := gateway.GetSessionAffinity("player-1")
+	if affinity.CellID != "parent-child-1-child-1" {
+		t.Errorf("Expected the newest handoff to win, got %s", affinity.CellID)
+	}
+	if err := gateway.HandoffSession("player-2", "parent", "parent-child-1"); err == nil {
+		t.Error("Expected handoff of a player without a session to fail")
+	}
+}
@@ -3,6 +3,7 @@ package gateway
 import (
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
@@ -100,6 +101,20 @@ func (r *CellRouter) GetAvailableCells() []*CellInfo {
 	return cells
 }
 
+// GetCell returns a registered cell by ID
+func (r *CellRouter) GetCell(cellID cell.CellID) (*CellInfo, error) {
+	r.cellMutex.RLock()
+	defer r.cellMutex.RUnlock()
+
+	cellInfo, exists := r.cells[cellID]
+	if !exists {
+		return nil, fmt.Errorf("cell %s not found", cellID)
+	}
+
+	cellCopy := *cellInfo
+	return &cellCopy, nil
+}
+
 // GetHealthyCells returns only healthy cells
 func (r *CellRouter) GetHealthyCells() []*CellInfo {
 	r.cellMutex.RLock()
@@ -134,6 +149,11 @@ func (r *CellRouter) SelectCellRoundRobin() (*CellInfo, error) {
 		return nil, fmt.Errorf("no healthy cells available")
 	}
 
+	// Map iteration order is random, so order by ID to visit every cell in turn
+	sort.Slice(healthyCells, func(i, j int) bool {
+		return healthyCells[i].ID < healthyCells[j].ID
+	})
+
 	// Simple round-robin selection
 	selectedIndex := r.roundRobin % len(healthyCells)
 	r.roundRobin++
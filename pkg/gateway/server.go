@@ -2,6 +2,8 @@ package gateway
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sync"
@@ -17,6 +19,8 @@ type DefaultGatewayServer struct {
 	server    *http.Server
 	router    *CellRouter
 	rateLimit *RateLimiter
+	health    *CellHealthChecker
+	discovery *CellDiscoverer
 
 	// Connection tracking
 	connections       map[ConnectionID]*Connection
@@ -45,10 +49,12 @@ func NewGatewayServer(config *GatewayConfig, logger Logger) *DefaultGatewayServe
 		logger = &noOpLogger{}
 	}
 
+	router := NewCellRouter(logger)
 	server := &DefaultGatewayServer{
 		config:      config,
-		router:      NewCellRouter(logger),
+		router:      router,
 		rateLimit:   NewRateLimiter(config.RateLimit.RequestsPerSecond, config.RateLimit.BurstSize, logger),
+		health:      NewCellHealthChecker(router, config, logger),
 		connections: make(map[ConnectionID]*Connection),
 		sessions:    make(map[cell.PlayerID]*SessionAffinity),
 		stopChan:    make(chan struct{}),
@@ -58,6 +64,12 @@ func NewGatewayServer(config *GatewayConfig, logger Logger) *DefaultGatewayServe
 	return server
 }
 
+// SetDiscoverySource registers cells from a discovery source. It must be
+// called before Start; the source is synced every CellDiscovery.RefreshInterval.
+func (s *DefaultGatewayServer) SetDiscoverySource(source DiscoverySource) {
+	s.discovery = NewCellDiscoverer(source, s.router, s.logger)
+}
+
 // Start starts the gateway server
 func (s *DefaultGatewayServer) Start() error {
 	// Create HTTP server
@@ -146,7 +158,9 @@ func (s *DefaultGatewayServer) HandleHTTP(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// HandleWebSocket handles WebSocket upgrade requests
+// HandleWebSocket upgrades a player connection to a WebSocket. The player is
+// identified by the playerId query parameter or a connect message, and the
+// connection is kept alive with pings until either side closes it.
 func (s *DefaultGatewayServer) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	// Apply rate limiting
 	clientIP := getClientIP(r)
@@ -155,22 +169,205 @@ func (s *DefaultGatewayServer) HandleWebSocket(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	// For now, we'll implement basic WebSocket handling
-	// In a production environment, you'd use a proper WebSocket library like gorilla/websocket
+	ws, err := Upgrade(w, r)
+	if err != nil {
+		s.logger.Debug("websocket upgrade failed", "remoteAddr", clientIP, "error", err.Error())
+		return
+	}
+	ws.SetReadLimit(s.config.WebSocket.MaxMessageSize)
 
-	// Create connection
-	conn := s.createConnection(ConnectionTypeWebSocket, r, w)
+	// The response writer belongs to the hijacked connection now
+	conn := s.createConnection(ConnectionTypeWebSocket, r, nil)
+	s.connMutex.Lock()
+	conn.WSConn = ws
+	conn.send = make(chan []byte, s.config.WebSocket.SendQueueSize)
+	s.connMutex.Unlock()
 	defer s.removeConnection(conn.ID)
+	defer ws.Close(CloseNormalClosure, "")
+	defer s.closeCellProxy(conn)
+
+	go s.webSocketWritePump(conn)
+
+	if playerID := r.URL.Query().Get("playerId"); playerID != "" {
+		s.connectWebSocketPlayer(conn, cell.PlayerID(playerID))
+	}
+
+	s.webSocketReadPump(conn)
+}
+
+// webSocketReadPump reads messages until the connection closes. Messages are
+// handled one at a time, so a client that sends faster than the gateway can
+// handle is slowed down by TCP flow control.
+func (s *DefaultGatewayServer) webSocketReadPump(conn *Connection) {
+	ws := conn.WSConn
+	pongTimeout := s.config.WebSocket.PongTimeout
+
+	ws.SetReadDeadline(time.Now().Add(pongTimeout))
+	ws.SetPongHandler(func([]byte) {
+		ws.SetReadDeadline(time.Now().Add(pongTimeout))
+		s.touchConnection(conn.ID)
+	})
+
+	for {
+		messageType, data, err := ws.ReadMessage()
+		if err != nil {
+			var closeErr *CloseError
+			if errors.As(err, &closeErr) {
+				s.logger.Debug("websocket closed", "connectionId", conn.ID, "code", closeErr.Code, "reason", closeErr.Text)
+			} else {
+				s.logger.Debug("websocket read failed", "connectionId", conn.ID, "error", err.Error())
+			}
+			return
+		}
+
+		ws.SetReadDeadline(time.Now().Add(pongTimeout))
+		s.touchConnection(conn.ID)
+
+		if messageType != TextMessage {
+			ws.Close(CloseUnsupportedData, "messages must be JSON text")
+			return
+		}
+		s.handleWebSocketMessage(conn, data)
+	}
+}
+
+// webSocketWritePump writes queued messages and keepalive pings until the
+// connection closes
+func (s *DefaultGatewayServer) webSocketWritePump(conn *Connection) {
+	ws := conn.WSConn
+	ticker := time.NewTicker(s.config.WebSocket.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case data := <-conn.send:
+			ws.SetWriteDeadline(time.Now().Add(s.config.WebSocket.WriteTimeout))
+			if err := ws.WriteMessage(TextMessage, data); err != nil {
+				s.logger.Debug("websocket write failed", "connectionId", conn.ID, "error", err.Error())
+				ws.closeConn()
+				return
+			}
+		case <-ticker.C:
+			ws.SetWriteDeadline(time.Now().Add(s.config.WebSocket.WriteTimeout))
+			if err := ws.WritePing(nil); err != nil {
+				s.logger.Debug("websocket ping failed", "connectionId", conn.ID, "error", err.Error())
+				ws.closeConn()
+				return
+			}
+		case <-ws.Done():
+			return
+		}
+	}
+}
+
+// sendWebSocket queues a message for a WebSocket connection. It waits while the
+// send queue is full, and disconnects a client that stays too slow to drain it
+// within the write timeout.
+func (s *DefaultGatewayServer) sendWebSocket(conn *Connection, msg WSMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	timer := time.NewTimer(s.config.WebSocket.WriteTimeout)
+	defer timer.Stop()
+
+	select {
+	case conn.send <- data:
+		return nil
+	case <-conn.WSConn.Done():
+		return ErrWebSocketClosed
+	case <-timer.C:
+		s.logger.Info("disconnecting slow websocket client", "connectionId", conn.ID)
+		conn.WSConn.Close(CloseTryAgainLater, "send queue full")
+		return fmt.Errorf("send queue of connection %s is full", conn.ID)
+	}
+}
 
-	// Basic WebSocket response (this would be replaced with proper WebSocket handling)
-	w.Header().Set("Upgrade", "websocket")
-	w.Header().Set("Connection", "Upgrade")
-	w.Header().Set("Sec-WebSocket-Accept", "mock-accept-key")
-	w.WriteHeader(http.StatusSwitchingProtocols)
+// handleWebSocketMessage handles a message from a WebSocket client
+func (s *DefaultGatewayServer) handleWebSocketMessage(conn *Connection, data []byte) {
+	var msg WSMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		s.sendWebSocket(conn, WSMessage{Type: WSMessageError, Error: "invalid JSON message"})
+		return
+	}
 
-	// Keep connection alive for demonstration
-	// In production, this would handle WebSocket frames
-	time.Sleep(time.Second)
+	switch msg.Type {
+	case WSMessageConnect:
+		s.connectWebSocketPlayer(conn, msg.PlayerID)
+	case WSMessageGameplay:
+		s.connMutex.RLock()
+		proxy := conn.proxy
+		s.connMutex.RUnlock()
+		if proxy == nil {
+			s.sendWebSocket(conn, WSMessage{Type: WSMessageError, Error: "connect before sending gameplay messages"})
+			return
+		}
+		if err := proxy.forward(msg); err != nil {
+			s.sendWebSocket(conn, WSMessage{Type: WSMessageError, PlayerID: proxy.playerID, Error: err.Error()})
+		}
+	default:
+		s.sendWebSocket(conn, WSMessage{Type: WSMessageError, Error: fmt.Sprintf("unsupported message type %q", msg.Type)})
+	}
+}
+
+// connectWebSocketPlayer binds a player to a WebSocket connection, assigns
+// them a cell and opens the proxy link to it
+func (s *DefaultGatewayServer) connectWebSocketPlayer(conn *Connection, playerID cell.PlayerID) {
+	if playerID == "" {
+		s.sendWebSocket(conn, WSMessage{Type: WSMessageError, Error: "playerId is required"})
+		return
+	}
+
+	proxy := newCellProxy(s, conn, playerID)
+	s.connMutex.Lock()
+	previous := conn.proxy
+	conn.PlayerID = playerID
+	conn.LastActivity = time.Now()
+	conn.proxy = proxy
+	s.connMutex.Unlock()
+	if previous != nil {
+		previous.close()
+	}
+
+	if err := s.CreateSession(playerID, conn.ID); err != nil {
+		s.sendWebSocket(conn, WSMessage{Type: WSMessageError, PlayerID: playerID, Error: fmt.Sprintf("failed to create session: %v", err)})
+		return
+	}
+	affinity, err := s.GetSessionAffinity(playerID)
+	if err != nil {
+		s.sendWebSocket(conn, WSMessage{Type: WSMessageError, PlayerID: playerID, Error: fmt.Sprintf("failed to get session: %v", err)})
+		return
+	}
+
+	s.connMutex.Lock()
+	conn.CellID = affinity.CellID
+	s.connMutex.Unlock()
+
+	s.sendWebSocket(conn, WSMessage{
+		Type:         WSMessageConnected,
+		PlayerID:     playerID,
+		CellID:       affinity.CellID,
+		ConnectionID: conn.ID,
+	})
+
+	// Open the link now so the cell can message the player before they send
+	// anything; a failure here is retried on their first gameplay message
+	if _, _, err := proxy.route(); err != nil && !errors.Is(err, ErrWebSocketClosed) {
+		s.logger.Debug("failed to connect player to cell", "playerId", playerID, "cellId", affinity.CellID, "error", err.Error())
+	}
+}
+
+// closeCellProxy closes a connection's link to its cell
+func (s *DefaultGatewayServer) closeCellProxy(conn *Connection) {
+	s.connMutex.Lock()
+	proxy := conn.proxy
+	conn.proxy = nil
+	s.connMutex.Unlock()
+
+	if proxy != nil {
+		proxy.close()
+	}
 }
 
 // GetActiveConnections returns all active connections
@@ -182,7 +379,9 @@ func (s *DefaultGatewayServer) GetActiveConnections() []*Connection {
 	for _, conn := range s.connections {
 		// Create a copy to prevent external modification
 		connCopy := *conn
-		connCopy.WSConn = nil      // Don't expose internal WebSocket connection
+		connCopy.WSConn = nil // Don't expose internal WebSocket connection
+		connCopy.send = nil
+		connCopy.proxy = nil
 		connCopy.HTTPWriter = nil  // Don't expose HTTP writer
 		connCopy.HTTPRequest = nil // Don't expose HTTP request
 		connections = append(connections, &connCopy)
@@ -241,15 +440,16 @@ func (s *DefaultGatewayServer) CreateSession(playerID cell.PlayerID, connectionI
 		return fmt.Errorf("player ID cannot be empty")
 	}
 
+	// Remember the current assignment so a move to another cell can reroute
+	// the player's connection
+	previous, _ := s.GetSessionAffinity(playerID)
+
 	// Select a cell for the player
 	selectedCell, err := s.SelectCell(playerID)
 	if err != nil {
 		return fmt.Errorf("failed to select cell: %w", err)
 	}
 
-	s.sessionMutex.Lock()
-	defer s.sessionMutex.Unlock()
-
 	// Create session affinity
 	affinity := &SessionAffinity{
 		PlayerID:     playerID,
@@ -259,13 +459,22 @@ func (s *DefaultGatewayServer) CreateSession(playerID cell.PlayerID, connectionI
 		ConnectionID: connectionID,
 	}
 
+	s.sessionMutex.Lock()
 	s.sessions[playerID] = affinity
+	s.sessionMutex.Unlock()
 
 	s.logger.Info("session created",
 		"playerId", playerID,
 		"cellId", selectedCell.ID,
 		"connectionId", connectionID)
 
+	if previous != nil && previous.CellID != selectedCell.ID {
+		s.rerouteConnection(previous.ConnectionID)
+		if previous.ConnectionID != connectionID {
+			s.rerouteConnection(connectionID)
+		}
+	}
+
 	return nil
 }
 
@@ -420,13 +629,39 @@ func (s *DefaultGatewayServer) closeAllConnections() {
 	s.connMutex.Lock()
 	defer s.connMutex.Unlock()
 
-	for connID := range s.connections {
+	for connID, conn := range s.connections {
+		if conn.WSConn != nil {
+			conn.WSConn.Close(CloseGoingAway, "gateway shutting down")
+		}
 		delete(s.connections, connID)
 	}
 
 	s.logger.Info("all connections closed")
 }
 
+// touchConnection records activity on a connection and keeps its player's session alive
+func (s *DefaultGatewayServer) touchConnection(connID ConnectionID) {
+	now := time.Now()
+
+	s.connMutex.Lock()
+	conn, exists := s.connections[connID]
+	var playerID cell.PlayerID
+	if exists {
+		conn.LastActivity = now
+		playerID = conn.PlayerID
+	}
+	s.connMutex.Unlock()
+
+	if playerID == "" {
+		return
+	}
+	s.sessionMutex.Lock()
+	if session, exists := s.sessions[playerID]; exists {
+		session.LastActivity = now
+	}
+	s.sessionMutex.Unlock()
+}
+
 func getClientIP(r *http.Request) string {
 	// Check for X-Forwarded-For header (behind proxy)
 	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
@@ -1,6 +1,7 @@
 package gateway
 
 import (
+	"encoding/json"
 	"net/http"
 	"sync"
 	"time"
@@ -31,7 +32,9 @@ type Connection struct {
 	LastActivity time.Time      `json:"lastActivity"`
 
 	// WebSocket specific fields
-	WSConn interface{} `json:"-"` // Will hold *websocket.Conn when needed
+	WSConn *WSConn     `json:"-"`
+	send   chan []byte // Outgoing messages, drained by the connection's write pump
+	proxy  *cellProxy  // Link to the player's assigned cell
 
 	// HTTP specific fields
 	HTTPWriter  http.ResponseWriter `json:"-"`
@@ -57,10 +60,29 @@ type GatewayConfig struct {
 	SessionTimeout time.Duration `json:"sessionTimeout"`
 	SessionCleanup time.Duration `json:"sessionCleanup"`
 
+	// WebSocket configuration
+	WebSocket struct {
+		PingInterval   time.Duration `json:"pingInterval"`
+		PongTimeout    time.Duration `json:"pongTimeout"`
+		WriteTimeout   time.Duration `json:"writeTimeout"`
+		MaxMessageSize int64         `json:"maxMessageSize"`
+		SendQueueSize  int           `json:"sendQueueSize"`
+	} `json:"webSocket"`
+
+	// Cell proxy configuration
+	CellProxy struct {
+		Path        string        `json:"path"`
+		DialTimeout time.Duration `json:"dialTimeout"`
+	} `json:"cellProxy"`
+
 	// Cell discovery configuration
 	CellDiscovery struct {
-		RefreshInterval time.Duration `json:"refreshInterval"`
-		HealthCheck     bool          `json:"healthCheck"`
+		RefreshInterval  time.Duration `json:"refreshInterval"`
+		HealthCheck      bool          `json:"healthCheck"`
+		HealthTimeout    time.Duration `json:"healthTimeout"`
+		FailureThreshold int           `json:"failureThreshold"`
+		SuccessThreshold int           `json:"successThreshold"`
+		EvictAfter       int           `json:"evictAfter"` // unreachable intervals before a cell is unregistered; 0 keeps it
 	} `json:"cellDiscovery"`
 }
 
@@ -79,8 +101,21 @@ func DefaultGatewayConfig() *GatewayConfig {
 	config.RateLimit.BurstSize = 20
 	config.RateLimit.CleanupInterval = 1 * time.Minute
 
+	config.WebSocket.PingInterval = 30 * time.Second
+	config.WebSocket.PongTimeout = 60 * time.Second
+	config.WebSocket.WriteTimeout = 10 * time.Second
+	config.WebSocket.MaxMessageSize = 64 * 1024
+	config.WebSocket.SendQueueSize = 64
+
+	config.CellProxy.Path = "/ws"
+	config.CellProxy.DialTimeout = 5 * time.Second
+
 	config.CellDiscovery.RefreshInterval = 30 * time.Second
 	config.CellDiscovery.HealthCheck = true
+	config.CellDiscovery.HealthTimeout = 2 * time.Second
+	config.CellDiscovery.FailureThreshold = 3
+	config.CellDiscovery.SuccessThreshold = 2
+	config.CellDiscovery.EvictAfter = 10
 
 	return config
 }
@@ -97,6 +132,26 @@ type CellInfo struct {
 	LastCheck   time.Time   `json:"lastCheck"`
 }
 
+// WebSocket message types
+const (
+	WSMessageConnect     = "connect"
+	WSMessageConnected   = "connected"
+	WSMessageGameplay    = "gameplay"
+	WSMessageCellChanged = "cellChanged"
+	WSMessageHandoff     = "handoff"
+	WSMessageError       = "error"
+)
+
+// WSMessage is the JSON envelope of messages exchanged over a WebSocket connection
+type WSMessage struct {
+	Type         string          `json:"type"`
+	PlayerID     cell.PlayerID   `json:"playerId,omitempty"`
+	CellID       cell.CellID     `json:"cellId,omitempty"`
+	ConnectionID ConnectionID    `json:"connectionId,omitempty"`
+	Payload      json.RawMessage `json:"payload,omitempty"`
+	Error        string          `json:"error,omitempty"`
+}
+
 // SessionAffinity tracks player to cell assignments for session stickiness
 type SessionAffinity struct {
 	PlayerID     cell.PlayerID `json:"playerId"`
@@ -137,6 +192,7 @@ type Gateway interface {
 	CreateSession(playerID cell.PlayerID, connectionID ConnectionID) error
 	DestroySession(playerID cell.PlayerID) error
 	GetSessionAffinity(playerID cell.PlayerID) (*SessionAffinity, error)
+	HandoffSession(playerID cell.PlayerID, fromCellID, toCellID cell.CellID) error
 
 	// Rate limiting
 	IsRateLimited(clientIP string) bool
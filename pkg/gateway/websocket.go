@@ -0,0 +1,553 @@
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// WebSocket support implemented on net/http hijacking, following RFC 6455
+
+// MessageType is the type of a WebSocket data message
+type MessageType int
+
+const (
+	TextMessage   MessageType = 1
+	BinaryMessage MessageType = 2
+)
+
+// Frame opcodes
+const (
+	opContinuation byte = 0x0
+	opText         byte = 0x1
+	opBinary       byte = 0x2
+	opClose        byte = 0x8
+	opPing         byte = 0x9
+	opPong         byte = 0xA
+)
+
+// Close codes defined by RFC 6455 section 7.4.1
+const (
+	CloseNormalClosure           = 1000
+	CloseGoingAway               = 1001
+	CloseProtocolError           = 1002
+	CloseUnsupportedData         = 1003
+	CloseNoStatusReceived        = 1005
+	CloseAbnormalClosure         = 1006
+	CloseInvalidFramePayloadData = 1007
+	ClosePolicyViolation         = 1008
+	CloseMessageTooBig           = 1009
+	CloseInternalServerErr       = 1011
+	CloseTryAgainLater           = 1013
+)
+
+// websocketGUID is appended to the client's key to compute the accept key
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxControlPayload is the largest payload a control frame may carry
+const maxControlPayload = 125
+
+// defaultMaxMessageSize limits messages when no read limit is set
+const defaultMaxMessageSize = 64 * 1024
+
+// ErrWebSocketClosed is returned when writing to a closed WebSocket connection
+var ErrWebSocketClosed = errors.New("websocket connection closed")
+
+// CloseError is returned by ReadMessage when the connection is closed, either
+// by a close frame from the peer or because the peer broke the protocol
+type CloseError struct {
+	Code int
+	Text string
+}
+
+func (e *CloseError) Error() string {
+	if e.Text == "" {
+		return fmt.Sprintf("websocket closed: %d", e.Code)
+	}
+	return fmt.Sprintf("websocket closed: %d %s", e.Code, e.Text)
+}
+
+// WSConn is a WebSocket connection
+type WSConn struct {
+	conn     net.Conn
+	reader   *bufio.Reader
+	isServer bool
+
+	readLimit   int64
+	pongHandler func(appData []byte)
+
+	writeMu   sync.Mutex
+	closeSent bool
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// newWSConn wraps an established connection. Servers expect masked frames from
+// their peer and write unmasked ones; clients do the opposite.
+func newWSConn(conn net.Conn, reader *bufio.Reader, isServer bool) *WSConn {
+	if reader == nil {
+		reader = bufio.NewReader(conn)
+	}
+	return &WSConn{
+		conn:      conn,
+		reader:    reader,
+		isServer:  isServer,
+		readLimit: defaultMaxMessageSize,
+		done:      make(chan struct{}),
+	}
+}
+
+// Upgrade performs the server side of the opening handshake and takes over the
+// request's connection. On failure an HTTP error has already been written.
+func Upgrade(w http.ResponseWriter, r *http.Request) (*WSConn, error) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return nil, fmt.Errorf("websocket upgrade requires GET, got %s", r.Method)
+	}
+	if !headerContainsToken(r.Header, "Connection", "upgrade") || !headerContainsToken(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "WebSocket upgrade required", http.StatusBadRequest)
+		return nil, fmt.Errorf("request is not a websocket upgrade")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "Unsupported WebSocket version", http.StatusUpgradeRequired)
+		return nil, fmt.Errorf("unsupported websocket version %q", r.Header.Get("Sec-WebSocket-Version"))
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		http.Error(w, "Invalid Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, fmt.Errorf("invalid websocket key %q", key)
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "WebSocket not supported", http.StatusInternalServerError)
+		return nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hijack connection: %w", err)
+	}
+
+	// The server's read and write timeouts no longer apply to the connection
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to clear connection deadlines: %w", err)
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + computeAcceptKey(key) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(response)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to write handshake response: %w", err)
+	}
+
+	// The server's reader must not be used after hijacking, so carry over
+	// anything the client sent early and read the connection directly
+	reader := bufio.NewReader(conn)
+	if buffered := rw.Reader.Buffered(); buffered > 0 {
+		early, _ := rw.Reader.Peek(buffered)
+		reader = bufio.NewReader(io.MultiReader(bytes.NewReader(append([]byte(nil), early...)), conn))
+	}
+
+	return newWSConn(conn, reader, true), nil
+}
+
+// Dial performs the client side of the opening handshake against a ws:// URL.
+// The context bounds the connection attempt and the handshake.
+func Dial(ctx context.Context, rawURL string) (*WSConn, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid websocket URL %q: %w", rawURL, err)
+	}
+	if target.Scheme != "ws" {
+		return nil, fmt.Errorf("unsupported websocket scheme %q", target.Scheme)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", target.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", target.Host, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to generate websocket key: %w", err)
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	req := &http.Request{
+		Method:     http.MethodGet,
+		URL:        target,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Host:       target.Host,
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to write handshake request: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read handshake response: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		conn.Close()
+		return nil, fmt.Errorf("websocket handshake with %s failed: %s", target.Host, resp.Status)
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") != computeAcceptKey(key) {
+		conn.Close()
+		return nil, fmt.Errorf("websocket handshake with %s returned an invalid accept key", target.Host)
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to clear connection deadlines: %w", err)
+	}
+	return newWSConn(conn, reader, false), nil
+}
+
+// computeAcceptKey returns the Sec-WebSocket-Accept value for a client key
+func computeAcceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// headerContainsToken reports whether a comma-separated header contains token
+func headerContainsToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SetReadLimit sets the largest message ReadMessage accepts. Larger messages
+// close the connection with CloseMessageTooBig.
+func (c *WSConn) SetReadLimit(limit int64) {
+	c.readLimit = limit
+}
+
+// SetReadDeadline sets the deadline for reading the next frame
+func (c *WSConn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline sets the deadline for writing frames
+func (c *WSConn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+// SetPongHandler sets the function called with each pong received while reading
+func (c *WSConn) SetPongHandler(handler func(appData []byte)) {
+	c.pongHandler = handler
+}
+
+// RemoteAddr returns the address of the peer
+func (c *WSConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Done is closed once the connection has been closed
+func (c *WSConn) Done() <-chan struct{} {
+	return c.done
+}
+
+// ReadMessage reads the next data message, reassembling fragments. Pings are
+// answered and pongs passed to the pong handler while reading. A close frame
+// from the peer is answered and returned as a *CloseError; protocol violations
+// close the connection with the matching close code.
+func (c *WSConn) ReadMessage() (MessageType, []byte, error) {
+	var messageType MessageType
+	var message []byte
+	inMessage := false
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, c.failRead(err)
+		}
+
+		switch opcode {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil && !errors.Is(err, ErrWebSocketClosed) {
+				return 0, nil, err
+			}
+			continue
+		case opPong:
+			if c.pongHandler != nil {
+				c.pongHandler(payload)
+			}
+			continue
+		case opClose:
+			closeErr := parseClosePayload(payload)
+			replyCode := closeErr.Code
+			if replyCode == CloseNoStatusReceived {
+				replyCode = CloseNormalClosure
+			}
+			c.Close(replyCode, "")
+			return 0, nil, closeErr
+		case opContinuation:
+			if !inMessage {
+				return 0, nil, c.failRead(&CloseError{Code: CloseProtocolError, Text: "unexpected continuation frame"})
+			}
+		case opText, opBinary:
+			if inMessage {
+				return 0, nil, c.failRead(&CloseError{Code: CloseProtocolError, Text: "expected continuation frame"})
+			}
+			messageType = MessageType(opcode)
+			inMessage = true
+		default:
+			return 0, nil, c.failRead(&CloseError{Code: CloseProtocolError, Text: fmt.Sprintf("unknown opcode %d", opcode)})
+		}
+
+		if int64(len(message)+len(payload)) > c.readLimit {
+			return 0, nil, c.failRead(&CloseError{Code: CloseMessageTooBig, Text: "message too big"})
+		}
+		message = append(message, payload...)
+
+		if fin {
+			if messageType == TextMessage && !utf8.Valid(message) {
+				return 0, nil, c.failRead(&CloseError{Code: CloseInvalidFramePayloadData, Text: "invalid UTF-8 in text message"})
+			}
+			return messageType, message, nil
+		}
+	}
+}
+
+// readFrame reads a single frame and unmasks its payload
+func (c *WSConn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin = header[0]&0x80 != 0
+	opcode = header[0] & 0x0F
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, &CloseError{Code: CloseProtocolError, Text: "reserved bits set"}
+	}
+
+	masked := header[1]&0x80 != 0
+	if masked != c.isServer {
+		return false, 0, nil, &CloseError{Code: CloseProtocolError, Text: "incorrect frame masking"}
+	}
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(extended[:])
+		if length>>63 != 0 {
+			return false, 0, nil, &CloseError{Code: CloseProtocolError, Text: "invalid payload length"}
+		}
+	}
+
+	if opcode >= opClose && (!fin || length > maxControlPayload) {
+		return false, 0, nil, &CloseError{Code: CloseProtocolError, Text: "invalid control frame"}
+	}
+	if length > uint64(c.readLimit) {
+		return false, 0, nil, &CloseError{Code: CloseMessageTooBig, Text: "message too big"}
+	}
+
+	var maskKey [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.reader, maskKey[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		maskBytes(maskKey, payload)
+	}
+	return fin, opcode, payload, nil
+}
+
+// failRead closes the connection after a read error. Protocol violations are
+// reported to the peer with their close code; other errors just drop it.
+func (c *WSConn) failRead(err error) error {
+	var closeErr *CloseError
+	if errors.As(err, &closeErr) {
+		c.Close(closeErr.Code, closeErr.Text)
+		return closeErr
+	}
+	c.closeConn()
+	return err
+}
+
+// parseClosePayload decodes the status code and reason of a close frame
+func parseClosePayload(payload []byte) *CloseError {
+	if len(payload) == 0 {
+		return &CloseError{Code: CloseNoStatusReceived}
+	}
+	if len(payload) == 1 {
+		return &CloseError{Code: CloseProtocolError, Text: "invalid close payload"}
+	}
+
+	code := int(binary.BigEndian.Uint16(payload))
+	reason := payload[2:]
+	if !validCloseCode(code) || !utf8.Valid(reason) {
+		return &CloseError{Code: CloseProtocolError, Text: "invalid close payload"}
+	}
+	return &CloseError{Code: code, Text: string(reason)}
+}
+
+// validCloseCode reports whether a peer may send a close code
+func validCloseCode(code int) bool {
+	switch {
+	case code >= 3000 && code <= 4999:
+		return true
+	case code < 1000 || code > 1014:
+		return false
+	}
+	switch code {
+	case 1004, CloseNoStatusReceived, CloseAbnormalClosure:
+		return false
+	}
+	return true
+}
+
+// WriteMessage writes a data message as a single frame
+func (c *WSConn) WriteMessage(messageType MessageType, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return fmt.Errorf("unsupported message type %d", messageType)
+	}
+	return c.writeFrame(byte(messageType), data)
+}
+
+// WritePing writes a ping; the peer's pong is passed to the pong handler
+func (c *WSConn) WritePing(appData []byte) error {
+	if len(appData) > maxControlPayload {
+		return fmt.Errorf("ping payload exceeds %d bytes", maxControlPayload)
+	}
+	return c.writeFrame(opPing, appData)
+}
+
+// writeFrame writes a single, final frame. Nothing may follow a close frame.
+func (c *WSConn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closeSent {
+		return ErrWebSocketClosed
+	}
+	if opcode == opClose {
+		c.closeSent = true
+	}
+
+	frame := make([]byte, 0, len(payload)+14)
+	frame = append(frame, 0x80|opcode)
+
+	var maskBit byte
+	if !c.isServer {
+		maskBit = 0x80
+	}
+	switch length := len(payload); {
+	case length <= 125:
+		frame = append(frame, maskBit|byte(length))
+	case length <= 0xFFFF:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+
+	if c.isServer {
+		frame = append(frame, payload...)
+	} else {
+		var maskKey [4]byte
+		if _, err := rand.Read(maskKey[:]); err != nil {
+			return fmt.Errorf("failed to generate mask key: %w", err)
+		}
+		frame = append(frame, maskKey[:]...)
+		start := len(frame)
+		frame = append(frame, payload...)
+		maskBytes(maskKey, frame[start:])
+	}
+
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// Close sends a close frame with the given code and reason, then closes the
+// connection. It is safe to call more than once.
+func (c *WSConn) Close(code int, reason string) error {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, reason...)
+	if len(payload) > maxControlPayload {
+		payload = payload[:maxControlPayload]
+	}
+
+	// Don't let a stalled peer hold up the close
+	c.conn.SetWriteDeadline(time.Now().Add(time.Second))
+	err := c.writeFrame(opClose, payload)
+	c.closeConn()
+	if errors.Is(err, ErrWebSocketClosed) {
+		return nil
+	}
+	return err
+}
+
+// closeConn closes the underlying connection without a close frame
+func (c *WSConn) closeConn() {
+	c.closeOnce.Do(func() {
+		c.conn.Close()
+		close(c.done)
+	})
+}
+
+// maskBytes applies the masking key to data in place
+func maskBytes(key [4]byte, data []byte) {
+	for i := range data {
+		data[i] ^= key[i%4]
+	}
+}
@@ -0,0 +1,317 @@
+package gateway
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dialTestWebSocket performs a client handshake against a test server and
+// returns the raw connection along with a client-side WebSocket over it
+func dialTestWebSocket(t *testing.T, server *httptest.Server, path string) (net.Conn, *WSConn) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to dial test server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+path, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	if err := req.Write(conn); err != nil {
+		t.Fatalf("Failed to write handshake: %v", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		t.Fatalf("Failed to read handshake response: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("Expected 101 Switching Protocols, got %d", resp.StatusCode)
+	}
+	// The accept key for this nonce is the example from RFC 6455 section 1.3
+	if accept := resp.Header.Get("Sec-WebSocket-Accept"); accept != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("Unexpected Sec-WebSocket-Accept %q", accept)
+	}
+
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	return conn, newWSConn(conn, reader, false)
+}
+
+// writeRawFrame writes a single client frame, optionally fragmented or unmasked
+func writeRawFrame(t *testing.T, conn net.Conn, fin bool, opcode byte, payload []byte, masked bool) {
+	t.Helper()
+
+	first := opcode
+	if fin {
+		first |= 0x80
+	}
+	frame := []byte{first, byte(len(payload))}
+	data := append([]byte(nil), payload...)
+	if masked {
+		key := [4]byte{1, 2, 3, 4}
+		frame[1] |= 0x80
+		frame = append(frame, key[:]...)
+		maskBytes(key, data)
+	}
+	if _, err := conn.Write(append(frame, data...)); err != nil {
+		t.Fatalf("Failed to write frame: %v", err)
+	}
+}
+
+func readWSMessage(t *testing.T, ws *WSConn) WSMessage {
+	t.Helper()
+
+	messageType, data, err := ws.ReadMessage()
+	if err != nil {
+		t.Fatalf("Failed to read message: %v", err)
+	}
+	if messageType != TextMessage {
+		t.Fatalf("Expected a text message, got type %d", messageType)
+	}
+	var msg WSMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("Failed to decode message %q: %v", data, err)
+	}
+	return msg
+}
+
+// newTestWebSocketServer starts a gateway with a single registered cell
+func newTestWebSocketServer(t *testing.T) (*DefaultGatewayServer, *httptest.Server, *fakeCell) {
+	t.Helper()
+
+	cellA := newFakeCell(t, "ws-cell-1")
+	gateway := NewGatewayServer(DefaultGatewayConfig(), nil)
+	if err := gateway.RegisterCell(cellA.info); err != nil {
+		t.Fatalf("Failed to register cell: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(gateway.HandleWebSocket))
+	t.Cleanup(server.Close)
+	return gateway, server, cellA
+}
+
+func TestComputeAcceptKey(t *testing.T) {
+	if got := computeAcceptKey("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("computeAcceptKey() = %q, expected the RFC 6455 example", got)
+	}
+}
+
+func TestHandleWebSocket_RejectsInvalidUpgrades(t *testing.T) {
+	_, server, _ := newTestWebSocketServer(t)
+
+	tests := []struct {
+		name     string
+		method   string
+		headers  map[string]string
+		expected int
+	}{
+		{
+			name:     "Plain HTTP request",
+			method:   http.MethodGet,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "Wrong method",
+			method:   http.MethodPost,
+			expected: http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "Unsupported version",
+			method: http.MethodGet,
+			headers: map[string]string{
+				"Connection": "Upgrade", "Upgrade": "websocket",
+				"Sec-WebSocket-Version": "8", "Sec-WebSocket-Key": "dGhlIHNhbXBsZSBub25jZQ==",
+			},
+			expected: http.StatusUpgradeRequired,
+		},
+		{
+			name:   "Invalid key",
+			method: http.MethodGet,
+			headers: map[string]string{
+				"Connection": "keep-alive, Upgrade", "Upgrade": "websocket",
+				"Sec-WebSocket-Version": "13", "Sec-WebSocket-Key": "short",
+			},
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, server.URL, nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestHandleWebSocket_Session(t *testing.T) {
+	gateway, server, _ := newTestWebSocketServer(t)
+	_, ws := dialTestWebSocket(t, server, "/?playerId=ws-player-1")
+
+	// The player is assigned a cell as soon as the connection opens
+	msg := readWSMessage(t, ws)
+	if msg.Type != WSMessageConnected || msg.PlayerID != "ws-player-1" || msg.CellID != "ws-cell-1" {
+		t.Fatalf("Unexpected connected message: %+v", msg)
+	}
+	if gateway.GetConnectionCount() != 1 {
+		t.Errorf("Expected 1 active connection, got %d", gateway.GetConnectionCount())
+	}
+	if _, err := gateway.GetSessionAffinity("ws-player-1"); err != nil {
+		t.Errorf("Expected a session for the player: %v", err)
+	}
+
+	// Pings are answered while the server reads
+	pongs := make(chan []byte, 1)
+	ws.SetPongHandler(func(appData []byte) { pongs <- appData })
+	if err := ws.WritePing([]byte("keepalive")); err != nil {
+		t.Fatalf("Failed to write ping: %v", err)
+	}
+
+	// Unknown messages are answered with an error, which also delivers the pong
+	if err := ws.WriteMessage(TextMessage, []byte(`{"type":"teleport"}`)); err != nil {
+		t.Fatalf("Failed to write message: %v", err)
+	}
+	if msg := readWSMessage(t, ws); msg.Type != WSMessageError {
+		t.Errorf("Expected an error message, got %+v", msg)
+	}
+	select {
+	case appData := <-pongs:
+		if string(appData) != "keepalive" {
+			t.Errorf("Expected the pong to echo the ping payload, got %q", appData)
+		}
+	default:
+		t.Error("Expected a pong for the ping")
+	}
+
+	// A normal close is echoed and the connection is removed
+	if err := ws.writeFrame(opClose, []byte{0x03, 0xE8}); err != nil {
+		t.Fatalf("Failed to write close frame: %v", err)
+	}
+	_, _, err := ws.ReadMessage()
+	var closeErr *CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != CloseNormalClosure {
+		t.Fatalf("Expected a normal close, got %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for gateway.GetConnectionCount() != 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if gateway.GetConnectionCount() != 0 {
+		t.Errorf("Expected the connection to be removed after closing, got %d", gateway.GetConnectionCount())
+	}
+}
+
+func TestHandleWebSocket_FragmentedMessage(t *testing.T) {
+	_, server, _ := newTestWebSocketServer(t)
+	conn, ws := dialTestWebSocket(t, server, "/")
+
+	// A connect message split across a text frame and a continuation frame
+	message := `{"type":"connect","playerId":"ws-player-2"}`
+	writeRawFrame(t, conn, false, opText, []byte(message[:10]), true)
+	writeRawFrame(t, conn, true, opContinuation, []byte(message[10:]), true)
+
+	msg := readWSMessage(t, ws)
+	if msg.Type != WSMessageConnected || msg.PlayerID != "ws-player-2" {
+		t.Errorf("Unexpected response to a fragmented connect: %+v", msg)
+	}
+}
+
+func TestHandleWebSocket_ProtocolErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		write    func(t *testing.T, conn net.Conn)
+		wantCode int
+	}{
+		{
+			name: "Unmasked client frame",
+			write: func(t *testing.T, conn net.Conn) {
+				writeRawFrame(t, conn, true, opText, []byte(`{}`), false)
+			},
+			wantCode: CloseProtocolError,
+		},
+		{
+			name: "Unexpected continuation",
+			write: func(t *testing.T, conn net.Conn) {
+				writeRawFrame(t, conn, true, opContinuation, []byte(`{}`), true)
+			},
+			wantCode: CloseProtocolError,
+		},
+		{
+			name: "Invalid UTF-8",
+			write: func(t *testing.T, conn net.Conn) {
+				writeRawFrame(t, conn, true, opText, []byte{0xff, 0xfe}, true)
+			},
+			wantCode: CloseInvalidFramePayloadData,
+		},
+		{
+			name: "Binary message",
+			write: func(t *testing.T, conn net.Conn) {
+				writeRawFrame(t, conn, true, opBinary, []byte{1, 2, 3}, true)
+			},
+			wantCode: CloseUnsupportedData,
+		},
+		{
+			name: "Oversized control frame",
+			write: func(t *testing.T, conn net.Conn) {
+				frame := []byte{0x80 | opPing, 0x80 | 126, 0x00, 0x80, 1, 2, 3, 4}
+				conn.Write(append(frame, make([]byte, 128)...))
+			},
+			wantCode: CloseProtocolError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, server, _ := newTestWebSocketServer(t)
+			conn, ws := dialTestWebSocket(t, server, "/")
+
+			tt.write(t, conn)
+
+			_, _, err := ws.ReadMessage()
+			var closeErr *CloseError
+			if !errors.As(err, &closeErr) {
+				t.Fatalf("Expected the server to close the connection, got %v", err)
+			}
+			if closeErr.Code != tt.wantCode {
+				t.Errorf("Expected close code %d, got %d (%s)", tt.wantCode, closeErr.Code, closeErr.Text)
+			}
+		})
+	}
+}
+
+func TestHandleWebSocket_MessageTooBig(t *testing.T) {
+	gateway, server, _ := newTestWebSocketServer(t)
+	gateway.config.WebSocket.MaxMessageSize = 16
+	_, ws := dialTestWebSocket(t, server, "/")
+
+	if err := ws.WriteMessage(TextMessage, []byte(strings.Repeat("x", 64))); err != nil {
+		t.Fatalf("Failed to write message: %v", err)
+	}
+
+	_, _, err := ws.ReadMessage()
+	var closeErr *CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != CloseMessageTooBig {
+		t.Errorf("Expected close code %d, got %v", CloseMessageTooBig, err)
+	}
+}